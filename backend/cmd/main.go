@@ -0,0 +1,508 @@
+// Command main starts the spoXpro HTTP API server.
+package main
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/addressvalidation"
+	"github.com/YoungGoofy/shopping/internal/cache"
+	"github.com/YoungGoofy/shopping/internal/carrier"
+	"github.com/YoungGoofy/shopping/internal/config"
+	"github.com/YoungGoofy/shopping/internal/dblog"
+	"github.com/YoungGoofy/shopping/internal/errorreport"
+	"github.com/YoungGoofy/shopping/internal/events"
+	"github.com/YoungGoofy/shopping/internal/fx"
+	"github.com/YoungGoofy/shopping/internal/handlers"
+	"github.com/YoungGoofy/shopping/internal/lock"
+	"github.com/YoungGoofy/shopping/internal/logrotate"
+	"github.com/YoungGoofy/shopping/internal/mailer"
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/migrate"
+	"github.com/YoungGoofy/shopping/internal/orderevents"
+	"github.com/YoungGoofy/shopping/internal/payments"
+	"github.com/YoungGoofy/shopping/internal/queue"
+	"github.com/YoungGoofy/shopping/internal/ratelimit"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/repository/redis"
+	"github.com/YoungGoofy/shopping/internal/retry"
+	"github.com/YoungGoofy/shopping/internal/routes"
+	"github.com/YoungGoofy/shopping/internal/scheduler"
+	"github.com/YoungGoofy/shopping/internal/search"
+	"github.com/YoungGoofy/shopping/internal/seed"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/YoungGoofy/shopping/internal/sms"
+	"github.com/YoungGoofy/shopping/internal/validation"
+	"github.com/YoungGoofy/shopping/internal/webpush"
+	"github.com/gin-gonic/gin"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func main() {
+	logrus.SetFormatter(&logrus.JSONFormatter{})
+	validation.UseJSONFieldNames()
+
+	cfg, err := config.LoadEnv()
+	if err != nil {
+		logrus.WithError(err).Fatal("failed to load config")
+	}
+	if err := cfg.Validate(); err != nil {
+		logrus.Fatal(err)
+	}
+
+	if level, err := logrus.ParseLevel(cfg.Log.Level); err == nil {
+		logrus.SetLevel(level)
+	}
+	var logWriter *logrotate.Writer
+	if cfg.Log.OutputFile != "" {
+		logWriter, err = logrotate.New(cfg.Log.OutputFile, cfg.Log.MaxSizeMB, cfg.Log.MaxBackups, cfg.Log.MaxAgeDays)
+		if err != nil {
+			logrus.WithError(err).Fatal("failed to open log file")
+		}
+		logrus.SetOutput(logWriter)
+	}
+	if cfg.ErrorReporting.DSN != "" {
+		logrus.AddHook(errorreport.NewHook(cfg.ErrorReporting.DSN, cfg.ErrorReporting.Environment))
+	}
+
+	// appCtx governs every background poller started below (carrier
+	// tracking, the collection scheduler, the outbox relay, exchange
+	// rates). Canceling it on shutdown stops them from starting new work
+	// while the HTTP server drains its in-flight requests.
+	appCtx, cancelApp := context.WithCancel(context.Background())
+	defer cancelApp()
+
+	rc := startupRetryConfig(cfg.Startup)
+	gormConfig := &gorm.Config{
+		Logger: dblog.New(time.Duration(cfg.Database.SlowQueryThresholdMS) * time.Millisecond),
+	}
+
+	var db *gorm.DB
+	err = retry.Do(context.Background(), rc, func() error {
+		db, err = gorm.Open(postgres.Open(cfg.Database.DSN()), gormConfig)
+		return err
+	})
+	if err != nil {
+		logrus.WithError(err).Fatal("failed to connect to database")
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		logrus.WithError(err).Fatal("failed to get underlying sql.DB")
+	}
+	if cfg.Database.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+	}
+	if cfg.Database.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+	}
+	if cfg.Database.ConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(time.Duration(cfg.Database.ConnMaxLifetime) * time.Minute)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrate.Up(sqlDB); err != nil {
+			logrus.WithError(err).Fatal("failed to run migrations")
+		}
+		logrus.Info("migrations applied")
+		return
+	}
+
+	if err := migrate.Verify(sqlDB); err != nil {
+		logrus.WithError(err).Fatal("database schema is not up to date")
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		if err := seed.Run(context.Background(), db); err != nil {
+			logrus.WithError(err).Fatal("failed to seed database")
+		}
+		logrus.Info("database seeded")
+		return
+	}
+
+	rdb := goredis.NewClient(&goredis.Options{
+		Addr:     cfg.Redis.Addr(),
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	if err := retry.Do(context.Background(), rc, func() error {
+		return rdb.Ping(context.Background()).Err()
+	}); err != nil {
+		logrus.WithError(err).Fatal("failed to connect to redis")
+	}
+	locks := lock.NewManager(rdb)
+	rateLimiter := ratelimit.NewLimiter(rdb)
+	orderEventsBroker := orderevents.NewBroker(rdb)
+	jobScheduler := scheduler.New(locks)
+	eventBus := events.NewLocalBus()
+
+	var replicaDBs []*gorm.DB
+	for _, replicaCfg := range cfg.Database.Replicas {
+		var replicaDB *gorm.DB
+		err := retry.Do(context.Background(), rc, func() error {
+			var err error
+			replicaDB, err = gorm.Open(postgres.Open(replicaCfg.DSN()), gormConfig)
+			return err
+		})
+		if err != nil {
+			logrus.WithError(err).Fatal("failed to connect to read replica")
+		}
+		replicaDBs = append(replicaDBs, replicaDB)
+	}
+	catalogDB := psql.NewReplicatedDB(db, replicaDBs...)
+
+	users := psql.NewUserRepository(db)
+	userCache := service.NewUserCacheService(context.Background(), users, rdb)
+	orders := psql.NewOrderRepository(db)
+	auditLogs := psql.NewAuditLogRepository(db)
+	products := psql.NewProductRepository(catalogDB)
+	categories := psql.NewCategoryRepository(catalogDB)
+	cart := psql.NewCartRepository(db)
+	reviews := psql.NewReviewRepository(db)
+	reviewVotes := psql.NewReviewVoteRepository(db)
+	reviewReplies := psql.NewReviewReplyRepository(db)
+	coupons := psql.NewCouponRepository(db)
+	cartCoupons := psql.NewCartCouponRepository(db)
+	shippingZones := psql.NewShippingZoneRepository(db)
+	shippingMethods := psql.NewShippingMethodRepository(db)
+	addresses := psql.NewAddressRepository(db)
+	savedItems := psql.NewSavedItemRepository(db)
+	cartShares := psql.NewCartShareRepository(db)
+	wishlist := psql.NewWishlistRepository(db)
+	wishlistShares := psql.NewWishlistShareRepository(db)
+	paymentAttempts := psql.NewPaymentRepository(db)
+	paymentWebhookLog := psql.NewPaymentWebhookEventRepository(db)
+	refunds := psql.NewRefundRepository(db)
+	paymentMethods := psql.NewPaymentMethodRepository(db)
+	savedCards := psql.NewSavedCardRepository(db)
+	ledgerEntries := psql.NewLedgerEntryRepository(db)
+	ledger := service.NewLedgerService(ledgerEntries)
+	shipments := psql.NewShipmentRepository(db)
+	carrierProvider := carrier.NewCDEKProvider(cfg.Carrier.AccountID, cfg.Carrier.Secure)
+	carrierTracking := service.NewCarrierService(carrierProvider, shipments)
+	carrierTracking.Start(appCtx, time.Duration(cfg.Carrier.PollIntervalMinutes)*time.Minute)
+	pickupPoints := psql.NewPickupPointRepository(db)
+	pickupPointSync := service.NewPickupPointService(carrierProvider, pickupPoints)
+	deliverySlots := psql.NewDeliverySlotRepository(db)
+	deliverySlotService := service.NewDeliverySlotService(deliverySlots)
+	collections := psql.NewCollectionRepository(db)
+	collectionScheduler := service.NewCollectionSchedulerService(collections)
+	jobScheduler.Register(scheduler.Job{Name: "collections", Interval: time.Duration(cfg.Catalog.CollectionCheckIntervalMinutes) * time.Minute, Run: collectionScheduler.Run})
+	shopSettingsRepo := psql.NewShopSettingsRepository(db)
+	shopSettingsCache := redis.NewShopSettingsCache(rdb)
+	shopSettings := service.NewShopSettingsService(shopSettingsRepo, shopSettingsCache)
+	featureFlags := psql.NewFeatureFlagRepository(db)
+	warehouses := psql.NewWarehouseRepository(db)
+	warehouseStock := psql.NewWarehouseStockRepository(db)
+	productTranslations := psql.NewProductTranslationRepository(db)
+	categoryTranslations := psql.NewCategoryTranslationRepository(db)
+	paymentMethodEligibility := service.NewPaymentMethodEligibilityService()
+	paymentProvider := payments.NewYooKassaProvider(cfg.Payments.YooKassa.ShopID, cfg.Payments.YooKassa.SecretKey)
+	outboxEvents := psql.NewOutboxEventRepository(db)
+	outboxRelay := service.NewOutboxRelayService(outboxEvents, cfg.Outbox.WebhookURLs)
+	outboxRelay.Start(appCtx, time.Duration(cfg.Outbox.PollIntervalSeconds)*time.Second)
+	tokens := redis.NewTokenRepository(rdb)
+	guestCart := redis.NewGuestCartRepository(rdb)
+	deliveryCache := redis.NewDeliveryEstimateCache(rdb)
+	exchangeRateCache := redis.NewExchangeRateCache(rdb)
+	fxSource := fx.NewAPISource(cfg.FX.APIKey)
+	addressValidator := addressvalidation.NewDaDataValidator(cfg.AddressValidation.APIKey, cfg.AddressValidation.Secret)
+	exchangeRates := service.NewExchangeRateService(fxSource, exchangeRateCache, cfg.FX.BaseCurrency)
+	jobScheduler.Register(scheduler.Job{Name: "exchange_rates", Interval: time.Duration(cfg.FX.RefreshIntervalMinutes) * time.Minute, Run: exchangeRates.Refresh})
+	reorderSuggestions := service.NewReorderSuggestionService(products, cache.New(rdb, "reorder", 0),
+		cfg.Inventory.LowStockThreshold, cfg.Inventory.LowStockLookbackDays, cfg.Inventory.LowStockLeadTimeDays)
+	reorderSuggestions.Start(appCtx, time.Duration(cfg.Inventory.LowStockRefreshIntervalMinutes)*time.Minute)
+	searchIndexJobs := psql.NewSearchIndexJobRepository(db)
+	searchEngine := search.NewMeilisearchEngine(cfg.Search.Host, cfg.Search.APIKey, cfg.Search.Index)
+	searchIndexer := service.NewSearchIndexService(searchIndexJobs, products, searchEngine)
+	searchIndexer.Start(appCtx, time.Duration(cfg.Search.SyncIntervalSeconds)*time.Second)
+	popularQueries := redis.NewPopularQueryRepository(rdb)
+	searchSuggest := service.NewSearchSuggestService(products, categories, popularQueries, cache.New(rdb, "search_suggest", 5*time.Minute))
+	searchAnalytics := psql.NewSearchAnalyticsRepository(db)
+	smsLogs := psql.NewSMSLogRepository(db)
+	pushSubscriptions := psql.NewPushSubscriptionRepository(db)
+	pushSender := webpush.NewSender(
+		webpush.VAPIDKeys{Subject: cfg.WebPush.Subject, PublicKey: cfg.WebPush.PublicKey, PrivateKey: cfg.WebPush.PrivateKey},
+		time.Duration(cfg.WebPush.TTLSeconds)*time.Second,
+	)
+	pushNotifications := service.NewPushNotificationService(pushSender, pushSubscriptions, users, wishlist)
+	emailLogs := psql.NewEmailLogRepository(db)
+	var mailerProvider mailer.Provider
+	switch cfg.Mailer.Provider {
+	case "smtp":
+		mailerProvider = mailer.NewSMTPProvider(cfg.Mailer.SMTP.Host, cfg.Mailer.SMTP.Port, cfg.Mailer.SMTP.Username, cfg.Mailer.SMTP.Password)
+	default:
+		mailerProvider = mailer.NewAPIProvider(cfg.Mailer.API.Host, cfg.Mailer.API.APIKey)
+	}
+	mailerRenderer := mailer.NewRenderer()
+	emailService := service.NewEmailService(mailerProvider, mailerRenderer, emailLogs, shopSettingsRepo, cfg.Mailer.From, cfg.Mailer.MaxAttempts, time.Duration(cfg.Mailer.RetryDelaySeconds)*time.Second)
+	eventBus.Subscribe(events.UserRegistered, func(ctx context.Context, event events.Event) error {
+		payload := event.Payload.(events.UserRegisteredPayload)
+		return emailService.Send(ctx, payload.Email, "welcome", map[string]interface{}{"Name": payload.FirstName})
+	})
+	eventBus.Subscribe(events.ProductStockChanged, func(ctx context.Context, event events.Event) error {
+		payload := event.Payload.(events.ProductStockChangedPayload)
+		return searchIndexer.EnqueueUpsert(ctx, payload.ProductID)
+	})
+	var smsProvider sms.Provider = sms.NewTwilioProvider(cfg.SMS.Twilio.AccountSID, cfg.SMS.Twilio.AuthToken)
+	smsSenders := make([]service.SMSSender, len(cfg.SMS.Senders))
+	for i, sender := range cfg.SMS.Senders {
+		smsSenders[i] = service.SMSSender{Country: sender.Country, From: sender.From}
+	}
+	smsService := service.NewSMSService(smsProvider, smsLogs, cfg.SMS.DefaultFrom, smsSenders, cfg.SMS.MaxAttempts, time.Duration(cfg.SMS.RetryDelaySeconds)*time.Second)
+	notificationLogs := psql.NewNotificationLogRepository(db)
+	notifications := service.NewNotificationService(users, notificationLogs, emailService, smsService, pushNotifications)
+	orderCancellation := service.NewOrderCancellationService(orders, time.Duration(cfg.Orders.UnpaidCancelAfterMinutes)*time.Minute)
+	jobScheduler.Register(scheduler.Job{Name: "unpaid_order_cancellation", Interval: time.Duration(cfg.Orders.CancelCheckIntervalMinutes) * time.Minute, Run: orderCancellation.Run})
+	reportsCache := cache.New(rdb, "reports", 0)
+	reportPrecompute := service.NewReportPrecomputeService(orders, users, reviews, refunds, reportsCache)
+	jobScheduler.Register(scheduler.Job{Name: "report_precompute", Interval: time.Duration(cfg.Reports.PrecomputeIntervalMinutes) * time.Minute, Run: reportPrecompute.Run})
+	abandonedCartReminders := psql.NewAbandonedCartReminderRepository(db)
+	abandonedCartReminderService := service.NewAbandonedCartReminderService(cart, users, abandonedCartReminders, coupons, emailService, cfg.Server.BaseURL,
+		time.Duration(cfg.Cart.AbandonedAfterDays)*24*time.Hour, time.Duration(cfg.Cart.ReminderIntervalHours)*time.Hour, cfg.Cart.MaxReminders,
+		cfg.Cart.ReminderCouponPercent, time.Duration(cfg.Cart.ReminderCouponValidDays)*24*time.Hour)
+	jobScheduler.Register(scheduler.Job{Name: "abandoned_cart_reminders", Interval: time.Duration(cfg.Cart.CheckIntervalMinutes) * time.Minute, Run: abandonedCartReminderService.Run})
+	cleanup := service.NewCleanupService(outboxEvents, paymentWebhookLog, time.Duration(cfg.Cleanup.OutboxRetentionDays)*24*time.Hour, time.Duration(cfg.Cleanup.WebhookLogRetentionDays)*24*time.Hour)
+	jobScheduler.Register(scheduler.Job{Name: "expired_data_cleanup", Interval: time.Duration(cfg.Cleanup.IntervalMinutes) * time.Minute, Run: cleanup.Run})
+	jobScheduler.Start(appCtx)
+	browsingHistory := redis.NewBrowsingHistoryRepository(rdb)
+	productViews := redis.NewProductViewRepository(rdb)
+	recommendations := service.NewRecommendationService(orders, browsingHistory, cache.New(rdb, "recommendations", 0),
+		cfg.Recommendation.LookbackDays, cfg.Recommendation.AlsoBoughtLimit, cfg.Recommendation.ForYouLimit)
+	recommendations.Start(appCtx, time.Duration(cfg.Recommendation.RefreshIntervalMinutes)*time.Minute)
+	validation := service.NewProductValidationService(cfg.Catalog.PublishThreshold)
+	media := service.NewMediaService(products)
+	userExport := service.NewUserExportService(users, addresses, orders, reviews)
+	promo := service.NewCouponService()
+	totals := service.NewTotalsService(cfg.Tax.Rate, cfg.Tax.Mode, cfg.Shipping.FlatRate, cfg.Shipping.FreeShippingOver)
+	stock := service.NewStockValidationService()
+	jobs := queue.New()
+
+	jwtExpiry := time.Duration(cfg.JWT.ExpiryHour) * time.Hour
+
+	deps := routes.Dependencies{
+		Health:                  handlers.NewHealthHandler(db, rdb),
+		AuthHandler:             handlers.NewAuthHandler(users, tokens, cart, guestCart, products, eventBus, cfg.JWT.Secret, jwtExpiry),
+		AuditLogs:               auditLogs,
+		AdminAuditLog:           handlers.NewAdminAuditLogHandler(auditLogs),
+		AdminDashboard:          handlers.NewAdminDashboardHandler(orders, users, reviews, refunds, reportsCache),
+		AdminReports:            handlers.NewAdminReportHandler(orders, products, searchAnalytics, cache.New(rdb, "analytics", 10*time.Minute)),
+		AdminCustomers:          handlers.NewAdminCustomerHandler(orders),
+		AdminInventoryDashboard: handlers.NewAdminInventoryDashboardHandler(reorderSuggestions),
+		AdminOrders:             handlers.NewAdminOrderHandler(orders, orderEventsBroker, pushNotifications, notifications, cfg.Server.BaseURL),
+		AdminProducts:           handlers.NewAdminProductHandler(products, validation, searchIndexJobs),
+		Search:                  handlers.NewSearchHandler(searchEngine, products, searchSuggest, searchAnalytics),
+		SMS:                     handlers.NewSMSHandler(smsLogs),
+		Push:                    handlers.NewPushHandler(pushSubscriptions),
+		Notifications:           handlers.NewNotificationHandler(notificationLogs),
+		AdminMedia:              handlers.NewAdminMediaHandler(media, jobs),
+		AdminUsers:              handlers.NewAdminUserHandler(tokens, userCache, users, orders),
+		AdminCarts:              handlers.NewAdminCartHandler(cart, cfg.Cart.AbandonedAfterDays),
+		AdminCoupons:            handlers.NewAdminCouponHandler(coupons),
+		AdminReviews:            handlers.NewAdminReviewHandler(reviews, reviewReplies, products, notifications, cfg.Server.BaseURL),
+		AdminPayments:           handlers.NewAdminPaymentHandler(db, paymentAttempts, refunds, orders, paymentProvider, ledger),
+		AdminPaymentMethods:     handlers.NewAdminPaymentMethodHandler(paymentMethods),
+		AdminLedger:             handlers.NewAdminLedgerHandler(ledgerEntries),
+		AdminShippingMethods:    handlers.NewAdminShippingMethodHandler(shippingMethods),
+		AdminPickupPoints:       handlers.NewAdminPickupPointHandler(pickupPointSync, jobs),
+		AdminDeliverySlots:      handlers.NewAdminDeliverySlotHandler(deliverySlots),
+		AdminCollections:        handlers.NewAdminCollectionHandler(collections, media),
+		AdminSettings:           handlers.NewAdminSettingsHandler(shopSettings),
+		AdminFeatureFlags:       handlers.NewAdminFeatureFlagHandler(featureFlags),
+		AdminWarehouses:         handlers.NewAdminWarehouseHandler(warehouses),
+		AdminWarehouseStock:     handlers.NewAdminWarehouseStockHandler(warehouseStock, products, locks, pushNotifications, eventBus),
+		Availability:            handlers.NewAvailabilityHandler(warehouseStock),
+		Delivery:                handlers.NewDeliveryHandler(shippingZones, deliveryCache),
+		AdminSEO:                handlers.NewAdminSEOHandler(products, categories),
+		AdminTranslations:       handlers.NewAdminTranslationHandler(productTranslations, categoryTranslations),
+		Products:                handlers.NewProductHandler(products, orders, productViews, cache.New(rdb, "homepage", 15*time.Minute)),
+		Sitemap:                 handlers.NewSitemapHandler(products, categories, cfg.Server.BaseURL),
+		Orders:                  handlers.NewOrderHandler(db, orders, products, cart, cartCoupons, pickupPoints, deliverySlotService, promo, totals, stock, ledger, cfg.Payments.Currency, orderEventsBroker),
+		Cart:                    handlers.NewCartHandler(cart, guestCart, products, coupons, cartCoupons, savedItems, cartShares, promo, totals, stock, shippingMethods),
+		Reviews:                 handlers.NewReviewHandler(reviews, orders, reviewVotes, cfg.Reviews.MaxPerDay, cfg.Reviews.SoftMaxLength, cfg.Reviews.RequirePurchase, cfg.Reviews.MaxPhotos),
+		Wishlist:                handlers.NewWishlistHandler(wishlist, wishlistShares, cart),
+		Payments:                handlers.NewPaymentHandler(orders, paymentAttempts, paymentWebhookLog, paymentMethods, savedCards, paymentMethodEligibility, paymentProvider, cfg.Payments.Provider, cfg.Payments.Currency, cfg.Payments.ReturnURL, cfg.Payments.MaxRetries, ledger, orderEventsBroker),
+		SavedCards:              handlers.NewSavedCardHandler(savedCards),
+		ExchangeRates:           handlers.NewExchangeRateHandler(exchangeRates),
+		Shipping:                handlers.NewShippingHandler(products, shippingMethods, cfg.Shipping.FreeShippingOver),
+		Addresses:               handlers.NewAddressHandler(addresses, addressValidator),
+		UserProfile:             handlers.NewUserProfileHandler(tokens, media, userCache),
+		UserExport:              handlers.NewUserExportHandler(userExport, jobs),
+		UserStats:               handlers.NewUserStatsHandler(orders, refunds),
+		Unsubscribe:             handlers.NewUnsubscribeHandler(userCache, cfg.JWT.Secret),
+		Tracking:                handlers.NewTrackingHandler(orders, shipments),
+		PickupPoints:            handlers.NewPickupPointHandler(pickupPoints),
+		DeliverySlots:           handlers.NewDeliverySlotHandler(deliverySlotService),
+		Collections:             handlers.NewCollectionHandler(collections),
+		ProductPage:             handlers.NewProductPageHandler(products, reviews, productTranslations, browsingHistory, productViews),
+		Recommendations:         handlers.NewRecommendationHandler(recommendations),
+		CatalogBatch:            handlers.NewCatalogBatchHandler(products, categories),
+		Settings:                handlers.NewSettingsHandler(shopSettings),
+		ShopSettings:            shopSettings,
+		Tokens:                  tokens,
+		JWTSecret:               cfg.JWT.Secret,
+		AllowDegradedAuth:       cfg.JWT.AllowDegradedAuth,
+		CORS:                    cfg.CORS,
+		Gzip:                    cfg.Gzip,
+		RateLimiter:             rateLimiter,
+		RateLimit:               cfg.RateLimit,
+	}
+
+	gin.SetMode(cfg.Server.Mode)
+	r := gin.New()
+	r.Use(middleware.Recovery())
+	routes.Register(r, deps)
+
+	addr := cfg.Server.Addr()
+
+	srv := &http.Server{Addr: addr, Handler: r}
+
+	go func() {
+		logrus.WithField("addr", addr).Info("starting server")
+		var err error
+		if cfg.Server.TLSEnabled() {
+			err = srv.ListenAndServeTLS(cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile)
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			logrus.WithError(err).Fatal("server failed")
+		}
+	}()
+
+	var redirectSrv *http.Server
+	if cfg.Server.HTTPRedirectPort != 0 {
+		redirectAddr := ":" + strconv.Itoa(cfg.Server.HTTPRedirectPort)
+		redirectSrv = &http.Server{
+			Addr: redirectAddr,
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				target := "https://" + req.Host + req.URL.RequestURI()
+				http.Redirect(w, req, target, http.StatusMovedPermanently)
+			}),
+		}
+		go func() {
+			logrus.WithField("addr", redirectAddr).Info("starting http->https redirect listener")
+			if err := redirectSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logrus.WithError(err).Error("redirect listener failed")
+			}
+		}()
+	}
+
+	var diagSrv *http.Server
+	if cfg.Diagnostics.Enabled {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+		mux.Handle("/debug/vars", expvar.Handler())
+
+		diagAddr := fmt.Sprintf("%s:%d", cfg.Diagnostics.Host, cfg.Diagnostics.Port)
+		diagSrv = &http.Server{Addr: diagAddr, Handler: mux}
+		go func() {
+			logrus.WithField("addr", diagAddr).Info("starting diagnostics listener")
+			if err := diagSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logrus.WithError(err).Error("diagnostics listener failed")
+			}
+		}()
+	}
+
+	// SIGHUP toggles between debug and the configured level without a
+	// restart, e.g. to dig into a live incident; SIGINT/SIGTERM begin the
+	// shutdown sequence below.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+	configuredLevel := logrus.GetLevel()
+	debugging := false
+	var sig os.Signal
+	for sig = range signals {
+		if sig == syscall.SIGHUP {
+			debugging = !debugging
+			if debugging {
+				logrus.SetLevel(logrus.DebugLevel)
+				logrus.Info("SIGHUP received: log level raised to debug")
+			} else {
+				logrus.SetLevel(configuredLevel)
+				logrus.WithField("level", configuredLevel).Info("SIGHUP received: log level restored")
+			}
+			continue
+		}
+		break
+	}
+	logrus.WithField("signal", sig).Info("shutting down")
+
+	// Stop the background pollers from picking up new work before draining
+	// requests already in flight, so a checkout mid-request isn't cut off
+	// underneath by carrier tracking or the outbox relay tearing down.
+	cancelApp()
+
+	shutdownTimeout := time.Duration(cfg.Server.ShutdownTimeoutSeconds) * time.Second
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = 15 * time.Second
+	}
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelShutdown()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logrus.WithError(err).Error("server did not shut down cleanly")
+	}
+	if redirectSrv != nil {
+		if err := redirectSrv.Shutdown(shutdownCtx); err != nil {
+			logrus.WithError(err).Error("redirect listener did not shut down cleanly")
+		}
+	}
+	if diagSrv != nil {
+		if err := diagSrv.Shutdown(shutdownCtx); err != nil {
+			logrus.WithError(err).Error("diagnostics listener did not shut down cleanly")
+		}
+	}
+
+	if err := sqlDB.Close(); err != nil {
+		logrus.WithError(err).Error("failed to close database connection")
+	}
+	for _, replicaDB := range replicaDBs {
+		if replicaSQLDB, err := replicaDB.DB(); err == nil {
+			_ = replicaSQLDB.Close()
+		}
+	}
+	if err := rdb.Close(); err != nil {
+		logrus.WithError(err).Error("failed to close redis connection")
+	}
+
+	logrus.Info("shutdown complete")
+	if logWriter != nil {
+		logWriter.Close()
+	}
+}
+
+// startupRetryConfig builds the backoff schedule used to connect to
+// PostgreSQL and Redis on startup, falling back to sane defaults for any
+// setting left unconfigured (zero) in config.toml.
+func startupRetryConfig(cfg config.StartupConfig) retry.Config {
+	initialDelay := time.Duration(cfg.RetryInitialDelayMS) * time.Millisecond
+	if initialDelay <= 0 {
+		initialDelay = 500 * time.Millisecond
+	}
+	maxDelay := time.Duration(cfg.RetryMaxDelaySeconds) * time.Second
+	if maxDelay <= 0 {
+		maxDelay = 10 * time.Second
+	}
+	maxElapsed := time.Duration(cfg.RetryMaxWaitSeconds) * time.Second
+	if maxElapsed <= 0 {
+		maxElapsed = 60 * time.Second
+	}
+	return retry.Config{InitialDelay: initialDelay, MaxDelay: maxDelay, MaxElapsed: maxElapsed}
+}
@@ -0,0 +1,38 @@
+// Package addressvalidation defines the provider-agnostic interface for
+// validating and normalizing a delivery address, plus one or more concrete
+// provider implementations.
+package addressvalidation
+
+import "context"
+
+// Input is the raw, user-entered address to validate.
+type Input struct {
+	Line1      string
+	Line2      string
+	City       string
+	Region     string
+	PostalCode string
+	Country    string
+}
+
+// Result is a provider's normalized form of an Input, plus geocoordinates
+// for delivery integrations that need them (route planning, courier
+// dispatch). Valid is false when the provider recognized the address but
+// flagged it as undeliverable or ambiguous; callers should keep the raw
+// Input in that case rather than overwrite it with a low-confidence guess.
+type Result struct {
+	Line1      string
+	Line2      string
+	City       string
+	Region     string
+	PostalCode string
+	Country    string
+	Latitude   float64
+	Longitude  float64
+	Valid      bool
+}
+
+// Validator normalizes a raw address and reports whether it's deliverable.
+type Validator interface {
+	Validate(ctx context.Context, input Input) (*Result, error)
+}
@@ -0,0 +1,100 @@
+package addressvalidation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const daDataCleanURL = "https://cleaner.dadata.ru/api/v1/clean/address"
+
+// DaDataValidator validates Russian addresses against DaData's cleaning API
+// (https://dadata.ru/api/clean/address/).
+type DaDataValidator struct {
+	apiKey string
+	secret string
+	client *http.Client
+}
+
+// NewDaDataValidator builds a DaDataValidator authenticating with the given
+// API key and secret key, both issued from a DaData account.
+func NewDaDataValidator(apiKey, secret string) *DaDataValidator {
+	return &DaDataValidator{
+		apiKey: apiKey,
+		secret: secret,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type daDataCleanedAddress struct {
+	Result     string `json:"result"`
+	Region     string `json:"region"`
+	City       string `json:"city"`
+	Street     string `json:"street"`
+	House      string `json:"house"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+	GeoLat     string `json:"geo_lat"`
+	GeoLon     string `json:"geo_lon"`
+	// QC is DaData's quality code: 0 means the address was matched exactly,
+	// anything else means it needs a human to double check it.
+	QC int `json:"qc"`
+}
+
+// Validate sends the raw address line to DaData's cleaner and maps its
+// response onto a Result. Only Line1 is sent - DaData parses a full
+// free-form address line more reliably than pre-split fields.
+func (v *DaDataValidator) Validate(ctx context.Context, input Input) (*Result, error) {
+	raw := strings.TrimSpace(strings.Join([]string{input.PostalCode, input.Country, input.Region, input.City, input.Line1, input.Line2}, ", "))
+
+	body, err := json.Marshal([]string{raw})
+	if err != nil {
+		return nil, fmt.Errorf("addressvalidation: encode request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, daDataCleanURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("addressvalidation: build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Token "+v.apiKey)
+	httpReq.Header.Set("X-Secret", v.secret)
+
+	resp, err := v.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("addressvalidation: request cleaning: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("addressvalidation: unexpected status %d", resp.StatusCode)
+	}
+
+	var cleaned []daDataCleanedAddress
+	if err := json.NewDecoder(resp.Body).Decode(&cleaned); err != nil {
+		return nil, fmt.Errorf("addressvalidation: decode response: %w", err)
+	}
+	if len(cleaned) == 0 {
+		return nil, fmt.Errorf("addressvalidation: empty response")
+	}
+
+	c := cleaned[0]
+	lat, _ := strconv.ParseFloat(c.GeoLat, 64)
+	lon, _ := strconv.ParseFloat(c.GeoLon, 64)
+
+	return &Result{
+		Line1:      strings.TrimSpace(strings.Join([]string{c.Street, c.House}, " ")),
+		City:       c.City,
+		Region:     c.Region,
+		PostalCode: c.PostalCode,
+		Country:    c.Country,
+		Latitude:   lat,
+		Longitude:  lon,
+		Valid:      c.QC == 0,
+	}, nil
+}
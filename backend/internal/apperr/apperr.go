@@ -0,0 +1,88 @@
+// Package apperr defines sentinel errors shared across repositories, so
+// handlers can branch on error identity with errors.Is instead of matching
+// on error message strings, plus a mapper from those sentinels to the HTTP
+// status and machine-readable code a handler should respond with.
+package apperr
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/i18n"
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrNotFound          = errors.New("not found")
+	ErrConflict          = errors.New("conflict")
+	ErrInsufficientStock = errors.New("insufficient stock")
+)
+
+// Code is a machine-readable identifier for an error response, stable
+// across releases even if Message's wording changes.
+type Code string
+
+const (
+	CodeNotFound          Code = "not_found"
+	CodeConflict          Code = "conflict"
+	CodeInsufficientStock Code = "insufficient_stock"
+	CodeValidation        Code = "validation_error"
+	CodeUnauthorized      Code = "unauthorized"
+	CodeForbidden         Code = "forbidden"
+	CodeInternal          Code = "internal_error"
+)
+
+// Response is the standard error envelope every handler responds with.
+// Details is populated ad hoc (e.g. per-field validation messages) and
+// omitted when there's nothing beyond Message to add.
+type Response struct {
+	Code      Code        `json:"code"`
+	Message   string      `json:"message"`
+	Details   interface{} `json:"details,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+}
+
+// StatusCode maps err to the HTTP status it should produce, unwrapping to
+// look for one of the sentinels above. Anything else maps to 500, same as
+// leaving the error unhandled.
+func StatusCode(err error) int {
+	status, _ := statusAndCode(err)
+	return status
+}
+
+func statusAndCode(err error) (int, Code) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound, CodeNotFound
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict, CodeConflict
+	case errors.Is(err, ErrInsufficientStock):
+		return http.StatusUnprocessableEntity, CodeInsufficientStock
+	default:
+		return http.StatusInternalServerError, CodeInternal
+	}
+}
+
+// Respond writes err to the response using the standard envelope, with the
+// status and code statusAndCode maps it to. Message is translated into the
+// locale middleware.Locale attached to c (Accept-Language aware), falling
+// back to err's own English text if the code has no translation - a
+// sentinel error's Go-side wording is the only copy the ones that aren't
+// in i18n's catalog have.
+func Respond(c *gin.Context, err error) {
+	status, code := statusAndCode(err)
+	message := i18n.T(middleware.LocaleFrom(c), string(code))
+	if message == string(code) {
+		message = err.Error()
+	}
+	c.JSON(status, Response{Code: code, Message: message, RequestID: middleware.RequestIDFrom(c)})
+}
+
+// RespondCode writes a standard envelope response for a status/code/message
+// that doesn't come from one of the sentinel errors above - a validation
+// failure, for instance - optionally attaching details (e.g. a per-field
+// message map).
+func RespondCode(c *gin.Context, status int, code Code, message string, details interface{}) {
+	c.JSON(status, Response{Code: code, Message: message, Details: details, RequestID: middleware.RequestIDFrom(c)})
+}
@@ -0,0 +1,103 @@
+// Package breaker provides a minimal circuit breaker for guarding calls to
+// a dependency that can go down for a stretch (Redis, a payment provider),
+// so a caller can stop hammering it and fall back to degraded behaviour
+// instead of blocking every request on its timeout.
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+// state is the breaker's current position in the standard
+// closed -> open -> half-open -> closed cycle.
+type state int
+
+const (
+	closed state = iota
+	open
+	halfOpen
+)
+
+// Breaker trips open after failureThreshold consecutive failures. Once
+// open, it rejects calls until cooldown has passed, then lets a single
+// probe call through (half-open) to decide whether to close again.
+type Breaker struct {
+	failureThreshold int
+	cooldown         time.Duration
+
+	mu            sync.Mutex
+	state         state
+	failures      int
+	openedAt      time.Time
+	probeInFlight bool
+}
+
+// New builds a Breaker that opens after failureThreshold consecutive
+// failures and stays open for cooldown before probing again.
+func New(failureThreshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{failureThreshold: failureThreshold, cooldown: cooldown}
+}
+
+// Allow reports whether a call should be attempted right now. It also
+// admits the single probe call that transitions an open breaker to
+// half-open once the cooldown has elapsed.
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case closed:
+		return true
+	case halfOpen:
+		return false // a probe is already in flight
+	default: // open
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = halfOpen
+		b.probeInFlight = true
+		return true
+	}
+}
+
+// Success records a successful call, closing the breaker if it was
+// half-open or resetting the failure count if it was closed.
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = closed
+	b.failures = 0
+	b.probeInFlight = false
+}
+
+// Failure records a failed call, tripping the breaker open once
+// failureThreshold consecutive failures have been seen (or immediately, if
+// the failure was the half-open probe).
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == halfOpen {
+		b.state = open
+		b.openedAt = time.Now()
+		b.probeInFlight = false
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.failureThreshold {
+		b.state = open
+		b.openedAt = time.Now()
+	}
+}
+
+// IsOpen reports whether the breaker is currently rejecting calls, without
+// admitting a half-open probe the way Allow does. Callers use this to
+// decide whether to fall back to degraded behaviour after Allow refused a
+// call.
+func (b *Breaker) IsOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state == open && time.Since(b.openedAt) < b.cooldown
+}
@@ -0,0 +1,173 @@
+// Package cache provides a generic, namespaced Redis-backed cache with JSON
+// serialization, replacing the earlier pattern of one hand-rolled
+// per-domain cache type (ExchangeRateCache, ShopSettingsCache, ...) alongside
+// an in-process go-cache for the read-heaviest lookups. Being Redis-backed
+// keeps every replica reading the same value and lets the cache survive an
+// individual instance restarting.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/breaker"
+	"github.com/redis/go-redis/v9"
+)
+
+// breakerFailureThreshold and breakerCooldown match TokenRepository's
+// values for guarding Redis; there's no traffic-derived tuning behind them.
+const (
+	breakerFailureThreshold = 5
+	breakerCooldown         = 30 * time.Second
+)
+
+// Cache reads and writes JSON-encoded values under namespace-prefixed keys
+// in Redis, with an optional in-process L1 layer in front of it for the
+// hottest keys. The L1 layer is kept consistent across replicas by
+// publishing an invalidation over Redis pub/sub on every write, rather than
+// only clearing the local copy.
+//
+// Calls are guarded by a circuit breaker: once Redis starts failing, Cache
+// stops trying it for a cooldown period and behaves as if caching were
+// disabled - Get reports a miss and Set/Delete are no-ops - rather than
+// failing the caller's request.
+type Cache struct {
+	client    *redis.Client
+	namespace string
+	ttl       time.Duration
+	breaker   *breaker.Breaker
+
+	l1        *l1Store
+	l1Started sync.Once
+}
+
+// New builds a Cache under namespace, storing entries with ttl. A zero ttl
+// means entries never expire in Redis (the caller is responsible for
+// deleting them, e.g. on the write that made them stale).
+func New(client *redis.Client, namespace string, ttl time.Duration) *Cache {
+	return &Cache{
+		client:    client,
+		namespace: namespace,
+		ttl:       ttl,
+		breaker:   breaker.New(breakerFailureThreshold, breakerCooldown),
+	}
+}
+
+// WithL1 enables an in-process cache layer in front of Redis, holding
+// entries for l1TTL. It also starts a background subscriber that purges the
+// local layer when another replica invalidates a key, so it never serves a
+// value past the point another instance wrote over it. Call it once, right
+// after New.
+func (c *Cache) WithL1(ctx context.Context, l1TTL time.Duration) *Cache {
+	c.l1 = newL1Store(l1TTL)
+	c.l1Started.Do(func() {
+		go c.subscribeInvalidations(ctx)
+	})
+	return c
+}
+
+func (c *Cache) key(key string) string {
+	return c.namespace + ":" + key
+}
+
+// Get looks up key, decoding it into dest (a pointer) if found. It reports
+// whether the key was present. If the circuit breaker is open, it reports a
+// miss rather than an error, so a caller falls back to its source of truth
+// exactly as it would on a cold cache.
+func (c *Cache) Get(ctx context.Context, key string, dest interface{}) (bool, error) {
+	if c.l1 != nil {
+		if raw, ok := c.l1.get(key); ok {
+			return true, json.Unmarshal(raw, dest)
+		}
+	}
+
+	if !c.breaker.Allow() {
+		return false, nil
+	}
+
+	raw, err := c.client.Get(ctx, c.key(key)).Bytes()
+	if err == redis.Nil {
+		c.breaker.Success()
+		return false, nil
+	}
+	if err != nil {
+		c.breaker.Failure()
+		return false, nil
+	}
+	c.breaker.Success()
+	if c.l1 != nil {
+		c.l1.set(key, raw)
+	}
+	return true, json.Unmarshal(raw, dest)
+}
+
+// Set writes value under key, JSON-encoded. If L1 is enabled, it broadcasts
+// an invalidation rather than refreshing the local copy directly, so every
+// replica (including this one) drops its stale L1 entry and reloads from
+// Redis on its next Get. If the circuit breaker is open, Set is a no-op:
+// caching is skipped rather than failing the write that triggered it.
+func (c *Cache) Set(ctx context.Context, key string, value interface{}) error {
+	if !c.breaker.Allow() {
+		return nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err := c.client.Set(ctx, c.key(key), raw, c.ttl).Err(); err != nil {
+		c.breaker.Failure()
+		return nil
+	}
+	c.breaker.Success()
+	if c.l1 != nil {
+		return c.publishInvalidation(ctx, key)
+	}
+	return nil
+}
+
+// Delete removes key from Redis and, if L1 is enabled, broadcasts its
+// invalidation so every replica drops its local copy too. If the circuit
+// breaker is open, Delete is a no-op, same as Set.
+func (c *Cache) Delete(ctx context.Context, key string) error {
+	if !c.breaker.Allow() {
+		return nil
+	}
+
+	if err := c.client.Del(ctx, c.key(key)).Err(); err != nil {
+		c.breaker.Failure()
+		return nil
+	}
+	c.breaker.Success()
+	if c.l1 != nil {
+		return c.publishInvalidation(ctx, key)
+	}
+	return nil
+}
+
+func (c *Cache) invalidationChannel() string {
+	return "cache-invalidate:" + c.namespace
+}
+
+func (c *Cache) publishInvalidation(ctx context.Context, key string) error {
+	return c.client.Publish(ctx, c.invalidationChannel(), key).Err()
+}
+
+func (c *Cache) subscribeInvalidations(ctx context.Context) {
+	sub := c.client.Subscribe(ctx, c.invalidationChannel())
+	defer sub.Close()
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			c.l1.delete(msg.Payload)
+		}
+	}
+}
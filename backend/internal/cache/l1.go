@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// l1Store is a small in-process, TTL-expiring byte cache. It exists purely
+// to save a Redis round trip on the hottest keys - Redis stays the source
+// of truth, so a missing or stale L1 entry is never a correctness problem,
+// only a slower read.
+type l1Store struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]l1Entry
+}
+
+type l1Entry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+func newL1Store(ttl time.Duration) *l1Store {
+	return &l1Store{ttl: ttl, entries: make(map[string]l1Entry)}
+}
+
+func (s *l1Store) get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (s *l1Store) set(key string, value []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = l1Entry{value: value, expiresAt: time.Now().Add(s.ttl)}
+}
+
+func (s *l1Store) delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+}
@@ -0,0 +1,77 @@
+// Package carrier defines the carrier-agnostic interface for registering a
+// shipment and polling its tracking status, plus one or more concrete
+// carrier implementations.
+package carrier
+
+import (
+	"context"
+	"time"
+)
+
+// RegisterShipmentRequest describes the shipment a carrier should create a
+// waybill for.
+type RegisterShipmentRequest struct {
+	OrderID     string
+	WeightGrams int
+	Recipient   string
+	Phone       string
+	Line1       string
+	City        string
+	Region      string
+	PostalCode  string
+	Country     string
+}
+
+// RegisterShipmentResult is what a Provider hands back after registering a
+// shipment. TrackingNumber is the carrier's own identifier, used for every
+// later tracking lookup.
+type RegisterShipmentResult struct {
+	TrackingNumber string
+	Status         string
+}
+
+// TrackingEvent is a single milestone a carrier reports against a shipment,
+// e.g. "handed to courier" or "arrived at sorting facility".
+type TrackingEvent struct {
+	Status      string
+	Description string
+	OccurredAt  time.Time
+}
+
+// TrackingStatus is a carrier's current view of a shipment: its overall
+// Status plus every event reported so far, oldest first.
+type TrackingStatus struct {
+	Status string
+	Events []TrackingEvent
+}
+
+// PickupPoint is a carrier-operated location a customer can collect an
+// order from, as reported by the carrier's own point directory.
+type PickupPoint struct {
+	ExternalID string
+	Name       string
+	Address    string
+	City       string
+	Region     string
+	PostalCode string
+	Latitude   float64
+	Longitude  float64
+}
+
+// Provider is implemented by a carrier integration.
+type Provider interface {
+	// Name identifies the carrier, e.g. "cdek" or "russian_post", stored
+	// against the shipment so a later Track call knows which provider to
+	// use.
+	Name() string
+
+	RegisterShipment(ctx context.Context, req RegisterShipmentRequest) (*RegisterShipmentResult, error)
+
+	// Track fetches the current status and full event history for a
+	// shipment previously registered with this provider.
+	Track(ctx context.Context, trackingNumber string) (*TrackingStatus, error)
+
+	// ListPickupPoints fetches every pickup point the carrier operates in
+	// city, for PickupPointService.Sync to mirror into PickupPointRepository.
+	ListPickupPoints(ctx context.Context, city string) ([]PickupPoint, error)
+}
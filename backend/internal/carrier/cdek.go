@@ -0,0 +1,287 @@
+package carrier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	cdekTokenURL       = "https://api.cdek.ru/v2/oauth/token"
+	cdekOrdersURL      = "https://api.cdek.ru/v2/orders"
+	cdekTrackingPath   = "https://api.cdek.ru/v2/orders/%s"
+	cdekDeliveryPoints = "https://api.cdek.ru/v2/deliverypoints"
+)
+
+// CDEKProvider registers shipments and polls tracking status through CDEK's
+// REST API (https://api-docs.cdek.ru/).
+type CDEKProvider struct {
+	accountID string
+	secure    string
+	client    *http.Client
+}
+
+// NewCDEKProvider builds a CDEKProvider authenticating with the given
+// account credentials, issued from a CDEK integrator account.
+func NewCDEKProvider(accountID, secure string) *CDEKProvider {
+	return &CDEKProvider{
+		accountID: accountID,
+		secure:    secure,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name identifies this provider.
+func (p *CDEKProvider) Name() string { return "cdek" }
+
+type cdekTokenResponse struct {
+	AccessToken string `json:"access_token"`
+}
+
+func (p *CDEKProvider) token(ctx context.Context) (string, error) {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {p.accountID},
+		"client_secret": {p.secure},
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, cdekTokenURL, bytes.NewBufferString(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("carrier: build token request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("carrier: request token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("carrier: unexpected token status %d", resp.StatusCode)
+	}
+
+	var tok cdekTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return "", fmt.Errorf("carrier: decode token: %w", err)
+	}
+	return tok.AccessToken, nil
+}
+
+type cdekRegisterRequest struct {
+	Number     string        `json:"number"`
+	Weight     int           `json:"weight"`
+	Recipient  cdekContact   `json:"recipient"`
+	ToLocation cdekLocation  `json:"to_location"`
+	Packages   []cdekPackage `json:"packages"`
+}
+
+type cdekContact struct {
+	Name   string      `json:"name"`
+	Phones []cdekPhone `json:"phones"`
+}
+
+type cdekPhone struct {
+	Number string `json:"number"`
+}
+
+type cdekLocation struct {
+	Address     string `json:"address"`
+	City        string `json:"city"`
+	Region      string `json:"region"`
+	PostalCode  string `json:"postal_code"`
+	CountryCode string `json:"country_code"`
+}
+
+type cdekPackage struct {
+	Number string `json:"number"`
+	Weight int    `json:"weight"`
+}
+
+type cdekEntity struct {
+	UUID       string `json:"uuid"`
+	CdekNumber string `json:"cdek_number"`
+}
+
+type cdekRegisterResponse struct {
+	Entity cdekEntity `json:"entity"`
+}
+
+// RegisterShipment creates a CDEK order for req and returns its tracking
+// number (CDEK's own order number, not the internal UUID).
+func (p *CDEKProvider) RegisterShipment(ctx context.Context, req RegisterShipmentRequest) (*RegisterShipmentResult, error) {
+	token, err := p.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(cdekRegisterRequest{
+		Number: req.OrderID,
+		Weight: req.WeightGrams,
+		Recipient: cdekContact{
+			Name:   req.Recipient,
+			Phones: []cdekPhone{{Number: req.Phone}},
+		},
+		ToLocation: cdekLocation{
+			Address:     req.Line1,
+			City:        req.City,
+			Region:      req.Region,
+			PostalCode:  req.PostalCode,
+			CountryCode: req.Country,
+		},
+		Packages: []cdekPackage{{Number: req.OrderID, Weight: req.WeightGrams}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("carrier: encode register request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, cdekOrdersURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("carrier: build register request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("carrier: request register: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("carrier: unexpected register status %d", resp.StatusCode)
+	}
+
+	var registered cdekRegisterResponse
+	if err := json.NewDecoder(resp.Body).Decode(&registered); err != nil {
+		return nil, fmt.Errorf("carrier: decode register response: %w", err)
+	}
+
+	return &RegisterShipmentResult{
+		TrackingNumber: registered.Entity.CdekNumber,
+		Status:         "created",
+	}, nil
+}
+
+type cdekStatus struct {
+	Code     string    `json:"code"`
+	Name     string    `json:"name"`
+	DateTime time.Time `json:"date_time"`
+}
+
+type cdekTrackResponse struct {
+	Entity struct {
+		Statuses []cdekStatus `json:"statuses"`
+	} `json:"entity"`
+}
+
+// Track fetches every status CDEK has recorded against trackingNumber,
+// oldest first, and reports the most recent one as the overall status.
+func (p *CDEKProvider) Track(ctx context.Context, trackingNumber string) (*TrackingStatus, error) {
+	token, err := p.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requestURL := fmt.Sprintf(cdekTrackingPath, trackingNumber) + "?cdek_number=" + url.QueryEscape(trackingNumber)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("carrier: build track request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("carrier: request track: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("carrier: unexpected track status %d", resp.StatusCode)
+	}
+
+	var tracked cdekTrackResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tracked); err != nil {
+		return nil, fmt.Errorf("carrier: decode track response: %w", err)
+	}
+
+	events := make([]TrackingEvent, 0, len(tracked.Entity.Statuses))
+	for _, s := range tracked.Entity.Statuses {
+		events = append(events, TrackingEvent{
+			Status:      s.Code,
+			Description: s.Name,
+			OccurredAt:  s.DateTime,
+		})
+	}
+
+	status := "created"
+	if len(events) > 0 {
+		status = events[len(events)-1].Status
+	}
+
+	return &TrackingStatus{Status: status, Events: events}, nil
+}
+
+type cdekDeliveryPoint struct {
+	Code     string            `json:"code"`
+	Name     string            `json:"name"`
+	Location cdekPointLocation `json:"location"`
+}
+
+type cdekPointLocation struct {
+	Address    string  `json:"address"`
+	City       string  `json:"city"`
+	Region     string  `json:"region"`
+	PostalCode string  `json:"postal_code"`
+	Latitude   float64 `json:"latitude"`
+	Longitude  float64 `json:"longitude"`
+}
+
+// ListPickupPoints fetches every CDEK pickup point in city.
+func (p *CDEKProvider) ListPickupPoints(ctx context.Context, city string) ([]PickupPoint, error) {
+	token, err := p.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requestURL := cdekDeliveryPoints + "?city=" + url.QueryEscape(city)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("carrier: build pickup points request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("carrier: request pickup points: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("carrier: unexpected pickup points status %d", resp.StatusCode)
+	}
+
+	var points []cdekDeliveryPoint
+	if err := json.NewDecoder(resp.Body).Decode(&points); err != nil {
+		return nil, fmt.Errorf("carrier: decode pickup points response: %w", err)
+	}
+
+	result := make([]PickupPoint, 0, len(points))
+	for _, point := range points {
+		result = append(result, PickupPoint{
+			ExternalID: point.Code,
+			Name:       point.Name,
+			Address:    point.Location.Address,
+			City:       point.Location.City,
+			Region:     point.Location.Region,
+			PostalCode: point.Location.PostalCode,
+			Latitude:   point.Location.Latitude,
+			Longitude:  point.Location.Longitude,
+		})
+	}
+	return result, nil
+}
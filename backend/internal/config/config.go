@@ -0,0 +1,632 @@
+// Package config loads application configuration from config.toml, layered
+// with an optional config.{APP_ENV}.toml override (see LoadEnv).
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/sirupsen/logrus"
+)
+
+// Config is the root application configuration, decoded from config.toml.
+type Config struct {
+	Server            ServerConfig            `toml:"server"`
+	Database          DatabaseConfig          `toml:"database"`
+	Redis             RedisConfig             `toml:"redis"`
+	JWT               JWTConfig               `toml:"jwt"`
+	Catalog           CatalogConfig           `toml:"catalog"`
+	Inventory         InventoryConfig         `toml:"inventory"`
+	Reviews           ReviewsConfig           `toml:"reviews"`
+	Cart              CartConfig              `toml:"cart"`
+	Tax               TaxConfig               `toml:"tax"`
+	Shipping          ShippingConfig          `toml:"shipping"`
+	Payments          PaymentsConfig          `toml:"payments"`
+	Orders            OrdersConfig            `toml:"orders"`
+	FX                FXConfig                `toml:"fx"`
+	Search            SearchConfig            `toml:"search"`
+	Recommendation    RecommendationConfig    `toml:"recommendation"`
+	Reports           ReportsConfig           `toml:"reports"`
+	Mailer            MailerConfig            `toml:"mailer"`
+	SMS               SMSConfig               `toml:"sms"`
+	WebPush           WebPushConfig           `toml:"web_push"`
+	AddressValidation AddressValidationConfig `toml:"address_validation"`
+	Carrier           CarrierConfig           `toml:"carrier"`
+	Outbox            OutboxConfig            `toml:"outbox"`
+	Cleanup           CleanupConfig           `toml:"cleanup"`
+	Startup           StartupConfig           `toml:"startup"`
+	Log               LogConfig               `toml:"log"`
+	ErrorReporting    ErrorReportingConfig    `toml:"error_reporting"`
+	Diagnostics       DiagnosticsConfig       `toml:"diagnostics"`
+	CORS              CORSConfig              `toml:"cors"`
+	Gzip              GzipConfig              `toml:"gzip"`
+	RateLimit         RateLimitConfig         `toml:"rate_limit"`
+}
+
+// ServerConfig holds HTTP server settings.
+type ServerConfig struct {
+	// Host is the address the server binds to, e.g. "127.0.0.1" to only
+	// accept local connections. Empty means all interfaces.
+	Host    string `toml:"host"`
+	Port    int    `toml:"port"`
+	Mode    string `toml:"mode"`
+	BaseURL string `toml:"base_url"`
+	// ShutdownTimeoutSeconds bounds how long the server waits for
+	// in-flight requests to finish draining on SIGINT/SIGTERM before it
+	// force-closes them.
+	ShutdownTimeoutSeconds int `toml:"shutdown_timeout_seconds"`
+	// TLSCertFile and TLSKeyFile, when both set, make the server terminate
+	// TLS itself with this cert/key pair instead of expecting a reverse
+	// proxy to. HTTPRedirectPort, if set, starts a second, plain-HTTP
+	// listener on that port which redirects every request to the HTTPS
+	// one; leave it 0 to skip the redirect listener entirely.
+	TLSCertFile      string `toml:"tls_cert_file"`
+	TLSKeyFile       string `toml:"tls_key_file"`
+	HTTPRedirectPort int    `toml:"http_redirect_port"`
+}
+
+// TLSEnabled reports whether the server should terminate TLS itself.
+func (s ServerConfig) TLSEnabled() bool {
+	return s.TLSCertFile != "" && s.TLSKeyFile != ""
+}
+
+// Addr returns the "host:port" address the server should listen on.
+func (s ServerConfig) Addr() string {
+	return fmt.Sprintf("%s:%d", s.Host, s.Port)
+}
+
+// DatabaseConfig holds PostgreSQL connection settings. The pool fields are
+// applied to the underlying sql.DB after connecting; a zero value for any of
+// them leaves Go's database/sql default in place.
+type DatabaseConfig struct {
+	Host            string           `toml:"host"`
+	Port            int              `toml:"port"`
+	User            string           `toml:"user"`
+	Password        string           `toml:"password"`
+	DBName          string           `toml:"dbname"`
+	SSLMode         string           `toml:"sslmode"`
+	MaxOpenConns    int              `toml:"max_open_conns"`
+	MaxIdleConns    int              `toml:"max_idle_conns"`
+	ConnMaxLifetime int              `toml:"conn_max_lifetime_minutes"`
+	Replicas        []DatabaseConfig `toml:"replicas"`
+	// SlowQueryThresholdMS flags a query logged at Warn instead of Debug
+	// once it runs this long. 0 falls back to 200ms.
+	SlowQueryThresholdMS int `toml:"slow_query_threshold_ms"`
+}
+
+// RedisConfig holds Redis connection settings.
+type RedisConfig struct {
+	Host     string `toml:"host"`
+	Port     int    `toml:"port"`
+	Password string `toml:"password"`
+	DB       int    `toml:"db"`
+}
+
+// JWTConfig holds JWT signing settings.
+type JWTConfig struct {
+	Secret     string `toml:"secret"`
+	ExpiryHour int    `toml:"expiry_hour"`
+	// AllowDegradedAuth lets the Auth/OptionalAuth middleware accept a
+	// request's JWT on signature and expiry alone when Redis (and so the
+	// revocation check) is unreachable, instead of rejecting every
+	// authenticated request.
+	AllowDegradedAuth bool `toml:"allow_degraded_auth"`
+}
+
+// CatalogConfig holds product catalog policy settings. CollectionCheckIntervalMinutes
+// governs how often CollectionSchedulerService checks for collections due to
+// launch or retire.
+type CatalogConfig struct {
+	PublishThreshold               int `toml:"publish_threshold"`
+	CollectionCheckIntervalMinutes int `toml:"collection_check_interval_minutes"`
+}
+
+// InventoryConfig holds the low-stock dashboard's policy: what counts as
+// low stock, how far back to measure sales velocity, and how far ahead a
+// suggested reorder should cover.
+type InventoryConfig struct {
+	LowStockThreshold              int `toml:"low_stock_threshold"`
+	LowStockLookbackDays           int `toml:"low_stock_lookback_days"`
+	LowStockLeadTimeDays           int `toml:"low_stock_lead_time_days"`
+	LowStockRefreshIntervalMinutes int `toml:"low_stock_refresh_interval_minutes"`
+}
+
+// ReviewsConfig holds soft-quota policy for review submissions.
+type ReviewsConfig struct {
+	MaxPerDay     int `toml:"max_per_day"`
+	SoftMaxLength int `toml:"soft_max_length"`
+	// RequirePurchase rejects a review from a user with no delivered order
+	// containing the product, instead of just tagging it unverified.
+	RequirePurchase bool `toml:"require_purchase"`
+	MaxPhotos       int  `toml:"max_photos"`
+}
+
+// CartConfig holds abandoned-cart detection and reminder policy.
+type CartConfig struct {
+	AbandonedAfterDays int `toml:"abandoned_after_days"`
+	// CheckIntervalMinutes is how often
+	// service.AbandonedCartReminderService scans for carts to remind.
+	CheckIntervalMinutes int `toml:"check_interval_minutes"`
+	// ReminderIntervalHours is the minimum time
+	// service.AbandonedCartReminderService waits between reminder emails
+	// to the same user within one cycle.
+	ReminderIntervalHours int `toml:"reminder_interval_hours"`
+	// MaxReminders caps how many reminder emails one cycle sends before
+	// AbandonedCartReminderService gives up on it.
+	MaxReminders int `toml:"max_reminders"`
+	// ReminderCouponPercent is the discount attached to a reminder cycle's
+	// auto-generated coupon. 0 disables generating one.
+	ReminderCouponPercent int `toml:"reminder_coupon_percent"`
+	// ReminderCouponValidDays is how long a reminder cycle's coupon stays
+	// valid for after it is generated.
+	ReminderCouponValidDays int `toml:"reminder_coupon_valid_days"`
+}
+
+// TaxConfig holds the storefront's VAT policy. Mode "inclusive" means Rate is
+// already baked into product prices (tax is only reported, not added), while
+// "exclusive" means Rate is added on top of the subtotal at checkout.
+// This lives in config until ShopSettings grows a proper admin-editable
+// store for it.
+type TaxConfig struct {
+	Rate float64 `toml:"rate"`
+	Mode string  `toml:"mode"`
+}
+
+// ShippingConfig holds the storefront's flat shipping policy.
+type ShippingConfig struct {
+	FlatRate         float64 `toml:"flat_rate"`
+	FreeShippingOver float64 `toml:"free_shipping_over"`
+}
+
+// PaymentsConfig selects and configures the payment gateway. ReturnURL is
+// where the gateway sends the shopper back after they confirm or cancel
+// payment on its hosted page. MaxRetries caps how many payment attempts
+// an order may accumulate before Pay refuses further ones, leaving it to
+// the auto-cancel process to release the order's stock.
+type PaymentsConfig struct {
+	Provider   string         `toml:"provider"`
+	Currency   string         `toml:"currency"`
+	ReturnURL  string         `toml:"return_url"`
+	MaxRetries int            `toml:"max_retries"`
+	YooKassa   YooKassaConfig `toml:"yookassa"`
+}
+
+// YooKassaConfig holds the merchant credentials for the YooKassa gateway.
+type YooKassaConfig struct {
+	ShopID    string `toml:"shop_id"`
+	SecretKey string `toml:"secret_key"`
+}
+
+// OrdersConfig governs OrderCancellationService, the auto-cancel process
+// PaymentsConfig's MaxRetries defers to once an order has exhausted its
+// payment attempts.
+type OrdersConfig struct {
+	UnpaidCancelAfterMinutes   int `toml:"unpaid_cancel_after_minutes"`
+	CancelCheckIntervalMinutes int `toml:"cancel_check_interval_minutes"`
+}
+
+// FXConfig configures the source and cadence of exchange-rate refreshes.
+// BaseCurrency is what every cached rate is quoted against.
+type FXConfig struct {
+	Source                 string `toml:"source"`
+	APIKey                 string `toml:"api_key"`
+	BaseCurrency           string `toml:"base_currency"`
+	RefreshIntervalMinutes int    `toml:"refresh_interval_minutes"`
+}
+
+// SearchConfig configures the external search engine products are synced
+// into and how often SearchIndexService drains the sync queue into it.
+type SearchConfig struct {
+	Host                string `toml:"host"`
+	APIKey              string `toml:"api_key"`
+	Index               string `toml:"index"`
+	SyncIntervalSeconds int    `toml:"sync_interval_seconds"`
+}
+
+// ReportsConfig governs ReportPrecomputeService's background refresh of
+// the admin dashboard snapshot.
+type ReportsConfig struct {
+	PrecomputeIntervalMinutes int `toml:"precompute_interval_minutes"`
+}
+
+// RecommendationConfig governs RecommendationService's background refresh
+// and the size of the lists it produces.
+type RecommendationConfig struct {
+	RefreshIntervalMinutes int `toml:"refresh_interval_minutes"`
+	LookbackDays           int `toml:"lookback_days"`
+	AlsoBoughtLimit        int `toml:"also_bought_limit"`
+	ForYouLimit            int `toml:"for_you_limit"`
+}
+
+// MailerConfig selects and configures the outgoing email backend.
+// Provider is "smtp" or "api", naming which of MailerConfig's sub-configs
+// is actually used - matching CarrierConfig's/AddressValidationConfig's
+// selector convention. MaxAttempts and RetryDelaySeconds bound how hard
+// EmailService retries a transient delivery failure before giving up.
+type MailerConfig struct {
+	Provider          string           `toml:"provider"`
+	From              string           `toml:"from"`
+	MaxAttempts       int              `toml:"max_attempts"`
+	RetryDelaySeconds int              `toml:"retry_delay_seconds"`
+	SMTP              MailerSMTPConfig `toml:"smtp"`
+	API               MailerAPIConfig  `toml:"api"`
+}
+
+// MailerSMTPConfig holds the credentials for the "smtp" mailer provider.
+type MailerSMTPConfig struct {
+	Host     string `toml:"host"`
+	Port     int    `toml:"port"`
+	Username string `toml:"username"`
+	Password string `toml:"password"`
+}
+
+// MailerAPIConfig holds the credentials for the "api" mailer provider - a
+// hosted transactional email service reached over HTTP.
+type MailerAPIConfig struct {
+	Host   string `toml:"host"`
+	APIKey string `toml:"api_key"`
+}
+
+// SMSConfig selects and configures the outgoing SMS backend. Senders maps
+// each country an order/verification SMS might go to onto the sending
+// number registered for it - most SMS carriers reject (or shadow-filter)
+// messages sent from a number not provisioned for the recipient's
+// country - falling back to DefaultFrom for a country with no entry.
+type SMSConfig struct {
+	Provider          string            `toml:"provider"`
+	DefaultFrom       string            `toml:"default_from"`
+	MaxAttempts       int               `toml:"max_attempts"`
+	RetryDelaySeconds int               `toml:"retry_delay_seconds"`
+	Senders           []SMSSenderConfig `toml:"senders"`
+	Twilio            SMSTwilioConfig   `toml:"twilio"`
+}
+
+// SMSSenderConfig pins the sending number used for SMS to recipients in
+// Country (an ISO 3166-1 alpha-2 code, e.g. "US").
+type SMSSenderConfig struct {
+	Country string `toml:"country"`
+	From    string `toml:"from"`
+}
+
+// SMSTwilioConfig holds the credentials for the "twilio" SMS provider.
+type SMSTwilioConfig struct {
+	AccountSID string `toml:"account_sid"`
+	AuthToken  string `toml:"auth_token"`
+}
+
+// WebPushConfig holds the VAPID key pair PushNotificationService signs
+// every push with, plus how long a push service should hold an
+// undelivered notification before giving up. PublicKey/PrivateKey are
+// base64url-encoded P-256 key material, generated once via
+// webpush.GenerateVAPIDKeys.
+type WebPushConfig struct {
+	Subject    string `toml:"subject"`
+	PublicKey  string `toml:"public_key"`
+	PrivateKey string `toml:"private_key"`
+	TTLSeconds int    `toml:"ttl_seconds"`
+}
+
+// AddressValidationConfig selects and configures the address normalization
+// provider used when a customer saves an address.
+type AddressValidationConfig struct {
+	Provider string `toml:"provider"`
+	APIKey   string `toml:"api_key"`
+	Secret   string `toml:"secret"`
+}
+
+// CarrierConfig selects and configures the shipping carrier used to
+// register shipments and poll tracking status. PollIntervalMinutes governs
+// how often CarrierService checks every active shipment for updates.
+type CarrierConfig struct {
+	Provider            string `toml:"provider"`
+	AccountID           string `toml:"account_id"`
+	Secure              string `toml:"secure"`
+	PollIntervalMinutes int    `toml:"poll_interval_minutes"`
+}
+
+// OutboxConfig configures OutboxRelayService. WebhookURLs is the set of
+// subscribers every outbox event is POSTed to; leaving it empty disables
+// the relay entirely (events accumulate but are never delivered).
+type OutboxConfig struct {
+	WebhookURLs         []string `toml:"webhook_urls"`
+	PollIntervalSeconds int      `toml:"poll_interval_seconds"`
+}
+
+// CleanupConfig governs service.CleanupService's periodic purge of
+// settled outbox events and old payment webhook logs. It does not cover
+// password-reset/verification tokens or idempotency keys, since this
+// codebase has no repository for either yet, or guest carts, which
+// already expire on their own via Redis TTL.
+type CleanupConfig struct {
+	IntervalMinutes         int `toml:"interval_minutes"`
+	OutboxRetentionDays     int `toml:"outbox_retention_days"`
+	WebhookLogRetentionDays int `toml:"webhook_log_retention_days"`
+}
+
+// StartupConfig controls how the service retries connecting to PostgreSQL
+// and Redis when it's started before they're ready to accept connections,
+// e.g. during a docker-compose or Kubernetes rollout.
+type StartupConfig struct {
+	// RetryInitialDelayMS is how long to wait before the second connection
+	// attempt. The delay doubles after every subsequent failure.
+	RetryInitialDelayMS int `toml:"retry_initial_delay_ms"`
+	// RetryMaxDelaySeconds caps the backoff so it doesn't grow unbounded.
+	RetryMaxDelaySeconds int `toml:"retry_max_delay_seconds"`
+	// RetryMaxWaitSeconds is the total time budget across every attempt,
+	// including delays. The service exits once it's exceeded.
+	RetryMaxWaitSeconds int `toml:"retry_max_wait_seconds"`
+}
+
+// LogConfig controls logrus's level and where its output goes. Leaving
+// OutputFile empty logs to stderr; setting it rotates the file by size and
+// age instead of letting it grow forever.
+type LogConfig struct {
+	// Level is one of logrus's level names (debug, info, warn, error).
+	// Defaults to "info".
+	Level string `toml:"level"`
+	// OutputFile, if set, is where logs are written instead of stderr.
+	OutputFile string `toml:"output_file"`
+	// MaxSizeMB rotates OutputFile once it reaches this size. Defaults to 100.
+	MaxSizeMB int `toml:"max_size_mb"`
+	// MaxBackups is how many rotated files are kept; the oldest beyond this
+	// are deleted. 0 means unlimited.
+	MaxBackups int `toml:"max_backups"`
+	// MaxAgeDays deletes rotated files older than this many days. 0 means
+	// they're never deleted by age.
+	MaxAgeDays int `toml:"max_age_days"`
+}
+
+// ErrorReportingConfig configures where Error/Fatal/Panic-level log entries
+// are forwarded for alerting. Leaving DSN empty disables reporting - errors
+// still go to the normal log output, just nowhere else.
+type ErrorReportingConfig struct {
+	DSN         string `toml:"dsn"`
+	Environment string `toml:"environment"`
+}
+
+// DiagnosticsConfig controls the separate pprof/expvar listener used to
+// profile memory and goroutine usage in production. It's a distinct port
+// from the main API, defaulting to loopback-only, so it's never exposed to
+// the internet by accident - reach it over SSH tunnel or from inside the
+// deployment's own network.
+type DiagnosticsConfig struct {
+	Enabled bool `toml:"enabled"`
+	// Host defaults to "127.0.0.1" when Enabled and left empty.
+	Host string `toml:"host"`
+	// Port has no default; it must be set when Enabled.
+	Port int `toml:"port"`
+}
+
+// CORSConfig configures which cross-origin requests the API accepts. Leaving
+// AllowedOrigins empty disables CORS handling entirely - no Access-Control-*
+// headers are sent, and browsers fall back to their same-origin default.
+type CORSConfig struct {
+	// AllowedOrigins is an exact-match allowlist, e.g. "https://shop.example.com".
+	// "*" allows any origin, but is rejected by Validate when AllowCredentials
+	// is set, since browsers refuse to honor a wildcard alongside credentials.
+	AllowedOrigins   []string `toml:"allowed_origins"`
+	AllowedMethods   []string `toml:"allowed_methods"`
+	AllowedHeaders   []string `toml:"allowed_headers"`
+	AllowCredentials bool     `toml:"allow_credentials"`
+	// MaxAgeSeconds is how long a browser may cache a preflight OPTIONS
+	// response before sending another one. Defaults to 600 when CORS is
+	// enabled and this is left at 0.
+	MaxAgeSeconds int `toml:"max_age_seconds"`
+}
+
+// Enabled reports whether CORS handling should be installed at all.
+func (c CORSConfig) Enabled() bool {
+	return len(c.AllowedOrigins) > 0
+}
+
+// GzipConfig controls response compression for JSON/text/SVG bodies.
+type GzipConfig struct {
+	Enabled bool `toml:"enabled"`
+	// MinSizeBytes skips compression on bodies smaller than this, since
+	// gzip's own overhead outweighs the savings on small responses.
+	// Defaults to 1024 when Enabled and left at 0.
+	MinSizeBytes int `toml:"min_size_bytes"`
+}
+
+// RateLimitConfig configures the Redis-backed token bucket applied to the
+// public API. Default governs every /api route; Auth overrides it for
+// /api/auth specifically, since login/register are worth limiting harder
+// than a product listing.
+type RateLimitConfig struct {
+	Enabled bool          `toml:"enabled"`
+	Default RateLimitRule `toml:"default"`
+	Auth    RateLimitRule `toml:"auth"`
+}
+
+// RateLimitRule is one token bucket's capacity and refill rate. A request
+// consumes one token; Capacity is the size of the burst a client can spend
+// at once, RefillPerSecond is the sustained rate it drains back to.
+type RateLimitRule struct {
+	Capacity        int     `toml:"capacity"`
+	RefillPerSecond float64 `toml:"refill_per_second"`
+}
+
+// DSN builds a libpq-compatible connection string for the database.
+func (d DatabaseConfig) DSN() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		d.Host, d.Port, d.User, d.Password, d.DBName, d.SSLMode)
+}
+
+// Addr returns the "host:port" address of the Redis instance.
+func (r RedisConfig) Addr() string {
+	return fmt.Sprintf("%s:%d", r.Host, r.Port)
+}
+
+// Load reads and decodes the config file at path.
+func Load(path string) (*Config, error) {
+	var cfg Config
+	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+		return nil, fmt.Errorf("config: decode %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// LoadEnv loads config.toml, then layers config.{APP_ENV}.toml on top of it
+// if APP_ENV is set and the file exists. Only the keys present in the
+// environment file override the shared one - toml.DecodeFile leaves fields
+// it doesn't mention untouched - so config.stage.toml or config.prod.toml
+// only needs to list the handful of values that actually differ (database
+// host, jwt secret, base_url, ...) rather than duplicate the whole file.
+// With APP_ENV unset, or no matching file, this is equivalent to Load.
+func LoadEnv() (*Config, error) {
+	cfg, err := Load("config.toml")
+	if err != nil {
+		return nil, err
+	}
+
+	env := os.Getenv("APP_ENV")
+	if env == "" {
+		return cfg, nil
+	}
+
+	overridePath := fmt.Sprintf("config.%s.toml", env)
+	if _, err := os.Stat(overridePath); err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("config: stat %s: %w", overridePath, err)
+	}
+	if _, err := toml.DecodeFile(overridePath, cfg); err != nil {
+		return nil, fmt.Errorf("config: decode %s: %w", overridePath, err)
+	}
+	return cfg, nil
+}
+
+// Validate applies sensible defaults for fields the operator left at their
+// zero value, then checks the remaining required fields, port ranges, and
+// durations. It collects every problem it finds instead of returning on the
+// first one, so a misconfigured deploy fails fast at startup with a full
+// list of what to fix rather than one cryptic GORM or Redis error at a time.
+func (c *Config) Validate() error {
+	var problems []string
+
+	if c.Server.Port == 0 {
+		c.Server.Port = 8080
+	} else if c.Server.Port < 0 || c.Server.Port > 65535 {
+		problems = append(problems, fmt.Sprintf("server.port: %d is not a valid port", c.Server.Port))
+	}
+	if c.Server.Mode == "" {
+		c.Server.Mode = "release"
+	}
+	if c.Server.ShutdownTimeoutSeconds <= 0 {
+		c.Server.ShutdownTimeoutSeconds = 15
+	}
+	if (c.Server.TLSCertFile == "") != (c.Server.TLSKeyFile == "") {
+		problems = append(problems, "server: tls_cert_file and tls_key_file must both be set, or both left empty")
+	}
+	if c.Server.HTTPRedirectPort != 0 && !c.Server.TLSEnabled() {
+		problems = append(problems, "server.http_redirect_port: set but no tls_cert_file/tls_key_file configured to redirect to")
+	}
+
+	if c.Database.Host == "" {
+		problems = append(problems, "database.host: must not be empty")
+	}
+	if c.Database.Port <= 0 || c.Database.Port > 65535 {
+		problems = append(problems, fmt.Sprintf("database.port: %d is not a valid port", c.Database.Port))
+	}
+	if c.Database.DBName == "" {
+		problems = append(problems, "database.dbname: must not be empty")
+	}
+	if c.Database.SlowQueryThresholdMS <= 0 {
+		c.Database.SlowQueryThresholdMS = 200
+	}
+	for i, replica := range c.Database.Replicas {
+		if replica.Host == "" {
+			problems = append(problems, fmt.Sprintf("database.replicas[%d].host: must not be empty", i))
+		}
+		if replica.Port <= 0 || replica.Port > 65535 {
+			problems = append(problems, fmt.Sprintf("database.replicas[%d].port: %d is not a valid port", i, replica.Port))
+		}
+	}
+
+	if c.Redis.Host == "" {
+		problems = append(problems, "redis.host: must not be empty")
+	}
+	if c.Redis.Port <= 0 || c.Redis.Port > 65535 {
+		problems = append(problems, fmt.Sprintf("redis.port: %d is not a valid port", c.Redis.Port))
+	}
+
+	if strings.TrimSpace(c.JWT.Secret) == "" {
+		problems = append(problems, "jwt.secret: must not be empty")
+	}
+	if c.JWT.ExpiryHour <= 0 {
+		c.JWT.ExpiryHour = 24
+	}
+
+	if c.Log.Level == "" {
+		c.Log.Level = "info"
+	} else if _, err := logrus.ParseLevel(c.Log.Level); err != nil {
+		problems = append(problems, fmt.Sprintf("log.level: %q is not a valid logrus level", c.Log.Level))
+	}
+	if c.Log.MaxSizeMB <= 0 {
+		c.Log.MaxSizeMB = 100
+	}
+
+	if c.Diagnostics.Enabled {
+		if c.Diagnostics.Host == "" {
+			c.Diagnostics.Host = "127.0.0.1"
+		}
+		if c.Diagnostics.Port <= 0 || c.Diagnostics.Port > 65535 {
+			problems = append(problems, fmt.Sprintf("diagnostics.port: %d is not a valid port", c.Diagnostics.Port))
+		}
+	}
+
+	if c.CORS.Enabled() {
+		for _, origin := range c.CORS.AllowedOrigins {
+			if origin == "*" && c.CORS.AllowCredentials {
+				problems = append(problems, "cors: allowed_origins may not contain \"*\" when allow_credentials is true")
+				break
+			}
+		}
+		if len(c.CORS.AllowedMethods) == 0 {
+			c.CORS.AllowedMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+		}
+		if len(c.CORS.AllowedHeaders) == 0 {
+			c.CORS.AllowedHeaders = []string{"Content-Type", "Authorization"}
+		}
+		if c.CORS.MaxAgeSeconds <= 0 {
+			c.CORS.MaxAgeSeconds = 600
+		}
+	}
+
+	if c.Gzip.Enabled && c.Gzip.MinSizeBytes <= 0 {
+		c.Gzip.MinSizeBytes = 1024
+	}
+
+	if c.RateLimit.Enabled {
+		if c.RateLimit.Default.Capacity <= 0 {
+			c.RateLimit.Default.Capacity = 120
+		}
+		if c.RateLimit.Default.RefillPerSecond <= 0 {
+			c.RateLimit.Default.RefillPerSecond = 2
+		}
+		if c.RateLimit.Auth.Capacity <= 0 {
+			c.RateLimit.Auth.Capacity = 10
+		}
+		if c.RateLimit.Auth.RefillPerSecond <= 0 {
+			c.RateLimit.Auth.RefillPerSecond = 0.2
+		}
+	}
+
+	if c.Startup.RetryInitialDelayMS <= 0 {
+		c.Startup.RetryInitialDelayMS = 500
+	}
+	if c.Startup.RetryMaxDelaySeconds <= 0 {
+		c.Startup.RetryMaxDelaySeconds = 10
+	}
+	if c.Startup.RetryMaxWaitSeconds <= 0 {
+		c.Startup.RetryMaxWaitSeconds = 60
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("config: invalid configuration:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Package dblog bridges GORM's query logging to logrus, so slow or failing
+// queries land in the same structured log stream as everything else
+// instead of GORM's own log.Logger.
+package dblog
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+)
+
+// Logger implements gorm's logger.Interface on top of logrus. Every query
+// is logged at Debug; one slower than SlowThreshold is logged at Warn
+// instead, and a failed one at Error, so a DBA scanning at Warn/Error
+// finds the queries worth an index before they take checkout down with
+// them, without production drowning in a Debug line per query.
+type Logger struct {
+	SlowThreshold time.Duration
+}
+
+// New builds a Logger that flags queries slower than slowThreshold. A
+// non-positive threshold disables the Warn-level slow-query flag; queries
+// are still logged at Debug.
+func New(slowThreshold time.Duration) *Logger {
+	return &Logger{SlowThreshold: slowThreshold}
+}
+
+// LogMode implements logger.Interface. This logger's verbosity is driven
+// by logrus's own level rather than gorm's LogLevel, so it's a no-op.
+func (l *Logger) LogMode(logger.LogLevel) logger.Interface {
+	return l
+}
+
+func (l *Logger) Info(ctx context.Context, msg string, args ...interface{}) {
+	logrus.WithField("caller", utils.FileWithLineNum()).Infof(msg, args...)
+}
+
+func (l *Logger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	logrus.WithField("caller", utils.FileWithLineNum()).Warnf(msg, args...)
+}
+
+func (l *Logger) Error(ctx context.Context, msg string, args ...interface{}) {
+	logrus.WithField("caller", utils.FileWithLineNum()).Errorf(msg, args...)
+}
+
+// Trace logs one completed query: its SQL, row count, duration, and the
+// file/line of the repository method that issued it (gorm's own
+// utils.FileWithLineNum, which already knows how to skip past gorm's
+// internals to find it).
+func (l *Logger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	elapsed := time.Since(begin)
+	sql, rows := fc()
+	entry := logrus.WithFields(logrus.Fields{
+		"caller":      utils.FileWithLineNum(),
+		"sql":         sql,
+		"rows":        rows,
+		"duration_ms": elapsed.Milliseconds(),
+	})
+
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		entry.WithError(err).Error("query failed")
+	case l.SlowThreshold > 0 && elapsed > l.SlowThreshold:
+		entry.Warn("slow query")
+	default:
+		entry.Debug("query")
+	}
+}
@@ -0,0 +1,87 @@
+// Package errorreport forwards logrus entries at Error level and above to
+// an external error-tracking endpoint, so a production failure shows up
+// somewhere other than app.log. It isn't the Sentry SDK - that isn't
+// vendored in this module - but any endpoint that accepts a JSON POST,
+// Sentry included behind a suitable ingest adapter, can consume its
+// payload.
+package errorreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Hook is a logrus.Hook that POSTs Error/Fatal/Panic entries to DSN.
+type Hook struct {
+	DSN         string
+	Environment string
+	client      *http.Client
+}
+
+// NewHook builds a Hook that reports to dsn, tagging every event with
+// environment (e.g. "production", "staging").
+func NewHook(dsn, environment string) *Hook {
+	return &Hook{DSN: dsn, Environment: environment, client: &http.Client{Timeout: 5 * time.Second}}
+}
+
+// Levels reports the levels this hook fires on.
+func (h *Hook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel}
+}
+
+// event is the payload POSTed to DSN.
+type event struct {
+	Level       string                 `json:"level"`
+	Message     string                 `json:"message"`
+	Timestamp   time.Time              `json:"timestamp"`
+	Environment string                 `json:"environment"`
+	RequestID   string                 `json:"request_id,omitempty"`
+	UserID      string                 `json:"user_id,omitempty"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+}
+
+// Fire sends entry to DSN in the background, best-effort - a reporting
+// outage shouldn't block or fail whatever just logged the error.
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	ev := event{
+		Level:       entry.Level.String(),
+		Message:     entry.Message,
+		Timestamp:   entry.Time,
+		Environment: h.Environment,
+		Extra:       make(map[string]interface{}),
+	}
+	for k, v := range entry.Data {
+		switch k {
+		case "request_id":
+			ev.RequestID = fmt.Sprint(v)
+		case "user_id":
+			ev.UserID = fmt.Sprint(v)
+		default:
+			ev.Extra[k] = v
+		}
+	}
+
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return nil
+	}
+
+	go func() {
+		req, err := http.NewRequest(http.MethodPost, h.DSN, bytes.NewReader(body))
+		if err != nil {
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := h.client.Do(req)
+		if err != nil {
+			return
+		}
+		resp.Body.Close()
+	}()
+	return nil
+}
@@ -0,0 +1,40 @@
+// Package etag answers conditional GET requests for read-mostly catalog
+// endpoints. It hashes the response body rather than relying on an
+// updated_at column, since not every model that needs this (Collection, in
+// particular) tracks one.
+package etag
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Respond writes v as JSON with an ETag header derived from its content, or
+// a bare 304 Not Modified if the request's If-None-Match already matches
+// it.
+func Respond(c *gin.Context, status int, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to encode response"})
+		return
+	}
+
+	tag := compute(body)
+	c.Header("ETag", tag)
+
+	if c.GetHeader("If-None-Match") == tag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(status, "application/json; charset=utf-8", body)
+}
+
+func compute(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
@@ -0,0 +1,67 @@
+// Package events is a small in-process publish/subscribe bus for domain
+// events - a user registering, a product's stock changing - that more
+// than one subsystem cares about, so wiring up a new integration is a
+// matter of registering a subscriber instead of editing the handler that
+// caused the event.
+//
+// It is deliberately narrower than two other pub/sub-shaped things
+// already in this codebase: orderevents.Broker fans a single order's
+// status out to its own Redis channel for the "track my order" page to
+// stream, and OutboxEventRepository/OutboxRelayService is the
+// transactional outbox that reliably delivers events to external webhook
+// subscribers. Neither is a general bus for internal subsystems to react
+// to each other's writes, which is the gap this package fills.
+package events
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Event names. Subscribers match on these via Bus.Subscribe.
+const (
+	// UserRegistered fires once a new account is created. Payload is
+	// UserRegisteredPayload.
+	UserRegistered = "user.registered"
+	// OrderCreated fires once an order is placed. Nothing in this
+	// codebase publishes it yet - there is no order-creation endpoint to
+	// hook it to, the same gap noted by OrderCancellationService and
+	// AbandonedCartReminderRepository.MarkConverted.
+	OrderCreated = "order.created"
+	// ProductStockChanged fires whenever a product's total stock across
+	// warehouses changes. Payload is ProductStockChangedPayload.
+	ProductStockChanged = "product.stock_changed"
+)
+
+// Event is one occurrence of a named domain event. Payload is one of the
+// *Payload types declared alongside the event name it goes with.
+type Event struct {
+	Name    string
+	Payload interface{}
+}
+
+// Handler reacts to one event. A returned error is logged by the Bus, not
+// propagated to the publisher - a slow or failing subscriber never affects
+// the request that published the event.
+type Handler func(ctx context.Context, event Event) error
+
+// Bus publishes events to every handler subscribed to their name.
+type Bus interface {
+	Publish(ctx context.Context, event Event)
+	Subscribe(name string, handler Handler)
+}
+
+// UserRegisteredPayload is Event.Payload for UserRegistered.
+type UserRegisteredPayload struct {
+	UserID    uint
+	Email     string
+	FirstName string
+}
+
+// ProductStockChangedPayload is Event.Payload for ProductStockChanged.
+type ProductStockChangedPayload struct {
+	ProductID     uuid.UUID
+	PreviousTotal int
+	NewTotal      int
+}
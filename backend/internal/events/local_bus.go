@@ -0,0 +1,45 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LocalBus is an in-process Bus: every subscriber runs synchronously, in
+// the goroutine that called Publish, in registration order. A Redis
+// Streams-backed Bus would let subscribers run out-of-process, but nothing
+// in this codebase needs that yet, so only LocalBus exists.
+type LocalBus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+// NewLocalBus builds an empty LocalBus.
+func NewLocalBus() *LocalBus {
+	return &LocalBus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe registers handler to run on every future Publish of name.
+// Register every subscriber during startup, before anything that publishes
+// is wired up to receive requests.
+func (b *LocalBus) Subscribe(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish runs every handler subscribed to event.Name. A handler's error
+// is logged and does not stop the remaining handlers from running.
+func (b *LocalBus) Publish(ctx context.Context, event Event) {
+	b.mu.RLock()
+	handlers := b.handlers[event.Name]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			logrus.WithError(err).WithField("event", event.Name).Warn("events: subscriber failed")
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Package featureflag evaluates FeatureFlag percentage rollouts. It has no
+// DB or HTTP dependency so it can be called directly from the service layer
+// or from middleware without either depending on the other.
+package featureflag
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// Evaluate reports whether a flag is on for a given user: false if the flag
+// itself is disabled, true unconditionally at 100% rollout, and otherwise a
+// stable per-user decision, so the same user always lands on the same side
+// of the rollout instead of flapping between requests.
+func Evaluate(enabled bool, rolloutPercent int, key string, userID uint) bool {
+	if !enabled {
+		return false
+	}
+	if rolloutPercent >= 100 {
+		return true
+	}
+	if rolloutPercent <= 0 {
+		return false
+	}
+
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s:%d", key, userID)
+	return int(h.Sum32()%100) < rolloutPercent
+}
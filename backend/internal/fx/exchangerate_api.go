@@ -0,0 +1,62 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const exchangeRateAPIBase = "https://api.exchangerate.host"
+
+// APISource fetches rates from exchangerate.host
+// (https://exchangerate.host/documentation), a free FX rate API.
+type APISource struct {
+	apiKey string
+	client *http.Client
+}
+
+// NewAPISource builds an APISource authenticating with apiKey.
+func NewAPISource(apiKey string) *APISource {
+	return &APISource{
+		apiKey: apiKey,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type exchangeRateAPIResponse struct {
+	Success bool               `json:"success"`
+	Rates   map[string]float64 `json:"rates"`
+}
+
+// FetchRates fetches every rate quoted against base.
+func (s *APISource) FetchRates(ctx context.Context, base string) (map[string]float64, error) {
+	endpoint := fmt.Sprintf("%s/live?source=%s&access_key=%s", exchangeRateAPIBase, url.QueryEscape(base), url.QueryEscape(s.apiKey))
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fx: build request: %w", err)
+	}
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("fx: request rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("fx: unexpected status %d", resp.StatusCode)
+	}
+
+	var parsed exchangeRateAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("fx: decode response: %w", err)
+	}
+	if !parsed.Success {
+		return nil, fmt.Errorf("fx: source reported failure")
+	}
+
+	return parsed.Rates, nil
+}
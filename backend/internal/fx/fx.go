@@ -0,0 +1,12 @@
+// Package fx defines the gateway-agnostic interface for fetching foreign
+// exchange rates, plus one or more concrete source implementations.
+package fx
+
+import "context"
+
+// Source fetches exchange rates for base against every currency it
+// knows, e.g. from a public FX API. Rates are expressed as "how many
+// units of the quote currency equal one unit of base".
+type Source interface {
+	FetchRates(ctx context.Context, base string) (map[string]float64, error)
+}
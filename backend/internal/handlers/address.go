@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/addressvalidation"
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+// AddressHandler lets a customer manage the delivery addresses saved to
+// their account.
+type AddressHandler struct {
+	addresses *psql.AddressRepository
+	validator addressvalidation.Validator
+}
+
+// NewAddressHandler builds an AddressHandler with its dependencies.
+func NewAddressHandler(addresses *psql.AddressRepository, validator addressvalidation.Validator) *AddressHandler {
+	return &AddressHandler{addresses: addresses, validator: validator}
+}
+
+// normalize runs an address through the configured Validator and, on a
+// confident match, overwrites its structured fields with the normalized
+// form and records the geocoordinates. A validator error or a low-
+// confidence match leaves the address exactly as the customer entered it -
+// validation is a quality-of-life improvement, not a gate on saving.
+func (h *AddressHandler) normalize(c *gin.Context, address *models.Address) {
+	result, err := h.validator.Validate(c.Request.Context(), addressvalidation.Input{
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		Region:     address.Region,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+	})
+	if err != nil || !result.Valid {
+		return
+	}
+	address.Line1 = result.Line1
+	address.City = result.City
+	address.Region = result.Region
+	address.PostalCode = result.PostalCode
+	address.Country = result.Country
+	address.Latitude = result.Latitude
+	address.Longitude = result.Longitude
+	address.Validated = true
+}
+
+// AddressRequest is the payload accepted by Create and Update.
+type AddressRequest struct {
+	Label      string `json:"label"`
+	Recipient  string `json:"recipient" binding:"required"`
+	Phone      string `json:"phone" binding:"required"`
+	Line1      string `json:"line1" binding:"required"`
+	Line2      string `json:"line2"`
+	City       string `json:"city" binding:"required"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postal_code" binding:"required"`
+	Country    string `json:"country" binding:"required"`
+	IsDefault  bool   `json:"is_default"`
+}
+
+// List returns every address the caller has saved.
+func (h *AddressHandler) List(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	addresses, err := h.addresses.ListByUser(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load addresses"})
+		return
+	}
+	c.JSON(http.StatusOK, addresses)
+}
+
+// Create saves a new address to the caller's account. If it's the first
+// address, or IsDefault is set, it becomes the default.
+func (h *AddressHandler) Create(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	var req AddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.IsDefault {
+		if err := h.addresses.ClearDefault(c.Request.Context(), userID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update default address"})
+			return
+		}
+	} else {
+		existing, err := h.addresses.ListByUser(c.Request.Context(), userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load addresses"})
+			return
+		}
+		req.IsDefault = len(existing) == 0
+	}
+
+	address := &models.Address{
+		UserID:     userID,
+		Label:      req.Label,
+		Recipient:  req.Recipient,
+		Phone:      req.Phone,
+		Line1:      req.Line1,
+		Line2:      req.Line2,
+		City:       req.City,
+		Region:     req.Region,
+		PostalCode: req.PostalCode,
+		Country:    req.Country,
+		IsDefault:  req.IsDefault,
+	}
+	h.normalize(c, address)
+
+	if err := h.addresses.Create(c.Request.Context(), address); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save address"})
+		return
+	}
+	c.JSON(http.StatusCreated, address)
+}
+
+// Update replaces an existing address's fields, scoped to the caller.
+func (h *AddressHandler) Update(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	address, err := h.addresses.GetByID(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "address not found"})
+		return
+	}
+
+	var req AddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.IsDefault && !address.IsDefault {
+		if err := h.addresses.ClearDefault(c.Request.Context(), userID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update default address"})
+			return
+		}
+	}
+
+	address.Label = req.Label
+	address.Recipient = req.Recipient
+	address.Phone = req.Phone
+	address.Line1 = req.Line1
+	address.Line2 = req.Line2
+	address.City = req.City
+	address.Region = req.Region
+	address.PostalCode = req.PostalCode
+	address.Country = req.Country
+	address.IsDefault = req.IsDefault
+	address.Validated = false
+
+	h.normalize(c, address)
+
+	if err := h.addresses.Update(c.Request.Context(), address); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update address"})
+		return
+	}
+	c.JSON(http.StatusOK, address)
+}
+
+// Delete removes an address owned by the caller.
+func (h *AddressHandler) Delete(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	if err := h.addresses.Delete(c.Request.Context(), userID, c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete address"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/pagination"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultAuditLogPageSize = 50
+	maxAuditLogPageSize     = 200
+)
+
+// AdminAuditLogHandler exposes the audit log of admin mutations for
+// back-office use.
+type AdminAuditLogHandler struct {
+	auditLogs *psql.AuditLogRepository
+}
+
+// NewAdminAuditLogHandler builds an AdminAuditLogHandler with its
+// dependencies.
+func NewAdminAuditLogHandler(auditLogs *psql.AuditLogRepository) *AdminAuditLogHandler {
+	return &AdminAuditLogHandler{auditLogs: auditLogs}
+}
+
+// List returns a page of audit log entries, newest first. Query params:
+// actor_id, action, entity_type, entity_id (all exact-match filters), page
+// (1-based, default 1), page_size (default 50, capped at 200).
+func (h *AdminAuditLogHandler) List(c *gin.Context) {
+	var filter psql.AuditLogFilter
+	if actorID, err := strconv.ParseUint(c.Query("actor_id"), 10, 64); err == nil {
+		filter.ActorID = uint(actorID)
+	}
+	filter.Action = c.Query("action")
+	filter.EntityType = c.Query("entity_type")
+	filter.EntityID = c.Query("entity_id")
+
+	page, pageSize, offset := pagination.ParseParams(c, defaultAuditLogPageSize, maxAuditLogPageSize)
+
+	logs, total, err := h.auditLogs.List(c.Request.Context(), filter, pageSize, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load audit log"})
+		return
+	}
+
+	c.JSON(http.StatusOK, pagination.New[models.AuditLog](logs, total, page, pageSize))
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminCartHandler exposes cart-related back-office reports.
+type AdminCartHandler struct {
+	cart               *psql.CartRepository
+	abandonedAfterDays int
+}
+
+// NewAdminCartHandler builds an AdminCartHandler with its dependencies.
+// abandonedAfterDays is the default cutoff used when the request does not
+// override it via the "days" query parameter.
+func NewAdminCartHandler(cart *psql.CartRepository, abandonedAfterDays int) *AdminCartHandler {
+	return &AdminCartHandler{cart: cart, abandonedAfterDays: abandonedAfterDays}
+}
+
+// Abandoned lists cart items that have not been updated in the configured
+// (or overridden) number of days, so marketing can target reminder emails.
+func (h *AdminCartHandler) Abandoned(c *gin.Context) {
+	days := h.abandonedAfterDays
+	if raw := c.Query("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid 'days' parameter"})
+			return
+		}
+		days = parsed
+	}
+
+	cutoff := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+	items, err := h.cart.ListAbandoned(c.Request.Context(), cutoff)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load abandoned carts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"cutoff": cutoff, "items": items})
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AdminCollectionHandler exposes collection CRUD and product association for
+// back-office use.
+type AdminCollectionHandler struct {
+	collections *psql.CollectionRepository
+	media       *service.MediaService
+}
+
+// NewAdminCollectionHandler builds an AdminCollectionHandler with its dependencies.
+func NewAdminCollectionHandler(collections *psql.CollectionRepository, media *service.MediaService) *AdminCollectionHandler {
+	return &AdminCollectionHandler{collections: collections, media: media}
+}
+
+// CollectionRequest is the payload accepted by Create and Update.
+type CollectionRequest struct {
+	Name        string     `json:"name" binding:"required"`
+	Slug        string     `json:"slug" binding:"required"`
+	Description string     `json:"description"`
+	IsActive    bool       `json:"is_active"`
+	LaunchDate  *time.Time `json:"launch_date"`
+	EndDate     *time.Time `json:"end_date"`
+
+	MetaTitle       string `json:"meta_title"`
+	MetaDescription string `json:"meta_description"`
+	Keywords        string `json:"keywords"`
+
+	DiscountPercent    int        `json:"discount_percent"`
+	DiscountValidFrom  *time.Time `json:"discount_valid_from"`
+	DiscountValidUntil *time.Time `json:"discount_valid_until"`
+}
+
+// List returns every collection, active or not.
+func (h *AdminCollectionHandler) List(c *gin.Context) {
+	collections, err := h.collections.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load collections"})
+		return
+	}
+	c.JSON(http.StatusOK, collections)
+}
+
+// Create adds a new collection.
+func (h *AdminCollectionHandler) Create(c *gin.Context) {
+	var req CollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	collection := &models.Collection{
+		Name:        req.Name,
+		Slug:        req.Slug,
+		Description: req.Description,
+		IsActive:    req.IsActive,
+		LaunchDate:  req.LaunchDate,
+		EndDate:     req.EndDate,
+
+		MetaTitle:       req.MetaTitle,
+		MetaDescription: req.MetaDescription,
+		Keywords:        req.Keywords,
+
+		DiscountPercent:    req.DiscountPercent,
+		DiscountValidFrom:  req.DiscountValidFrom,
+		DiscountValidUntil: req.DiscountValidUntil,
+	}
+	if err := h.collections.Create(c.Request.Context(), collection); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create collection"})
+		return
+	}
+	c.JSON(http.StatusCreated, collection)
+}
+
+// Update replaces an existing collection's fields.
+func (h *AdminCollectionHandler) Update(c *gin.Context) {
+	collection, err := h.collections.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "collection not found"})
+		return
+	}
+
+	var req CollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	collection.Name = req.Name
+	collection.Slug = req.Slug
+	collection.Description = req.Description
+	collection.IsActive = req.IsActive
+	collection.LaunchDate = req.LaunchDate
+	collection.EndDate = req.EndDate
+	collection.MetaTitle = req.MetaTitle
+	collection.MetaDescription = req.MetaDescription
+	collection.Keywords = req.Keywords
+	collection.DiscountPercent = req.DiscountPercent
+	collection.DiscountValidFrom = req.DiscountValidFrom
+	collection.DiscountValidUntil = req.DiscountValidUntil
+
+	if err := h.collections.Update(c.Request.Context(), collection); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update collection"})
+		return
+	}
+	c.JSON(http.StatusOK, collection)
+}
+
+// Delete removes a collection by ID.
+func (h *AdminCollectionHandler) Delete(c *gin.Context) {
+	if _, err := h.collections.GetByID(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "collection not found"})
+		return
+	}
+	if err := h.collections.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete collection"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AddProductRequest is the payload accepted by AddProduct.
+type AddProductRequest struct {
+	ProductID string `json:"product_id" binding:"required"`
+}
+
+// AddProduct links a product into a collection.
+func (h *AdminCollectionHandler) AddProduct(c *gin.Context) {
+	if _, err := h.collections.GetByID(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "collection not found"})
+		return
+	}
+
+	var req AddProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid collection id"})
+		return
+	}
+	productID, err := uuid.Parse(req.ProductID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product_id"})
+		return
+	}
+
+	if err := h.collections.AddProduct(c.Request.Context(), collectionID, productID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add product to collection"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// UploadCoverImage validates and stores an uploaded cover image, recording
+// the banner and thumbnail variant names alongside the original. See
+// service.MediaService.AssignCoverImage for the current resizing caveat.
+func (h *AdminCollectionHandler) UploadCoverImage(c *gin.Context) {
+	collection, err := h.collections.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "collection not found"})
+		return
+	}
+
+	fh, err := c.FormFile("cover_image")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'cover_image' file field"})
+		return
+	}
+
+	variants, err := h.media.AssignCoverImage(fh)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	collection.CoverImage = variants.Original
+	collection.CoverImageBanner = variants.Banner
+	collection.CoverImageThumbnail = variants.Thumbnail
+	if err := h.collections.Update(c.Request.Context(), collection); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save collection"})
+		return
+	}
+	c.JSON(http.StatusOK, collection)
+}
+
+// RemoveProduct unlinks a product from a collection.
+func (h *AdminCollectionHandler) RemoveProduct(c *gin.Context) {
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid collection id"})
+		return
+	}
+	productID, err := uuid.Parse(c.Param("productID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	if err := h.collections.RemoveProduct(c.Request.Context(), collectionID, productID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove product from collection"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+// parseValidityWindow parses optional YYYY-MM-DD bounds for a coupon; a
+// blank bound is left as the zero time, meaning "no restriction".
+func parseValidityWindow(from, until string) (time.Time, time.Time, error) {
+	var (
+		validFrom  time.Time
+		validUntil time.Time
+		err        error
+	)
+	if from != "" {
+		if validFrom, err = time.Parse(dateLayout, from); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if until != "" {
+		if validUntil, err = time.Parse(dateLayout, until); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	return validFrom, validUntil, nil
+}
+
+// AdminCouponHandler exposes promo-code CRUD for back-office use.
+type AdminCouponHandler struct {
+	coupons *psql.CouponRepository
+}
+
+// NewAdminCouponHandler builds an AdminCouponHandler with its dependencies.
+func NewAdminCouponHandler(coupons *psql.CouponRepository) *AdminCouponHandler {
+	return &AdminCouponHandler{coupons: coupons}
+}
+
+// CouponRequest is the payload accepted by Create and Update.
+type CouponRequest struct {
+	Code          string  `json:"code" binding:"required"`
+	Type          string  `json:"type" binding:"required,oneof=percent fixed"`
+	Amount        float64 `json:"amount" binding:"required,gt=0"`
+	MinOrderTotal float64 `json:"min_order_total"`
+	CategoryID    *uint   `json:"category_id"`
+	MaxUses       int     `json:"max_uses"`
+	ValidFrom     string  `json:"valid_from"`
+	ValidUntil    string  `json:"valid_until"`
+	Active        bool    `json:"active"`
+}
+
+// List returns every coupon.
+func (h *AdminCouponHandler) List(c *gin.Context) {
+	coupons, err := h.coupons.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load coupons"})
+		return
+	}
+	c.JSON(http.StatusOK, coupons)
+}
+
+// Create adds a new coupon.
+func (h *AdminCouponHandler) Create(c *gin.Context) {
+	var req CouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	validFrom, validUntil, err := parseValidityWindow(req.ValidFrom, req.ValidUntil)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	coupon := &models.Coupon{
+		Code:          req.Code,
+		Type:          req.Type,
+		Amount:        req.Amount,
+		MinOrderTotal: req.MinOrderTotal,
+		CategoryID:    req.CategoryID,
+		MaxUses:       req.MaxUses,
+		ValidFrom:     validFrom,
+		ValidUntil:    validUntil,
+		Active:        req.Active,
+	}
+	if err := h.coupons.Create(c.Request.Context(), coupon); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create coupon"})
+		return
+	}
+	c.JSON(http.StatusCreated, coupon)
+}
+
+// Update replaces an existing coupon's fields.
+func (h *AdminCouponHandler) Update(c *gin.Context) {
+	coupon, err := h.coupons.GetByCode(c.Request.Context(), c.Param("code"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "coupon not found"})
+		return
+	}
+
+	var req CouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	validFrom, validUntil, err := parseValidityWindow(req.ValidFrom, req.ValidUntil)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	coupon.Type = req.Type
+	coupon.Amount = req.Amount
+	coupon.MinOrderTotal = req.MinOrderTotal
+	coupon.CategoryID = req.CategoryID
+	coupon.MaxUses = req.MaxUses
+	coupon.ValidFrom = validFrom
+	coupon.ValidUntil = validUntil
+	coupon.Active = req.Active
+
+	if err := h.coupons.Update(c.Request.Context(), coupon); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update coupon"})
+		return
+	}
+	c.JSON(http.StatusOK, coupon)
+}
+
+// Delete removes a coupon by code.
+func (h *AdminCouponHandler) Delete(c *gin.Context) {
+	coupon, err := h.coupons.GetByCode(c.Request.Context(), c.Param("code"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "coupon not found"})
+		return
+	}
+	if err := h.coupons.Delete(c.Request.Context(), coupon.ID.String()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete coupon"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
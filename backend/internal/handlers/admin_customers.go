@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/pagination"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultCustomerPageSize = 50
+	maxCustomerPageSize     = 200
+)
+
+// Customer segment labels, in rough order of marketing priority. They're a
+// simplified take on RFM (recency/frequency/monetary) scoring - enough to
+// point a campaign at the right list without standing up a real scoring
+// model.
+const (
+	SegmentChampion = "champion"
+	SegmentAtRisk   = "at_risk"
+	SegmentNew      = "new"
+	SegmentRegular  = "regular"
+)
+
+// AdminCustomerHandler exposes customer lifetime-value and segmentation
+// reporting for back-office use.
+type AdminCustomerHandler struct {
+	orders *psql.OrderRepository
+}
+
+// NewAdminCustomerHandler builds an AdminCustomerHandler with its
+// dependencies.
+func NewAdminCustomerHandler(orders *psql.OrderRepository) *AdminCustomerHandler {
+	return &AdminCustomerHandler{orders: orders}
+}
+
+// CustomerSegment is one customer's lifetime-value stats plus a derived
+// segment label.
+type CustomerSegment struct {
+	psql.CustomerStatsRow
+	Segment string `json:"segment"`
+}
+
+// Segments returns a page of customers with at least one paid order, newest
+// spenders first, each tagged with a segment label based on their order
+// count and recency.
+func (h *AdminCustomerHandler) Segments(c *gin.Context) {
+	page, pageSize, offset := pagination.ParseParams(c, defaultCustomerPageSize, maxCustomerPageSize)
+
+	rows, total, err := h.orders.CustomerStats(c.Request.Context(), pageSize, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load customer stats"})
+		return
+	}
+
+	now := time.Now()
+	segments := make([]CustomerSegment, len(rows))
+	for i, row := range rows {
+		segments[i] = CustomerSegment{CustomerStatsRow: row, Segment: segmentFor(row, now)}
+	}
+
+	c.JSON(http.StatusOK, pagination.New[CustomerSegment](segments, total, page, pageSize))
+}
+
+// segmentFor derives a simple RFM-style label from a customer's order
+// count and how long it's been since their last order:
+//   - champion: 5+ orders and bought within the last 90 days
+//   - at_risk: hasn't ordered in 180+ days
+//   - new: exactly one order
+//   - regular: everyone else
+func segmentFor(row psql.CustomerStatsRow, now time.Time) string {
+	daysSinceLastOrder := now.Sub(row.LastOrderDate).Hours() / 24
+
+	switch {
+	case row.OrderCount >= 5 && daysSinceLastOrder <= 90:
+		return SegmentChampion
+	case daysSinceLastOrder > 180:
+		return SegmentAtRisk
+	case row.OrderCount == 1:
+		return SegmentNew
+	default:
+		return SegmentRegular
+	}
+}
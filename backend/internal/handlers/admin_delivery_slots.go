@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminDeliverySlotHandler exposes delivery-slot CRUD for back-office use.
+type AdminDeliverySlotHandler struct {
+	slots *psql.DeliverySlotRepository
+}
+
+// NewAdminDeliverySlotHandler builds an AdminDeliverySlotHandler with its dependencies.
+func NewAdminDeliverySlotHandler(slots *psql.DeliverySlotRepository) *AdminDeliverySlotHandler {
+	return &AdminDeliverySlotHandler{slots: slots}
+}
+
+// DeliverySlotRequest is the payload accepted by Create and Update.
+type DeliverySlotRequest struct {
+	Weekday   int    `json:"weekday" binding:"gte=0,lte=6"`
+	StartTime string `json:"start_time" binding:"required"`
+	EndTime   string `json:"end_time" binding:"required"`
+	Capacity  int    `json:"capacity" binding:"required,gt=0"`
+	Enabled   bool   `json:"enabled"`
+}
+
+// List returns every configured delivery slot.
+func (h *AdminDeliverySlotHandler) List(c *gin.Context) {
+	slots, err := h.slots.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load delivery slots"})
+		return
+	}
+	c.JSON(http.StatusOK, slots)
+}
+
+// Create adds a new delivery slot.
+func (h *AdminDeliverySlotHandler) Create(c *gin.Context) {
+	var req DeliverySlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	slot := &models.DeliverySlot{
+		Weekday:   req.Weekday,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Capacity:  req.Capacity,
+		Enabled:   req.Enabled,
+	}
+	if err := h.slots.Create(c.Request.Context(), slot); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create delivery slot"})
+		return
+	}
+	c.JSON(http.StatusCreated, slot)
+}
+
+// Update replaces an existing delivery slot's fields.
+func (h *AdminDeliverySlotHandler) Update(c *gin.Context) {
+	slot, err := h.slots.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "delivery slot not found"})
+		return
+	}
+
+	var req DeliverySlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	slot.Weekday = req.Weekday
+	slot.StartTime = req.StartTime
+	slot.EndTime = req.EndTime
+	slot.Capacity = req.Capacity
+	slot.Enabled = req.Enabled
+
+	if err := h.slots.Update(c.Request.Context(), slot); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update delivery slot"})
+		return
+	}
+	c.JSON(http.StatusOK, slot)
+}
+
+// Delete removes a delivery slot by ID.
+func (h *AdminDeliverySlotHandler) Delete(c *gin.Context) {
+	if _, err := h.slots.GetByID(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "delivery slot not found"})
+		return
+	}
+	if err := h.slots.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete delivery slot"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
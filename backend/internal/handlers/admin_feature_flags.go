@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminFeatureFlagHandler exposes admin management of feature flags.
+type AdminFeatureFlagHandler struct {
+	flags *psql.FeatureFlagRepository
+}
+
+// NewAdminFeatureFlagHandler builds an AdminFeatureFlagHandler with its dependencies.
+func NewAdminFeatureFlagHandler(flags *psql.FeatureFlagRepository) *AdminFeatureFlagHandler {
+	return &AdminFeatureFlagHandler{flags: flags}
+}
+
+// FeatureFlagRequest is the payload accepted by Set.
+type FeatureFlagRequest struct {
+	Enabled        bool `json:"enabled"`
+	RolloutPercent int  `json:"rollout_percent"`
+}
+
+// List returns every configured feature flag.
+func (h *AdminFeatureFlagHandler) List(c *gin.Context) {
+	flags, err := h.flags.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load feature flags"})
+		return
+	}
+	c.JSON(http.StatusOK, flags)
+}
+
+// Set creates or overwrites the feature flag identified by :key.
+func (h *AdminFeatureFlagHandler) Set(c *gin.Context) {
+	var req FeatureFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.RolloutPercent < 0 || req.RolloutPercent > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "rollout_percent must be between 0 and 100"})
+		return
+	}
+
+	flag := &models.FeatureFlag{
+		Key:            c.Param("key"),
+		Enabled:        req.Enabled,
+		RolloutPercent: req.RolloutPercent,
+	}
+	if err := h.flags.Set(c.Request.Context(), flag); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save feature flag"})
+		return
+	}
+	c.JSON(http.StatusOK, flag)
+}
+
+// Delete removes a feature flag by key.
+func (h *AdminFeatureFlagHandler) Delete(c *gin.Context) {
+	if err := h.flags.Delete(c.Request.Context(), c.Param("key")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete feature flag"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminInventoryDashboardHandler serves the precomputed low-stock/reorder
+// dashboard.
+type AdminInventoryDashboardHandler struct {
+	suggestions *service.ReorderSuggestionService
+}
+
+// NewAdminInventoryDashboardHandler builds an AdminInventoryDashboardHandler
+// with its dependencies.
+func NewAdminInventoryDashboardHandler(suggestions *service.ReorderSuggestionService) *AdminInventoryDashboardHandler {
+	return &AdminInventoryDashboardHandler{suggestions: suggestions}
+}
+
+// LowStock returns the last computed low-stock/reorder dashboard. It's
+// refreshed on a background schedule rather than per request, so a slow
+// refresh never blocks this endpoint; it responds 503 until the first
+// refresh completes.
+func (h *AdminInventoryDashboardHandler) LowStock(c *gin.Context) {
+	rows, hit, err := h.suggestions.Get(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load low-stock dashboard"})
+		return
+	}
+	if !hit {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "low-stock dashboard has not been computed yet"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rows)
+}
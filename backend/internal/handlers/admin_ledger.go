@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AdminLedgerHandler exposes the double-entry ledger for finance audit.
+type AdminLedgerHandler struct {
+	entries *psql.LedgerEntryRepository
+}
+
+// NewAdminLedgerHandler builds an AdminLedgerHandler around a LedgerEntryRepository.
+func NewAdminLedgerHandler(entries *psql.LedgerEntryRepository) *AdminLedgerHandler {
+	return &AdminLedgerHandler{entries: entries}
+}
+
+// Reconciliation reports total debits and credits per ledger account, so
+// finance can confirm the books balance without trusting mutable order or
+// payment rows.
+func (h *AdminLedgerHandler) Reconciliation(c *gin.Context) {
+	balances, err := h.entries.Reconciliation(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build reconciliation report"})
+		return
+	}
+	c.JSON(http.StatusOK, balances)
+}
+
+// OrderLedger lists every ledger entry recorded against a single order, the
+// full audit trail behind its current Order.PaymentStatus.
+func (h *AdminLedgerHandler) OrderLedger(c *gin.Context) {
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order id"})
+		return
+	}
+	entries, err := h.entries.ListByOrder(c.Request.Context(), orderID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load ledger entries"})
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
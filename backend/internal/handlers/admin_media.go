@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/queue"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminMediaHandler exposes bulk media-management operations.
+type AdminMediaHandler struct {
+	media *service.MediaService
+	jobs  *queue.Queue
+}
+
+// NewAdminMediaHandler builds an AdminMediaHandler with its dependencies.
+func NewAdminMediaHandler(media *service.MediaService, jobs *queue.Queue) *AdminMediaHandler {
+	return &AdminMediaHandler{media: media, jobs: jobs}
+}
+
+// BulkAssignMedia accepts a ZIP of images named "<SKU>.<ext>" and assigns
+// each to the matching product asynchronously, returning a job ID to poll
+// for the per-file success/error report.
+func (h *AdminMediaHandler) BulkAssignMedia(c *gin.Context) {
+	fileHeader, err := c.FormFile("archive")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'archive' file field"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read uploaded file"})
+		return
+	}
+
+	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "uploaded file is not a valid ZIP"})
+		return
+	}
+
+	// The job outlives this request, so it gets its own context rather than
+	// c.Request.Context(), which is cancelled as soon as we respond below.
+	jobID := h.jobs.Enqueue(func() (interface{}, error) {
+		return h.media.AssignZIP(context.Background(), zipReader), nil
+	})
+
+	c.JSON(http.StatusAccepted, gin.H{"job_id": jobID})
+}
+
+// BulkAssignMediaStatus returns the status and, once done, the per-file
+// report of a previously submitted bulk media assignment job.
+func (h *AdminMediaHandler) BulkAssignMediaStatus(c *gin.Context) {
+	job, ok := h.jobs.Get(c.Param("jobID"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/apperr"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/orderevents"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/YoungGoofy/shopping/internal/sparsefields"
+	"github.com/YoungGoofy/shopping/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// AdminOrderHandler exposes order-related operations for back-office use.
+type AdminOrderHandler struct {
+	orders        *psql.OrderRepository
+	orderEvents   *orderevents.Broker
+	push          *service.PushNotificationService
+	notifications *service.NotificationService
+	baseURL       string
+}
+
+// NewAdminOrderHandler builds an AdminOrderHandler with its dependencies.
+// baseURL is the storefront's public URL, used to link back to the order
+// from a "your order shipped" email.
+func NewAdminOrderHandler(orders *psql.OrderRepository, orderEvents *orderevents.Broker, push *service.PushNotificationService, notifications *service.NotificationService, baseURL string) *AdminOrderHandler {
+	return &AdminOrderHandler{orders: orders, orderEvents: orderEvents, push: push, notifications: notifications, baseURL: baseURL}
+}
+
+const dateLayout = "2006-01-02"
+
+// ExportOrders streams orders placed within [from, to] as CSV, one row per
+// order line item, so accounting no longer has to pull the data via raw SQL.
+func (h *AdminOrderHandler) ExportOrders(c *gin.Context) {
+	from, err := time.Parse(dateLayout, c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or missing 'from' date, expected YYYY-MM-DD"})
+		return
+	}
+	to, err := time.Parse(dateLayout, c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or missing 'to' date, expected YYYY-MM-DD"})
+		return
+	}
+	// Make 'to' inclusive of the whole day.
+	to = to.Add(24*time.Hour - time.Nanosecond)
+
+	orders, err := h.orders.ListBetween(c.Request.Context(), from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load orders"})
+		return
+	}
+
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=orders_%s_%s.csv", c.Query("from"), c.Query("to")))
+
+	w := csv.NewWriter(c.Writer)
+	defer w.Flush()
+
+	_ = w.Write([]string{
+		"order_id", "order_date", "payment_status", "product_id", "product_name",
+		"size", "quantity", "price_at_time", "line_total", "vat_amount", "order_total",
+		"tags", "admin_note", "customer_note",
+	})
+
+	for _, order := range orders {
+		tags := strings.Join(order.Tags, ";")
+		for _, item := range order.Items {
+			lineTotal := item.PriceAtTime * float64(item.Quantity)
+			_ = w.Write([]string{
+				order.ID.String(),
+				order.CreatedDate.Format(time.RFC3339),
+				order.PaymentStatus,
+				item.ProductID.String(),
+				item.ProductName,
+				item.Size,
+				fmt.Sprintf("%d", item.Quantity),
+				fmt.Sprintf("%.2f", item.PriceAtTime),
+				fmt.Sprintf("%.2f", lineTotal),
+				fmt.Sprintf("%.2f", order.VATAmount),
+				fmt.Sprintf("%.2f", order.TotalAmount),
+				tags,
+				order.AdminNote,
+				// Note is a free-text field the customer set at checkout, so
+				// it's sanitized against CSV formula/DDE injection before
+				// this accounting export can open it in a spreadsheet app.
+				utils.SanitizeCSVField(order.Note),
+			})
+		}
+	}
+}
+
+// ListByTag returns orders carrying the given internal tag, for the admin
+// order list and warehouse pick list. A "fields" query parameter trims each
+// order down to the named fields.
+func (h *AdminOrderHandler) ListByTag(c *gin.Context) {
+	tag := c.Query("tag")
+	if tag == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'tag' query parameter"})
+		return
+	}
+
+	orders, err := h.orders.ListByTag(c.Request.Context(), tag)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load orders"})
+		return
+	}
+
+	sparsefields.Respond(c, http.StatusOK, orders)
+}
+
+// UpdateOrderMetaRequest is the payload accepted by UpdateMeta.
+type UpdateOrderMetaRequest struct {
+	AdminNote string   `json:"admin_note"`
+	Tags      []string `json:"tags"`
+}
+
+// UpdateMeta sets the internal admin note and tags on an order.
+func (h *AdminOrderHandler) UpdateMeta(c *gin.Context) {
+	var req UpdateOrderMetaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.orders.UpdateAdminMeta(c.Request.Context(), c.Param("id"), req.AdminNote, req.Tags); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update order"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateOrderStatusRequest is the payload accepted by UpdateStatus. Version
+// must match the order's current version, as returned by the last read -
+// it's how the caller proves it isn't overwriting someone else's change.
+type UpdateOrderStatusRequest struct {
+	Status  string `json:"status" binding:"required"`
+	Version int    `json:"version"`
+}
+
+// UpdateStatus transitions an order's status, failing with 409 if the order
+// was modified since the caller last read it.
+func (h *AdminOrderHandler) UpdateStatus(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order id"})
+		return
+	}
+
+	var req UpdateOrderStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.orders.UpdateStatus(c.Request.Context(), id, req.Version, req.Status); err != nil {
+		apperr.Respond(c, err)
+		return
+	}
+	_ = h.orderEvents.PublishStatus(c.Request.Context(), id, req.Status)
+
+	if order, err := h.orders.GetByID(c.Request.Context(), id.String()); err == nil {
+		if req.Status == models.OrderStatusShipped {
+			event := service.NotificationEvent{
+				Kind:          service.EventOrderShipped,
+				UserID:        order.UserID,
+				EmailTemplate: "order_shipped",
+				EmailData: map[string]interface{}{
+					"OrderNumber": order.ID.String(),
+					"TrackingURL": h.baseURL + "/orders/" + order.ID.String(),
+				},
+				PushTitle: "Order shipped",
+				PushBody:  "Your order is on its way",
+				PushData:  map[string]string{"order_id": id.String(), "status": req.Status},
+			}
+			if err := h.notifications.Dispatch(c.Request.Context(), event); err != nil {
+				logrus.WithError(err).WithField("order_id", id).Warn("admin_orders: failed to dispatch order shipped notification")
+			}
+		} else if err := h.push.NotifyOrderStatus(c.Request.Context(), order.UserID, id, req.Status); err != nil {
+			logrus.WithError(err).WithField("order_id", id).Warn("admin_orders: failed to push order status update")
+		}
+	}
+
+	c.Status(http.StatusNoContent)
+}
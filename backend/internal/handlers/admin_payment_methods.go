@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminPaymentMethodHandler exposes admin management of the payment methods
+// offered at checkout (which ones are enabled, and their eligibility rules).
+type AdminPaymentMethodHandler struct {
+	methods *psql.PaymentMethodRepository
+}
+
+// NewAdminPaymentMethodHandler builds an AdminPaymentMethodHandler with its dependencies.
+func NewAdminPaymentMethodHandler(methods *psql.PaymentMethodRepository) *AdminPaymentMethodHandler {
+	return &AdminPaymentMethodHandler{methods: methods}
+}
+
+// PaymentMethodRequest is the payload accepted by Set.
+type PaymentMethodRequest struct {
+	Label          string   `json:"label" binding:"required"`
+	Enabled        bool     `json:"enabled"`
+	MaxAmount      float64  `json:"max_amount"`
+	AllowedRegions []string `json:"allowed_regions"`
+}
+
+// List returns every configured payment method, enabled or not.
+func (h *AdminPaymentMethodHandler) List(c *gin.Context) {
+	methods, err := h.methods.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load payment methods"})
+		return
+	}
+	c.JSON(http.StatusOK, methods)
+}
+
+// Set creates or overwrites the payment method identified by :key.
+func (h *AdminPaymentMethodHandler) Set(c *gin.Context) {
+	var req PaymentMethodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	method := &models.PaymentMethod{
+		Key:            c.Param("key"),
+		Label:          req.Label,
+		Enabled:        req.Enabled,
+		MaxAmount:      req.MaxAmount,
+		AllowedRegions: models.StringList(req.AllowedRegions),
+	}
+	if err := h.methods.Set(c.Request.Context(), method); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save payment method"})
+		return
+	}
+	c.JSON(http.StatusOK, method)
+}
+
+// Delete removes a payment method by key.
+func (h *AdminPaymentMethodHandler) Delete(c *gin.Context) {
+	if err := h.methods.Delete(c.Request.Context(), c.Param("key")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete payment method"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/payments"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AdminPaymentHandler exposes payment refund operations for back-office use.
+type AdminPaymentHandler struct {
+	db       *gorm.DB
+	payments *psql.PaymentRepository
+	refunds  *psql.RefundRepository
+	orders   *psql.OrderRepository
+	provider payments.Provider
+	ledger   *service.LedgerService
+}
+
+// NewAdminPaymentHandler builds an AdminPaymentHandler with its dependencies.
+// db is used only to open the transaction Refund runs its writes and
+// outbox event in - every other operation still goes through the
+// repositories above.
+func NewAdminPaymentHandler(db *gorm.DB, paymentsRepo *psql.PaymentRepository, refunds *psql.RefundRepository, orders *psql.OrderRepository, provider payments.Provider, ledger *service.LedgerService) *AdminPaymentHandler {
+	return &AdminPaymentHandler{db: db, payments: paymentsRepo, refunds: refunds, orders: orders, provider: provider, ledger: ledger}
+}
+
+// refundOutboxPayload is the JSON body delivered to every webhook
+// subscriber for a "refund.issued" outbox event.
+type refundOutboxPayload struct {
+	RefundID  string  `json:"refund_id"`
+	OrderID   string  `json:"order_id"`
+	PaymentID string  `json:"payment_id"`
+	Amount    float64 `json:"amount"`
+	Currency  string  `json:"currency"`
+}
+
+// RefundPaymentRequest is the payload accepted by Refund. Amount is
+// optional - a zero or omitted amount refunds whatever remains captured.
+type RefundPaymentRequest struct {
+	Amount float64 `json:"amount"`
+	Reason string  `json:"reason"`
+}
+
+// Refund issues a full or partial refund against a captured payment.
+func (h *AdminPaymentHandler) Refund(c *gin.Context) {
+	payment, err := h.payments.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "payment not found"})
+		return
+	}
+	if payment.Status != models.PaymentStatusPaid {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "only a captured payment can be refunded"})
+		return
+	}
+
+	var req RefundPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	alreadyRefunded, err := h.refunds.SumSucceededByPayment(c.Request.Context(), payment.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check refund history"})
+		return
+	}
+	remaining := payment.Amount - alreadyRefunded
+
+	amount := req.Amount
+	if amount <= 0 {
+		amount = remaining
+	}
+	if amount > remaining {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "refund amount exceeds what remains captured", "remaining": remaining})
+		return
+	}
+
+	result, err := h.provider.CreateRefund(c.Request.Context(), payments.RefundRequest{
+		GatewayPaymentID: payment.GatewayPaymentID,
+		Amount:           amount,
+		Currency:         payment.Currency,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "failed to issue refund with gateway"})
+		return
+	}
+
+	refund := &models.Refund{
+		ID:              uuid.New(),
+		PaymentID:       payment.ID,
+		GatewayRefundID: result.GatewayRefundID,
+		Amount:          amount,
+		Status:          models.RefundStatusSucceeded,
+		Reason:          req.Reason,
+	}
+
+	orderPaymentStatus := models.PaymentStatusPartiallyRefunded
+	if amount == remaining {
+		orderPaymentStatus = models.PaymentStatusRefunded
+	}
+
+	payloadBytes, err := json.Marshal(refundOutboxPayload{
+		RefundID:  refund.ID.String(),
+		OrderID:   payment.OrderID.String(),
+		PaymentID: payment.ID.String(),
+		Amount:    amount,
+		Currency:  payment.Currency,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build refund event"})
+		return
+	}
+
+	err = psql.WithTx(c.Request.Context(), h.db, func(tx *psql.TxRepos) error {
+		if err := tx.Refunds.Create(c.Request.Context(), refund); err != nil {
+			return err
+		}
+		if err := tx.Orders.UpdatePaymentStatus(c.Request.Context(), payment.OrderID, orderPaymentStatus); err != nil {
+			return err
+		}
+		return tx.Outbox.Create(c.Request.Context(), &models.OutboxEvent{
+			AggregateType: "order",
+			AggregateID:   payment.OrderID.String(),
+			EventType:     "refund.issued",
+			Payload:       string(payloadBytes),
+		})
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to record refund"})
+		return
+	}
+
+	if err := h.ledger.RecordRefund(c.Request.Context(), payment.OrderID, refund.ID, amount, payment.Currency); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to record ledger entry"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, refund)
+}
+
+// MarkCollected settles a cash-on-delivery payment once the driver has
+// collected payment on delivery, feeding into the same Payment/Order
+// records a gateway confirmation would.
+func (h *AdminPaymentHandler) MarkCollected(c *gin.Context) {
+	payment, err := h.payments.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "payment not found"})
+		return
+	}
+	if payment.Status != models.PaymentStatusAwaitingDelivery {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "only a cash-on-delivery payment awaiting delivery can be marked collected"})
+		return
+	}
+
+	if err := h.payments.UpdateStatus(c.Request.Context(), payment.ID, models.PaymentStatusPaid); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update payment"})
+		return
+	}
+	if err := h.orders.UpdatePaymentStatus(c.Request.Context(), payment.OrderID, models.PaymentStatusPaid); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update order"})
+		return
+	}
+
+	if err := h.ledger.RecordCODSettlement(c.Request.Context(), payment.OrderID, payment.ID, payment.Amount, payment.Currency); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to record ledger entry"})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/queue"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminPickupPointHandler triggers a resync of the carrier pickup point
+// directory used by PickupPointHandler.Search.
+type AdminPickupPointHandler struct {
+	sync *service.PickupPointService
+	jobs *queue.Queue
+}
+
+// NewAdminPickupPointHandler builds an AdminPickupPointHandler with its dependencies.
+func NewAdminPickupPointHandler(sync *service.PickupPointService, jobs *queue.Queue) *AdminPickupPointHandler {
+	return &AdminPickupPointHandler{sync: sync, jobs: jobs}
+}
+
+// Sync resyncs every pickup point the carrier operates in a city,
+// asynchronously, returning a job ID to poll for completion.
+func (h *AdminPickupPointHandler) Sync(c *gin.Context) {
+	city := c.Query("city")
+	if city == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "city is required"})
+		return
+	}
+
+	// The job outlives this request, so it gets its own context rather than
+	// c.Request.Context(), which is cancelled as soon as we respond below.
+	jobID := h.jobs.Enqueue(func() (interface{}, error) {
+		return nil, h.sync.Sync(context.Background(), city)
+	})
+
+	c.JSON(http.StatusAccepted, gin.H{"job_id": jobID})
+}
+
+// SyncStatus returns the status of a previously submitted sync job.
+func (h *AdminPickupPointHandler) SyncStatus(c *gin.Context) {
+	job, ok := h.jobs.Get(c.Param("jobID"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
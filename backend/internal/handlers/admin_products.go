@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// AdminProductHandler exposes product content-quality operations.
+type AdminProductHandler struct {
+	products   *psql.ProductRepository
+	validation *service.ProductValidationService
+	searchJobs *psql.SearchIndexJobRepository
+}
+
+// NewAdminProductHandler builds an AdminProductHandler with its dependencies.
+func NewAdminProductHandler(products *psql.ProductRepository, validation *service.ProductValidationService, searchJobs *psql.SearchIndexJobRepository) *AdminProductHandler {
+	return &AdminProductHandler{products: products, validation: validation, searchJobs: searchJobs}
+}
+
+// enqueueSearchSync queues productID for SearchIndexService to sync to the
+// search engine. It runs after the triggering write has already committed,
+// so a failure here only delays the product showing up in search results -
+// it never rolls back the write. Logged rather than returned, since none
+// of this handler's callers should fail their request over it.
+func (h *AdminProductHandler) enqueueSearchSync(productID, action string) {
+	id, err := uuid.Parse(productID)
+	if err != nil {
+		logrus.WithError(err).WithField("product_id", productID).Error("admin products: invalid product id for search sync")
+		return
+	}
+	job := &models.SearchIndexJob{ProductID: id, Action: action}
+	if err := h.searchJobs.Create(context.Background(), job); err != nil {
+		logrus.WithError(err).WithField("product_id", productID).Error("admin products: failed to enqueue search sync")
+	}
+}
+
+// CompletenessReport returns the content completeness score for a product.
+func (h *AdminProductHandler) CompletenessReport(c *gin.Context) {
+	product, err := h.products.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "product not found"})
+		return
+	}
+	c.JSON(http.StatusOK, h.validation.Score(*product))
+}
+
+// Publish marks a product as published, refusing if its content score is
+// below the configured threshold.
+func (h *AdminProductHandler) Publish(c *gin.Context) {
+	product, err := h.products.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "product not found"})
+		return
+	}
+
+	completeness := h.validation.Score(*product)
+	if !h.validation.CanPublish(*product) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":        "product content is not complete enough to publish",
+			"completeness": completeness,
+		})
+		return
+	}
+
+	product.Published = true
+	if err := h.products.Update(c.Request.Context(), product); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to publish product"})
+		return
+	}
+	h.enqueueSearchSync(product.ID.String(), models.SearchJobActionUpsert)
+	c.JSON(http.StatusOK, product)
+}
+
+// Delete soft-deletes a product, hiding it from the storefront and every
+// other admin listing without touching order history that references it.
+func (h *AdminProductHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.products.Delete(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete product"})
+		return
+	}
+	h.enqueueSearchSync(id, models.SearchJobActionDelete)
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeleted returns soft-deleted products for the admin trash view.
+func (h *AdminProductHandler) ListDeleted(c *gin.Context) {
+	products, err := h.products.ListDeleted(c.Request.Context(), 50, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list deleted products"})
+		return
+	}
+	c.JSON(http.StatusOK, products)
+}
+
+// Restore undoes a soft delete, making the product visible again.
+func (h *AdminProductHandler) Restore(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.products.Restore(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to restore product"})
+		return
+	}
+	h.enqueueSearchSync(id, models.SearchJobActionUpsert)
+	c.Status(http.StatusOK)
+}
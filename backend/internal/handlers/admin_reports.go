@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/cache"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+// analyticsCacheTTL bounds how stale a top-products/category-revenue
+// response can be. These reports scan order_items over a date range, so
+// caching keeps merchandising dashboards from re-running that aggregation
+// on every page load.
+const analyticsCacheTTL = 10 * time.Minute
+
+// AdminReportHandler exposes reporting endpoints for finance/back-office use.
+type AdminReportHandler struct {
+	orders    *psql.OrderRepository
+	products  *psql.ProductRepository
+	search    *psql.SearchAnalyticsRepository
+	analytics *cache.Cache
+}
+
+// NewAdminReportHandler builds an AdminReportHandler with its dependencies.
+func NewAdminReportHandler(orders *psql.OrderRepository, products *psql.ProductRepository, search *psql.SearchAnalyticsRepository, analytics *cache.Cache) *AdminReportHandler {
+	return &AdminReportHandler{orders: orders, products: products, search: search, analytics: analytics}
+}
+
+// searchReportWindowDays bounds how far back TopSearchQueries and
+// ZeroResultQueries look, so a stale, long-abandoned query doesn't linger
+// at the top of the report forever.
+const searchReportWindowDays = 30
+
+// Sales returns revenue, order counts and units sold for [from, to],
+// bucketed by group_by ("day", "week" or "month", default "day"). A
+// request with an Accept: text/csv header gets the same rows as a CSV
+// download instead of JSON.
+func (h *AdminReportHandler) Sales(c *gin.Context) {
+	from, err := time.Parse(dateLayout, c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or missing 'from' date, expected YYYY-MM-DD"})
+		return
+	}
+	to, err := time.Parse(dateLayout, c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or missing 'to' date, expected YYYY-MM-DD"})
+		return
+	}
+	// Make 'to' inclusive of the whole day.
+	to = to.Add(24*time.Hour - time.Nanosecond)
+
+	groupBy := c.DefaultQuery("group_by", "day")
+
+	rows, err := h.orders.SalesReport(c.Request.Context(), from, to, groupBy)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or unsupported group_by, expected day, week or month"})
+		return
+	}
+
+	if strings.Contains(c.GetHeader("Accept"), "text/csv") {
+		writeSalesReportCSV(c, rows)
+		return
+	}
+	c.JSON(http.StatusOK, rows)
+}
+
+// Inventory returns stock level, stock value and turnover for [from, to],
+// optionally filtered to a single category via category_id, so purchasing
+// can plan restocks and spot dead stock. Accept: text/csv gets a CSV
+// download instead of JSON, same as Sales.
+func (h *AdminReportHandler) Inventory(c *gin.Context) {
+	from, err := time.Parse(dateLayout, c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or missing 'from' date, expected YYYY-MM-DD"})
+		return
+	}
+	to, err := time.Parse(dateLayout, c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or missing 'to' date, expected YYYY-MM-DD"})
+		return
+	}
+	to = to.Add(24*time.Hour - time.Nanosecond)
+
+	var categoryID *uint
+	if raw := c.Query("category_id"); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category_id"})
+			return
+		}
+		id := uint(parsed)
+		categoryID = &id
+	}
+
+	rows, err := h.products.InventoryReport(c.Request.Context(), categoryID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build inventory report"})
+		return
+	}
+
+	if strings.Contains(c.GetHeader("Accept"), "text/csv") {
+		writeInventoryReportCSV(c, rows)
+		return
+	}
+	c.JSON(http.StatusOK, rows)
+}
+
+// TopProducts returns the best-selling products by units sold for [from,
+// to], for merchandising decisions about what to feature or restock.
+// Defaults to the top 20, capped at 100 via limit.
+func (h *AdminReportHandler) TopProducts(c *gin.Context) {
+	from, err := time.Parse(dateLayout, c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or missing 'from' date, expected YYYY-MM-DD"})
+		return
+	}
+	to, err := time.Parse(dateLayout, c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or missing 'to' date, expected YYYY-MM-DD"})
+		return
+	}
+	to = to.Add(24*time.Hour - time.Nanosecond)
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	key := fmt.Sprintf("top_products:%s:%s:%d", c.Query("from"), c.Query("to"), limit)
+	var rows []psql.TopProductRow
+	if hit, err := h.analytics.Get(c.Request.Context(), key, &rows); err == nil && hit {
+		c.JSON(http.StatusOK, rows)
+		return
+	}
+
+	rows, err = h.orders.TopProducts(c.Request.Context(), from, to, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build top products report"})
+		return
+	}
+	_ = h.analytics.Set(c.Request.Context(), key, rows)
+
+	c.JSON(http.StatusOK, rows)
+}
+
+// CategoryRevenue returns units sold and revenue by category for [from,
+// to], ordered by revenue descending.
+func (h *AdminReportHandler) CategoryRevenue(c *gin.Context) {
+	from, err := time.Parse(dateLayout, c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or missing 'from' date, expected YYYY-MM-DD"})
+		return
+	}
+	to, err := time.Parse(dateLayout, c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or missing 'to' date, expected YYYY-MM-DD"})
+		return
+	}
+	to = to.Add(24*time.Hour - time.Nanosecond)
+
+	key := fmt.Sprintf("category_revenue:%s:%s", c.Query("from"), c.Query("to"))
+	var rows []psql.CategoryRevenueRow
+	if hit, err := h.analytics.Get(c.Request.Context(), key, &rows); err == nil && hit {
+		c.JSON(http.StatusOK, rows)
+		return
+	}
+
+	rows, err = h.orders.CategoryRevenue(c.Request.Context(), from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build category revenue report"})
+		return
+	}
+	_ = h.analytics.Set(c.Request.Context(), key, rows)
+
+	c.JSON(http.StatusOK, rows)
+}
+
+// TopSearchQueries returns the most-searched queries over the last
+// searchReportWindowDays days, most frequent first, so the catalog team
+// knows what customers are looking for. Defaults to the top 20, capped at
+// 100 via limit.
+func (h *AdminReportHandler) TopSearchQueries(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	since := time.Now().AddDate(0, 0, -searchReportWindowDays)
+	rows, err := h.search.TopQueries(c.Request.Context(), since, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build top search queries report"})
+		return
+	}
+	c.JSON(http.StatusOK, rows)
+}
+
+// ZeroResultQueries returns the most-searched queries that returned no
+// results over the last searchReportWindowDays days, most frequent first -
+// the list of what customers can't find. Defaults to the top 20, capped
+// at 100 via limit.
+func (h *AdminReportHandler) ZeroResultQueries(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	since := time.Now().AddDate(0, 0, -searchReportWindowDays)
+	rows, err := h.search.ZeroResultQueries(c.Request.Context(), since, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build zero-result search queries report"})
+		return
+	}
+	c.JSON(http.StatusOK, rows)
+}
+
+func writeInventoryReportCSV(c *gin.Context, rows []psql.InventoryReportRow) {
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=inventory_%s_%s.csv", c.Query("from"), c.Query("to")))
+
+	w := csv.NewWriter(c.Writer)
+	defer w.Flush()
+
+	_ = w.Write([]string{"product_id", "product_name", "category_id", "stock_quantity", "stock_value", "out_of_stock", "units_sold", "turnover"})
+	for _, row := range rows {
+		_ = w.Write([]string{
+			row.ProductID.String(),
+			row.ProductName,
+			strconv.FormatUint(uint64(row.CategoryID), 10),
+			strconv.Itoa(row.StockQuantity),
+			strconv.FormatFloat(row.StockValue, 'f', 2, 64),
+			strconv.FormatBool(row.OutOfStock),
+			strconv.FormatInt(row.UnitsSold, 10),
+			strconv.FormatFloat(row.Turnover, 'f', 4, 64),
+		})
+	}
+}
+
+func writeSalesReportCSV(c *gin.Context, rows []psql.SalesReportRow) {
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=sales_%s_%s.csv", c.Query("from"), c.Query("to")))
+
+	w := csv.NewWriter(c.Writer)
+	defer w.Flush()
+
+	_ = w.Write([]string{"period", "order_count", "revenue", "units_sold"})
+	for _, row := range rows {
+		_ = w.Write([]string{
+			row.Period.Format(dateLayout),
+			strconv.FormatInt(row.OrderCount, 10),
+			strconv.FormatFloat(row.Revenue, 'f', 2, 64),
+			strconv.FormatInt(row.UnitsSold, 10),
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// AdminReviewHandler exposes the review moderation queue for back-office use.
+type AdminReviewHandler struct {
+	reviews       *psql.ReviewRepository
+	replies       *psql.ReviewReplyRepository
+	products      *psql.ProductRepository
+	notifications *service.NotificationService
+	baseURL       string
+}
+
+// NewAdminReviewHandler builds an AdminReviewHandler with its dependencies.
+// baseURL is the storefront's public URL, used to link back to the
+// reviewed product from a "shop replied to your review" email.
+func NewAdminReviewHandler(reviews *psql.ReviewRepository, replies *psql.ReviewReplyRepository, products *psql.ProductRepository, notifications *service.NotificationService, baseURL string) *AdminReviewHandler {
+	return &AdminReviewHandler{reviews: reviews, replies: replies, products: products, notifications: notifications, baseURL: baseURL}
+}
+
+// Pending lists every review awaiting moderation.
+func (h *AdminReviewHandler) Pending(c *gin.Context) {
+	reviews, err := h.reviews.ListPending(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load pending reviews"})
+		return
+	}
+	c.JSON(http.StatusOK, reviews)
+}
+
+// ModerateReviewRequest is the payload accepted by Moderate.
+type ModerateReviewRequest struct {
+	Status          string `json:"status" binding:"required,oneof=approved rejected"`
+	RejectionReason string `json:"rejection_reason"`
+}
+
+// Moderate approves or rejects a pending review.
+func (h *AdminReviewHandler) Moderate(c *gin.Context) {
+	var req ModerateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Status == models.ReviewStatusRejected && req.RejectionReason == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "rejection_reason is required when rejecting a review"})
+		return
+	}
+
+	if err := h.reviews.Moderate(c.Request.Context(), c.Param("id"), req.Status, req.RejectionReason); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to moderate review"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ReplyToReviewRequest is the payload accepted by Reply.
+type ReplyToReviewRequest struct {
+	Content string `json:"content" binding:"required"`
+}
+
+// Reply posts (or overwrites) the shop's official response to a review.
+func (h *AdminReviewHandler) Reply(c *gin.Context) {
+	adminID := c.MustGet(middleware.ContextUserID).(uint)
+
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return
+	}
+
+	var req ReplyToReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.replies.Set(c.Request.Context(), reviewID, adminID, req.Content); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save reply"})
+		return
+	}
+
+	if review, err := h.reviews.GetByID(c.Request.Context(), reviewID.String()); err == nil {
+		if product, err := h.products.GetByID(c.Request.Context(), review.ProductID.String()); err == nil {
+			event := service.NotificationEvent{
+				Kind:          service.EventReviewReply,
+				UserID:        review.UserID,
+				EmailTemplate: "review_reply",
+				EmailData: map[string]interface{}{
+					"ProductName": product.Name,
+					"ProductURL":  h.baseURL + "/products/" + product.ID.String(),
+					"ReplyText":   req.Content,
+				},
+				PushTitle: "The shop replied to your review",
+				PushBody:  req.Content,
+				PushData:  map[string]string{"review_id": reviewID.String(), "product_id": review.ProductID.String()},
+			}
+			if err := h.notifications.Dispatch(c.Request.Context(), event); err != nil {
+				logrus.WithError(err).WithField("review_id", reviewID).Warn("admin_reviews: failed to dispatch review reply notification")
+			}
+		}
+	}
+
+	c.Status(http.StatusNoContent)
+}
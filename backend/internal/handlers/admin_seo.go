@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminSEOHandler exposes indexation controls for products and categories.
+type AdminSEOHandler struct {
+	products   *psql.ProductRepository
+	categories *psql.CategoryRepository
+}
+
+// NewAdminSEOHandler builds an AdminSEOHandler with its dependencies.
+func NewAdminSEOHandler(products *psql.ProductRepository, categories *psql.CategoryRepository) *AdminSEOHandler {
+	return &AdminSEOHandler{products: products, categories: categories}
+}
+
+// SetIndexableRequest is the payload accepted by SetProductIndexable and
+// SetCategoryIndexable.
+type SetIndexableRequest struct {
+	Indexable bool `json:"indexable"`
+}
+
+// SetProductIndexable flags a product for inclusion or exclusion from the
+// sitemap, e.g. for out-of-season or duplicate content.
+func (h *AdminSEOHandler) SetProductIndexable(c *gin.Context) {
+	var req SetIndexableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.products.SetIndexable(c.Request.Context(), c.Param("id"), req.Indexable); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update product"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// SetCategoryIndexable flags a category for inclusion or exclusion from the sitemap.
+func (h *AdminSEOHandler) SetCategoryIndexable(c *gin.Context) {
+	var req SetIndexableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.categories.SetIndexable(c.Request.Context(), c.Param("id"), req.Indexable); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update category"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminSettingsHandler exposes admin management of the shop's runtime settings.
+type AdminSettingsHandler struct {
+	settings *service.ShopSettingsService
+}
+
+// NewAdminSettingsHandler builds an AdminSettingsHandler with its dependencies.
+func NewAdminSettingsHandler(settings *service.ShopSettingsService) *AdminSettingsHandler {
+	return &AdminSettingsHandler{settings: settings}
+}
+
+// SettingsRequest is the payload accepted by Update.
+type SettingsRequest struct {
+	Name            string `json:"name" binding:"required"`
+	Currency        string `json:"currency" binding:"required"`
+	SupportEmail    string `json:"support_email"`
+	MaintenanceMode bool   `json:"maintenance_mode"`
+}
+
+// Update overwrites the shop settings.
+func (h *AdminSettingsHandler) Update(c *gin.Context) {
+	var req SettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings := &models.ShopSettings{
+		Name:            req.Name,
+		Currency:        req.Currency,
+		SupportEmail:    req.SupportEmail,
+		MaintenanceMode: req.MaintenanceMode,
+	}
+	if err := h.settings.Update(c.Request.Context(), settings); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save settings"})
+		return
+	}
+	c.JSON(http.StatusOK, settings)
+}
+
+// MaintenanceRequest is the payload accepted by SetMaintenance.
+type MaintenanceRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// SetMaintenance toggles maintenance mode without touching the rest of the
+// settings, since forcing a full settings payload through Update just to
+// flip one switch during an incident is exactly the friction this
+// endpoint exists to avoid.
+func (h *AdminSettingsHandler) SetMaintenance(c *gin.Context) {
+	var req MaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := h.settings.Get(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load settings"})
+		return
+	}
+	settings.MaintenanceMode = req.Enabled
+	if err := h.settings.Update(c.Request.Context(), settings); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save settings"})
+		return
+	}
+	c.JSON(http.StatusOK, settings)
+}
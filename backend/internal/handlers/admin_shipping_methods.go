@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminShippingMethodHandler exposes shipping-method CRUD for back-office use.
+type AdminShippingMethodHandler struct {
+	methods *psql.ShippingMethodRepository
+}
+
+// NewAdminShippingMethodHandler builds an AdminShippingMethodHandler with its dependencies.
+func NewAdminShippingMethodHandler(methods *psql.ShippingMethodRepository) *AdminShippingMethodHandler {
+	return &AdminShippingMethodHandler{methods: methods}
+}
+
+// ShippingMethodRequest is the payload accepted by Create and Update.
+type ShippingMethodRequest struct {
+	Name           string                    `json:"name" binding:"required"`
+	Carrier        string                    `json:"carrier" binding:"required"`
+	BasePrice      float64                   `json:"base_price"`
+	PriceRules     models.ShippingPriceRules `json:"price_rules"`
+	AllowedRegions models.StringList         `json:"allowed_regions"`
+	Zones          models.ShippingZoneRules  `json:"zones"`
+	Enabled        bool                      `json:"enabled"`
+}
+
+// List returns every configured shipping method.
+func (h *AdminShippingMethodHandler) List(c *gin.Context) {
+	methods, err := h.methods.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load shipping methods"})
+		return
+	}
+	c.JSON(http.StatusOK, methods)
+}
+
+// Create adds a new shipping method.
+func (h *AdminShippingMethodHandler) Create(c *gin.Context) {
+	var req ShippingMethodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	method := &models.ShippingMethod{
+		Name:           req.Name,
+		Carrier:        req.Carrier,
+		BasePrice:      req.BasePrice,
+		PriceRules:     req.PriceRules,
+		AllowedRegions: req.AllowedRegions,
+		Zones:          req.Zones,
+		Enabled:        req.Enabled,
+	}
+	if err := h.methods.Create(c.Request.Context(), method); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create shipping method"})
+		return
+	}
+	c.JSON(http.StatusCreated, method)
+}
+
+// Update replaces an existing shipping method's fields.
+func (h *AdminShippingMethodHandler) Update(c *gin.Context) {
+	method, err := h.methods.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "shipping method not found"})
+		return
+	}
+
+	var req ShippingMethodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	method.Name = req.Name
+	method.Carrier = req.Carrier
+	method.BasePrice = req.BasePrice
+	method.PriceRules = req.PriceRules
+	method.AllowedRegions = req.AllowedRegions
+	method.Zones = req.Zones
+	method.Enabled = req.Enabled
+
+	if err := h.methods.Update(c.Request.Context(), method); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update shipping method"})
+		return
+	}
+	c.JSON(http.StatusOK, method)
+}
+
+// Delete removes a shipping method by ID.
+func (h *AdminShippingMethodHandler) Delete(c *gin.Context) {
+	if _, err := h.methods.GetByID(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "shipping method not found"})
+		return
+	}
+	if err := h.methods.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete shipping method"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
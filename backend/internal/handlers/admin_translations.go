@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/YoungGoofy/shopping/internal/i18n"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AdminTranslationHandler manages per-locale product and category content.
+type AdminTranslationHandler struct {
+	products   *psql.ProductTranslationRepository
+	categories *psql.CategoryTranslationRepository
+}
+
+// NewAdminTranslationHandler builds an AdminTranslationHandler with its
+// dependencies.
+func NewAdminTranslationHandler(products *psql.ProductTranslationRepository, categories *psql.CategoryTranslationRepository) *AdminTranslationHandler {
+	return &AdminTranslationHandler{products: products, categories: categories}
+}
+
+// SetProductTranslationRequest is the payload accepted by SetProduct.
+type SetProductTranslationRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// SetProduct writes a product's Name/Description for the locale named in
+// the :locale path param, replacing whatever was there before.
+func (h *AdminTranslationHandler) SetProduct(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+	locale := c.Param("locale")
+	if !i18n.IsSupported(locale) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unsupported locale"})
+		return
+	}
+
+	var req SetProductTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.products.Upsert(c.Request.Context(), productID, locale, req.Name, req.Description); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save translation"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// SetCategoryTranslationRequest is the payload accepted by SetCategory.
+type SetCategoryTranslationRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// SetCategory writes a category's Name for the locale named in the
+// :locale path param, replacing whatever was there before.
+func (h *AdminTranslationHandler) SetCategory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		return
+	}
+	categoryID := uint(id)
+	locale := c.Param("locale")
+	if !i18n.IsSupported(locale) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unsupported locale"})
+		return
+	}
+
+	var req SetCategoryTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.categories.Upsert(c.Request.Context(), categoryID, locale, req.Name); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save translation"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
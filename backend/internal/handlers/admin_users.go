@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/pagination"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/repository/redis"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultUserPageSize = 50
+	maxUserPageSize     = 200
+)
+
+// AdminUserHandler exposes user account management for back-office use.
+type AdminUserHandler struct {
+	tokens    *redis.TokenRepository
+	userCache *service.UserCacheService
+	users     *psql.UserRepository
+	orders    *psql.OrderRepository
+}
+
+// NewAdminUserHandler builds an AdminUserHandler with its dependencies.
+func NewAdminUserHandler(tokens *redis.TokenRepository, userCache *service.UserCacheService, users *psql.UserRepository, orders *psql.OrderRepository) *AdminUserHandler {
+	return &AdminUserHandler{tokens: tokens, userCache: userCache, users: users, orders: orders}
+}
+
+// List returns a page of customers, newest first. An optional "search"
+// query param matches case-insensitively against email and name.
+func (h *AdminUserHandler) List(c *gin.Context) {
+	page, pageSize, offset := pagination.ParseParams(c, defaultUserPageSize, maxUserPageSize)
+
+	users, total, err := h.users.List(c.Request.Context(), c.Query("search"), pageSize, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load users"})
+		return
+	}
+
+	c.JSON(http.StatusOK, pagination.New[models.User](users, total, page, pageSize))
+}
+
+// Get returns a customer along with their order history.
+func (h *AdminUserHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+	userID := uint(id)
+
+	user, err := h.userCache.GetByID(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
+
+	orders, err := h.orders.ListByUser(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load order history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": user, "orders": orders})
+}
+
+// Block prevents a user from logging in and revokes their current session.
+func (h *AdminUserHandler) Block(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+	userID := uint(id)
+
+	if err := h.userCache.SetBlocked(c.Request.Context(), userID, true); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to block user"})
+		return
+	}
+	if err := h.tokens.Revoke(context.Background(), userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "user blocked but failed to revoke existing session"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Unblock restores a user's ability to log in. There is no session to
+// revoke here - blocking already did that, and the account has been unable
+// to create a new one since.
+func (h *AdminUserHandler) Unblock(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	if err := h.userCache.SetBlocked(c.Request.Context(), uint(id), false); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to unblock user"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateRoleRequest is the payload accepted by UpdateRole.
+type UpdateRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=user admin"`
+}
+
+// UpdateRole changes a user's role and revokes their current session, since
+// a cached JWT still carries the old role/claims until it is reissued.
+func (h *AdminUserHandler) UpdateRole(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	var req UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := uint(id)
+	if err := h.userCache.UpdateRole(c.Request.Context(), userID, req.Role); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update role"})
+		return
+	}
+
+	if err := h.tokens.Revoke(context.Background(), userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "role updated but failed to revoke existing session"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Anonymize scrubs a user's PII and revokes their session, for
+// admin-initiated deletion requests (e.g. filed via support rather than
+// self-service). Orders and reviews stay attached to the row.
+func (h *AdminUserHandler) Anonymize(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+	userID := uint(id)
+
+	if err := h.userCache.Anonymize(c.Request.Context(), userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete account"})
+		return
+	}
+	if err := h.tokens.Revoke(context.Background(), userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "account deleted but failed to revoke existing session"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/apperr"
+	"github.com/YoungGoofy/shopping/internal/events"
+	"github.com/YoungGoofy/shopping/internal/lock"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// stockLockTTL bounds how long a stock lock can be held, so a crashed
+// request doesn't strand the lock forever.
+const stockLockTTL = 5 * time.Second
+
+// AdminWarehouseStockHandler exposes per-warehouse stock management and
+// inter-warehouse transfers for back-office use.
+type AdminWarehouseStockHandler struct {
+	stock    *psql.WarehouseStockRepository
+	products *psql.ProductRepository
+	locks    *lock.Manager
+	push     *service.PushNotificationService
+	events   events.Bus
+}
+
+// NewAdminWarehouseStockHandler builds an AdminWarehouseStockHandler with its dependencies.
+func NewAdminWarehouseStockHandler(stock *psql.WarehouseStockRepository, products *psql.ProductRepository, locks *lock.Manager, push *service.PushNotificationService, eventBus events.Bus) *AdminWarehouseStockHandler {
+	return &AdminWarehouseStockHandler{stock: stock, products: products, locks: locks, push: push, events: eventBus}
+}
+
+// stockLockKey scopes a stock lock to a single product, so transfers and
+// allocations for different products never contend with each other.
+func stockLockKey(productID uuid.UUID) string {
+	return fmt.Sprintf("lock:stock:%s", productID)
+}
+
+// SetStockRequest is the payload accepted by SetStock.
+type SetStockRequest struct {
+	Quantity int `json:"quantity" binding:"min=0"`
+}
+
+// SetStock overwrites the stock level for a product at a warehouse.
+func (h *AdminWarehouseStockHandler) SetStock(c *gin.Context) {
+	warehouseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid warehouse id"})
+		return
+	}
+	productID, err := uuid.Parse(c.Param("productID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	var req SetStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	previousTotal, err := h.stock.TotalForProduct(c.Request.Context(), productID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to set stock"})
+		return
+	}
+
+	if err := h.stock.SetQuantity(c.Request.Context(), warehouseID, productID, req.Quantity); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to set stock"})
+		return
+	}
+
+	if previousTotal == 0 {
+		h.notifyIfBackInStock(c.Request.Context(), productID)
+	}
+	h.publishStockChanged(c.Request.Context(), productID, previousTotal)
+
+	c.Status(http.StatusNoContent)
+}
+
+// notifyIfBackInStock pushes a back-in-stock alert if productID's total
+// stock across all warehouses is now positive. Failures are logged, not
+// surfaced - a missed push shouldn't fail the stock update that caused it.
+func (h *AdminWarehouseStockHandler) notifyIfBackInStock(ctx context.Context, productID uuid.UUID) {
+	newTotal, err := h.stock.TotalForProduct(ctx, productID)
+	if err != nil || newTotal <= 0 {
+		return
+	}
+	product, err := h.products.GetByID(ctx, productID.String())
+	if err != nil {
+		logrus.WithError(err).WithField("product_id", productID).Warn("admin_warehouse_stock: failed to load product for back-in-stock push")
+		return
+	}
+	if err := h.push.NotifyBackInStock(ctx, productID, product.Name); err != nil {
+		logrus.WithError(err).WithField("product_id", productID).Warn("admin_warehouse_stock: failed to push back-in-stock notification")
+	}
+}
+
+// publishStockChanged notifies subscribers of productID's new total stock,
+// e.g. search indexing keeping its out-of-stock facet current. A failure
+// computing the new total is logged, not surfaced - the stock update
+// itself already succeeded by the time this runs.
+func (h *AdminWarehouseStockHandler) publishStockChanged(ctx context.Context, productID uuid.UUID, previousTotal int) {
+	newTotal, err := h.stock.TotalForProduct(ctx, productID)
+	if err != nil {
+		logrus.WithError(err).WithField("product_id", productID).Warn("admin_warehouse_stock: failed to load new total for stock changed event")
+		return
+	}
+	h.events.Publish(ctx, events.Event{
+		Name:    events.ProductStockChanged,
+		Payload: events.ProductStockChangedPayload{ProductID: productID, PreviousTotal: previousTotal, NewTotal: newTotal},
+	})
+}
+
+// ListForProduct returns the per-warehouse stock breakdown for a product.
+func (h *AdminWarehouseStockHandler) ListForProduct(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("productID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	stock, err := h.stock.ListForProduct(c.Request.Context(), productID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load stock"})
+		return
+	}
+	c.JSON(http.StatusOK, stock)
+}
+
+// TransferRequest is the payload accepted by Transfer.
+type TransferRequest struct {
+	FromWarehouseID uuid.UUID `json:"from_warehouse_id" binding:"required"`
+	ToWarehouseID   uuid.UUID `json:"to_warehouse_id" binding:"required"`
+	ProductID       uuid.UUID `json:"product_id" binding:"required"`
+	Quantity        int       `json:"quantity" binding:"required,min=1"`
+}
+
+// Transfer moves stock of a product from one warehouse to another.
+func (h *AdminWarehouseStockHandler) Transfer(c *gin.Context) {
+	var req TransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	held, err := h.locks.Acquire(c.Request.Context(), stockLockKey(req.ProductID), stockLockTTL)
+	if err != nil {
+		if err == lock.ErrNotHeld {
+			c.JSON(http.StatusConflict, gin.H{"error": "stock for this product is being modified elsewhere, try again"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to acquire stock lock"})
+		return
+	}
+	defer held.Release(c.Request.Context())
+
+	if err := h.stock.Transfer(c.Request.Context(), req.FromWarehouseID, req.ToWarehouseID, req.ProductID, req.Quantity); err != nil {
+		apperr.Respond(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminWarehouseHandler exposes warehouse CRUD for back-office use.
+type AdminWarehouseHandler struct {
+	warehouses *psql.WarehouseRepository
+}
+
+// NewAdminWarehouseHandler builds an AdminWarehouseHandler with its dependencies.
+func NewAdminWarehouseHandler(warehouses *psql.WarehouseRepository) *AdminWarehouseHandler {
+	return &AdminWarehouseHandler{warehouses: warehouses}
+}
+
+// WarehouseRequest is the payload accepted by Create and Update.
+type WarehouseRequest struct {
+	Name   string `json:"name" binding:"required"`
+	Region string `json:"region" binding:"required"`
+	Active bool   `json:"active"`
+}
+
+// List returns every warehouse.
+func (h *AdminWarehouseHandler) List(c *gin.Context) {
+	warehouses, err := h.warehouses.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load warehouses"})
+		return
+	}
+	c.JSON(http.StatusOK, warehouses)
+}
+
+// Create adds a new warehouse.
+func (h *AdminWarehouseHandler) Create(c *gin.Context) {
+	var req WarehouseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	warehouse := &models.Warehouse{
+		Name:   req.Name,
+		Region: req.Region,
+		Active: req.Active,
+	}
+	if err := h.warehouses.Create(c.Request.Context(), warehouse); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create warehouse"})
+		return
+	}
+	c.JSON(http.StatusCreated, warehouse)
+}
+
+// Update replaces an existing warehouse's fields.
+func (h *AdminWarehouseHandler) Update(c *gin.Context) {
+	warehouse, err := h.warehouses.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "warehouse not found"})
+		return
+	}
+
+	var req WarehouseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	warehouse.Name = req.Name
+	warehouse.Region = req.Region
+	warehouse.Active = req.Active
+
+	if err := h.warehouses.Update(c.Request.Context(), warehouse); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update warehouse"})
+		return
+	}
+	c.JSON(http.StatusOK, warehouse)
+}
+
+// Delete removes a warehouse by ID, along with its stock records.
+func (h *AdminWarehouseHandler) Delete(c *gin.Context) {
+	if _, err := h.warehouses.GetByID(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "warehouse not found"})
+		return
+	}
+	if err := h.warehouses.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete warehouse"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
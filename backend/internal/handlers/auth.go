@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/apperr"
+	"github.com/YoungGoofy/shopping/internal/events"
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/repository/redis"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/YoungGoofy/shopping/internal/utils"
+	"github.com/YoungGoofy/shopping/internal/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// AuthHandler handles registration and login.
+type AuthHandler struct {
+	users     *psql.UserRepository
+	tokens    *redis.TokenRepository
+	cart      *psql.CartRepository
+	guestCart *redis.GuestCartRepository
+	products  *psql.ProductRepository
+	events    events.Bus
+	jwtSecret string
+	jwtExpiry time.Duration
+}
+
+// NewAuthHandler builds an AuthHandler with its dependencies.
+func NewAuthHandler(users *psql.UserRepository, tokens *redis.TokenRepository, cart *psql.CartRepository, guestCart *redis.GuestCartRepository, products *psql.ProductRepository, eventBus events.Bus, jwtSecret string, jwtExpiry time.Duration) *AuthHandler {
+	return &AuthHandler{users: users, tokens: tokens, cart: cart, guestCart: guestCart, products: products, events: eventBus, jwtSecret: jwtSecret, jwtExpiry: jwtExpiry}
+}
+
+// RegisterRequest is the payload accepted by Register. Phone has no
+// declarative "e164" tag despite the field existing in a stricter format
+// downstream - it's accepted in whatever punctuation the client sends
+// (e.g. "+1 415-555-2671") and normalized before validation, which a bind
+// tag alone can't do.
+type RegisterRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
+	Phone    string `json:"phone"`
+}
+
+// LoginRequest is the payload accepted by Login.
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// Register creates a new user account.
+func (h *AuthHandler) Register(c *gin.Context) {
+	var req RegisterRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	phone := utils.NormalizePhone(req.Phone)
+	if phone != "" && !utils.IsValidE164(phone) {
+		apperr.RespondCode(c, http.StatusBadRequest, apperr.CodeValidation, "validation failed",
+			[]validation.FieldError{{Field: "phone", Rule: "must be a valid E.164 number, e.g. +14155552671"}})
+		return
+	}
+
+	hash, err := utils.HashPassword(req.Password)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to hash password"})
+		return
+	}
+
+	user := &models.User{Email: req.Email, PasswordHash: hash, Phone: phone}
+	if err := h.users.Create(c.Request.Context(), user); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "email already registered"})
+		return
+	}
+
+	h.events.Publish(c.Request.Context(), events.Event{
+		Name:    events.UserRegistered,
+		Payload: events.UserRegisteredPayload{UserID: user.ID, Email: user.Email, FirstName: user.FirstName},
+	})
+
+	c.JSON(http.StatusCreated, gin.H{"id": user.ID, "email": user.Email})
+}
+
+// Login verifies credentials and issues a JWT.
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req LoginRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.users.GetByEmail(c.Request.Context(), req.Email)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
+		return
+	}
+
+	if !utils.CheckPassword(user.PasswordHash, req.Password) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
+		return
+	}
+
+	if user.Blocked {
+		apperr.RespondCode(c, http.StatusForbidden, apperr.CodeForbidden, "account is blocked", nil)
+		return
+	}
+
+	token, err := utils.GenerateToken(h.jwtSecret, user.ID, user.Role, h.jwtExpiry)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to issue token"})
+		return
+	}
+
+	if err := h.tokens.Save(context.Background(), user.ID, token, h.jwtExpiry); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to persist session"})
+		return
+	}
+
+	if cookie, err := c.Cookie(middleware.GuestCookieName); err == nil && cookie != "" {
+		_ = service.MergeGuestCart(c.Request.Context(), h.cart, h.guestCart, h.products, user.ID, cookie)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token})
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AvailabilityHandler exposes aggregate, warehouse-agnostic stock
+// availability for the catalog.
+type AvailabilityHandler struct {
+	stock *psql.WarehouseStockRepository
+}
+
+// NewAvailabilityHandler builds an AvailabilityHandler with its dependencies.
+func NewAvailabilityHandler(stock *psql.WarehouseStockRepository) *AvailabilityHandler {
+	return &AvailabilityHandler{stock: stock}
+}
+
+// Get reports whether a product is in stock and the total quantity summed
+// across every warehouse, without exposing the per-warehouse breakdown.
+func (h *AvailabilityHandler) Get(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	total, err := h.stock.TotalForProduct(c.Request.Context(), productID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check availability"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"in_stock": total > 0,
+		"quantity": total,
+	})
+}
@@ -0,0 +1,447 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/repository/redis"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CartHandler exposes the shopping cart API. Requests without a session are
+// served from the guest cart keyed by cookie rather than the database.
+type CartHandler struct {
+	cart            *psql.CartRepository
+	guest           *redis.GuestCartRepository
+	products        *psql.ProductRepository
+	coupons         *psql.CouponRepository
+	applied         *psql.CartCouponRepository
+	saved           *psql.SavedItemRepository
+	shares          *psql.CartShareRepository
+	promo           *service.CouponService
+	totals          *service.TotalsService
+	stock           *service.StockValidationService
+	shippingMethods *psql.ShippingMethodRepository
+}
+
+// NewCartHandler builds a CartHandler with its dependencies.
+func NewCartHandler(cart *psql.CartRepository, guest *redis.GuestCartRepository, products *psql.ProductRepository, coupons *psql.CouponRepository, applied *psql.CartCouponRepository, saved *psql.SavedItemRepository, shares *psql.CartShareRepository, promo *service.CouponService, totals *service.TotalsService, stock *service.StockValidationService, shippingMethods *psql.ShippingMethodRepository) *CartHandler {
+	return &CartHandler{cart: cart, guest: guest, products: products, coupons: coupons, applied: applied, saved: saved, shares: shares, promo: promo, totals: totals, stock: stock, shippingMethods: shippingMethods}
+}
+
+// userID returns the authenticated user's ID and true, or false if the
+// request is unauthenticated and should fall back to the guest cart.
+func userID(c *gin.Context) (uint, bool) {
+	v, ok := c.Get(middleware.ContextUserID)
+	if !ok {
+		return 0, false
+	}
+	return v.(uint), true
+}
+
+// AddToCartRequest is the payload accepted by AddItem.
+type AddToCartRequest struct {
+	ProductID string `json:"product_id" binding:"required,uuid"`
+	Size      string `json:"size" binding:"required"`
+	Quantity  int    `json:"quantity" binding:"required,min=1"`
+}
+
+// UpdateCartItemRequest is the payload accepted by UpdateItem.
+type UpdateCartItemRequest struct {
+	Quantity int `json:"quantity" binding:"required,min=1"`
+}
+
+// List returns the caller's cart items, from the database if authenticated
+// or from the guest cart identified by cookie otherwise.
+func (h *CartHandler) List(c *gin.Context) {
+	if uid, ok := userID(c); ok {
+		items, err := h.cart.ListByUser(c.Request.Context(), uid)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load cart"})
+			return
+		}
+		saved, err := h.saved.ListByUser(c.Request.Context(), uid)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load cart"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"items": items, "saved_for_later": saved})
+		return
+	}
+
+	lines, err := h.guest.List(c.Request.Context(), c.MustGet(middleware.ContextGuestCookie).(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load cart"})
+		return
+	}
+	c.JSON(http.StatusOK, lines)
+}
+
+// AddItem adds a product/size to the caller's cart, merging with any
+// existing line for the same product/size.
+func (h *CartHandler) AddItem(c *gin.Context) {
+	var req AddToCartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	productID, err := uuid.Parse(req.ProductID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product_id"})
+		return
+	}
+
+	product, err := h.products.GetByID(c.Request.Context(), req.ProductID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "product not found"})
+		return
+	}
+	if issue := h.stock.CheckQuantity(*product, req.Quantity); issue != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "insufficient stock", "issue": issue})
+		return
+	}
+
+	if uid, ok := userID(c); ok {
+		if err := h.cart.AddOrIncrement(c.Request.Context(), uid, productID, req.Size, req.Quantity, product.DiscountedPrice()); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add to cart"})
+			return
+		}
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	cookie := c.MustGet(middleware.ContextGuestCookie).(string)
+	if err := h.guest.AddOrIncrement(c.Request.Context(), cookie, req.ProductID, req.Size, req.Quantity); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add to cart"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateItem changes the quantity of a single cart line in the
+// authenticated user's cart.
+func (h *CartHandler) UpdateItem(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	var req UpdateCartItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.cart.UpdateQuantity(c.Request.Context(), uid, c.Param("id"), req.Quantity); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update cart item"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveItem deletes a single cart line from the authenticated user's cart.
+func (h *CartHandler) RemoveItem(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	if err := h.cart.Delete(c.Request.Context(), uid, c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove cart item"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// SaveForLater moves a cart line into the authenticated user's saved-for-later
+// list.
+func (h *CartHandler) SaveForLater(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	item, err := h.cart.GetByID(c.Request.Context(), uid, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "cart item not found"})
+		return
+	}
+
+	saved := &models.SavedItem{
+		UserID:    uid,
+		ProductID: item.ProductID,
+		Size:      item.Size,
+		Quantity:  item.Quantity,
+	}
+	if err := h.saved.Create(c.Request.Context(), saved); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save item for later"})
+		return
+	}
+	if err := h.cart.Delete(c.Request.Context(), uid, c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove cart item"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, saved)
+}
+
+// MoveToCart moves a saved-for-later item back into the authenticated user's cart.
+func (h *CartHandler) MoveToCart(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	item, err := h.saved.GetByID(c.Request.Context(), uid, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "saved item not found"})
+		return
+	}
+
+	if err := h.cart.AddOrIncrement(c.Request.Context(), uid, item.ProductID, item.Size, item.Quantity, item.Product.DiscountedPrice()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add to cart"})
+		return
+	}
+	if err := h.saved.Delete(c.Request.Context(), uid, c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove saved item"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ValidateStock re-checks every line of the authenticated user's cart
+// against current stock, e.g. immediately before checkout, and returns a
+// per-line issue for anything that can no longer be fully satisfied.
+func (h *CartHandler) ValidateStock(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	items, err := h.cart.ListByUser(c.Request.Context(), uid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load cart"})
+		return
+	}
+
+	issues := h.stock.CheckCart(items)
+	c.JSON(http.StatusOK, gin.H{"valid": len(issues) == 0, "issues": issues})
+}
+
+// Totals returns the authoritative price breakdown (subtotal, item and
+// coupon discounts, tax, shipping) plus every shipping method available for
+// the optional ?region= query param, priced for this cart's weight and
+// total - the same code path checkout will use to derive the order total.
+func (h *CartHandler) Totals(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	items, err := h.cart.ListByUser(c.Request.Context(), uid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load cart"})
+		return
+	}
+
+	couponDiscount, err := h.appliedCouponDiscount(c, uid, items)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load applied coupon"})
+		return
+	}
+
+	totals := h.totals.Calculate(items, couponDiscount)
+
+	methods, err := h.shippingMethods.ListEnabled(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load shipping methods"})
+		return
+	}
+	country := c.Query("country")
+	region := c.Query("region")
+	city := c.Query("city")
+	weightGrams := 0
+	for _, item := range items {
+		weightGrams += item.Product.WeightGrams * item.Quantity
+	}
+	netTotal := totals.Subtotal - totals.ItemDiscounts - totals.CouponDiscount
+	for _, method := range methods {
+		if !method.AvailableIn(country, region, city) {
+			continue
+		}
+		totals.ShippingMethods = append(totals.ShippingMethods, service.ShippingMethodQuote{
+			ID:      method.ID,
+			Name:    method.Name,
+			Carrier: method.Carrier,
+			Price:   method.PriceFor(weightGrams, netTotal) + method.ZoneSurcharge(country, region, city),
+		})
+	}
+
+	c.JSON(http.StatusOK, totals)
+}
+
+// appliedCouponDiscount returns the discount granted by the cart's currently
+// applied coupon, or 0 if none is applied.
+func (h *CartHandler) appliedCouponDiscount(c *gin.Context, uid uint, items []models.CartItem) (float64, error) {
+	cc, err := h.applied.Get(c.Request.Context(), uid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	subtotal := 0.0
+	categoryIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		subtotal += item.Product.DiscountedPrice() * float64(item.Quantity)
+		categoryIDs = append(categoryIDs, item.Product.CategoryID)
+	}
+
+	discount, err := h.promo.Apply(cc.Coupon, subtotal, categoryIDs)
+	if err != nil {
+		// The coupon that was valid when applied may no longer qualify
+		// (expired, used up, cart changed) - treat it as not discounting
+		// rather than failing the whole totals request.
+		return 0, nil
+	}
+	return discount, nil
+}
+
+// ApplyCouponRequest is the payload accepted by ApplyCoupon.
+type ApplyCouponRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// ApplyCoupon validates a promo code against the authenticated user's cart
+// and, if it qualifies, records it as the cart's applied coupon. Guest carts
+// do not support coupons.
+func (h *CartHandler) ApplyCoupon(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	var req ApplyCouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	coupon, err := h.coupons.GetByCode(c.Request.Context(), req.Code)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "coupon not found"})
+		return
+	}
+
+	items, err := h.cart.ListByUser(c.Request.Context(), uid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load cart"})
+		return
+	}
+
+	subtotal := 0.0
+	categoryIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		subtotal += item.Product.DiscountedPrice() * float64(item.Quantity)
+		categoryIDs = append(categoryIDs, item.Product.CategoryID)
+	}
+
+	discount, err := h.promo.Apply(*coupon, subtotal, categoryIDs)
+	if err != nil {
+		if errors.Is(err, service.ErrCouponNotApplicable) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "coupon is not applicable to this cart"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to apply coupon"})
+		return
+	}
+
+	if err := h.applied.Set(c.Request.Context(), uid, coupon.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to apply coupon"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"subtotal": subtotal, "discount": discount, "total": subtotal - discount})
+}
+
+// RemoveCoupon clears any coupon applied to the authenticated user's cart.
+func (h *CartHandler) RemoveCoupon(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	if err := h.applied.Clear(c.Request.Context(), uid); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove coupon"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Clear empties the authenticated user's cart.
+func (h *CartHandler) Clear(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	if err := h.cart.Clear(c.Request.Context(), uid); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to clear cart"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Share snapshots the authenticated user's current cart lines under a fresh
+// token, so it can be reopened elsewhere via GetShared/Import. The snapshot
+// is frozen at creation time - later changes to the cart do not follow it.
+func (h *CartHandler) Share(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	items, err := h.cart.ListByUser(c.Request.Context(), uid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load cart"})
+		return
+	}
+
+	lines := make(models.CartShareLines, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, models.CartShareLine{
+			ProductID: item.ProductID.String(),
+			Size:      item.Size,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	share := &models.CartShare{Token: uuid.New().String(), UserID: uid, Items: lines}
+	if err := h.shares.Create(c.Request.Context(), share); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to share cart"})
+		return
+	}
+	c.JSON(http.StatusCreated, share)
+}
+
+// GetShared returns a shared cart snapshot by token, with no authentication
+// required, so a link can be previewed before importing it.
+func (h *CartHandler) GetShared(c *gin.Context) {
+	share, err := h.shares.GetByToken(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "shared cart not found"})
+		return
+	}
+	c.JSON(http.StatusOK, share)
+}
+
+// ImportShared reconstructs a shared cart snapshot into the authenticated
+// user's own cart, merging quantities with anything already there. Lines
+// whose product no longer exists are skipped rather than failing the whole
+// import.
+func (h *CartHandler) ImportShared(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	share, err := h.shares.GetByToken(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "shared cart not found"})
+		return
+	}
+
+	imported := 0
+	for _, line := range share.Items {
+		productID, err := uuid.Parse(line.ProductID)
+		if err != nil {
+			continue
+		}
+		product, err := h.products.GetByID(c.Request.Context(), line.ProductID)
+		if err != nil {
+			continue
+		}
+		if err := h.cart.AddOrIncrement(c.Request.Context(), uid, productID, line.Size, line.Quantity, product.DiscountedPrice()); err != nil {
+			continue
+		}
+		imported++
+	}
+
+	c.JSON(http.StatusOK, gin.H{"imported_count": imported})
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/apperr"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/sparsefields"
+	"github.com/YoungGoofy/shopping/internal/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// maxBatchIDs caps a single batch request, so a client can't turn it into
+// an unbounded "fetch everything" query.
+const maxBatchIDs = 100
+
+// CatalogBatchHandler serves batch lookups by ID, so a frontend rendering a
+// cart or wishlist can fetch every item's current product or category data
+// in one request instead of one per item.
+type CatalogBatchHandler struct {
+	products   *psql.ProductRepository
+	categories *psql.CategoryRepository
+}
+
+// NewCatalogBatchHandler builds a CatalogBatchHandler with its
+// dependencies.
+func NewCatalogBatchHandler(products *psql.ProductRepository, categories *psql.CategoryRepository) *CatalogBatchHandler {
+	return &CatalogBatchHandler{products: products, categories: categories}
+}
+
+// ProductBatchRequest is the payload accepted by Products.
+type ProductBatchRequest struct {
+	IDs []string `json:"ids" binding:"required,min=1"`
+}
+
+// Products returns the products matching the given IDs, up to maxBatchIDs
+// per request. IDs with no matching product are silently omitted. A
+// "fields" query parameter trims each product down to the named fields.
+func (h *CatalogBatchHandler) Products(c *gin.Context) {
+	var req ProductBatchRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	if len(req.IDs) > maxBatchIDs {
+		apperr.RespondCode(c, http.StatusBadRequest, apperr.CodeValidation, "too many ids",
+			[]validation.FieldError{{Field: "ids", Rule: "must contain at most 100 items"}})
+		return
+	}
+
+	products, err := h.products.GetByIDs(c.Request.Context(), req.IDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load products"})
+		return
+	}
+	sparsefields.Respond(c, http.StatusOK, products)
+}
+
+// CategoryBatchRequest is the payload accepted by Categories.
+type CategoryBatchRequest struct {
+	IDs []uint `json:"ids" binding:"required,min=1"`
+}
+
+// Categories returns the categories matching the given IDs, up to
+// maxBatchIDs per request. IDs with no matching category are silently
+// omitted.
+func (h *CatalogBatchHandler) Categories(c *gin.Context) {
+	var req CategoryBatchRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	if len(req.IDs) > maxBatchIDs {
+		apperr.RespondCode(c, http.StatusBadRequest, apperr.CodeValidation, "too many ids",
+			[]validation.FieldError{{Field: "ids", Rule: "must contain at most 100 items"}})
+		return
+	}
+
+	categories, err := h.categories.GetByIDs(c.Request.Context(), req.IDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load categories"})
+		return
+	}
+	c.JSON(http.StatusOK, categories)
+}
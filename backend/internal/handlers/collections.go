@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/etag"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/pagination"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+// CollectionProductView augments a product with the price it carries within
+// this collection, since Product.DiscountedPrice only knows about the
+// product's own discount and has no notion of the collection it's being
+// viewed through.
+type CollectionProductView struct {
+	models.Product
+	EffectivePrice float64 `json:"effective_price"`
+}
+
+const (
+	defaultCollectionPageSize = 20
+	maxCollectionPageSize     = 100
+)
+
+// CollectionHandler exposes browsable, unauthenticated collection listings
+// for server-rendered storefront landing pages.
+type CollectionHandler struct {
+	collections *psql.CollectionRepository
+}
+
+// NewCollectionHandler builds a CollectionHandler with its dependencies.
+func NewCollectionHandler(collections *psql.CollectionRepository) *CollectionHandler {
+	return &CollectionHandler{collections: collections}
+}
+
+// List returns every collection currently available to customers. The
+// response carries an ETag so a polling client can send If-None-Match and
+// get a 304 back instead of the same body over and over.
+func (h *CollectionHandler) List(c *gin.Context) {
+	all, err := h.collections.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load collections"})
+		return
+	}
+
+	available := make([]interface{}, 0, len(all))
+	for _, collection := range all {
+		if collection.IsAvailable() {
+			available = append(available, collection)
+		}
+	}
+	etag.Respond(c, http.StatusOK, available)
+}
+
+// Get returns a single collection's SEO metadata and a page of its
+// products, identified by slug, as long as the collection is currently
+// available. Query params: page (1-based, default 1), page_size (default
+// 20, capped at 100). The response carries an ETag, so a client polling the
+// same page gets a 304 once nothing about it has changed.
+func (h *CollectionHandler) Get(c *gin.Context) {
+	collection, err := h.collections.GetBySlug(c.Request.Context(), c.Param("slug"))
+	if err != nil || !collection.IsAvailable() {
+		c.JSON(http.StatusNotFound, gin.H{"error": "collection not found"})
+		return
+	}
+
+	page, pageSize, offset := pagination.ParseParams(c, defaultCollectionPageSize, maxCollectionPageSize)
+
+	products, total, err := h.collections.ListProducts(c.Request.Context(), collection.ID.String(), pageSize, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load collection products"})
+		return
+	}
+
+	views := make([]CollectionProductView, 0, len(products))
+	for _, product := range products {
+		views = append(views, CollectionProductView{
+			Product:        product,
+			EffectivePrice: product.DiscountedPriceForCollection(*collection),
+		})
+	}
+
+	etag.Respond(c, http.StatusOK, gin.H{
+		"collection": collection,
+		"products":   pagination.New(views, total, page, pageSize),
+	})
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/repository/redis"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// DeliveryHandler exposes postal-code based delivery availability checks.
+type DeliveryHandler struct {
+	zones *psql.ShippingZoneRepository
+	cache *redis.DeliveryEstimateCache
+}
+
+// NewDeliveryHandler builds a DeliveryHandler with its dependencies.
+func NewDeliveryHandler(zones *psql.ShippingZoneRepository, cache *redis.DeliveryEstimateCache) *DeliveryHandler {
+	return &DeliveryHandler{zones: zones, cache: cache}
+}
+
+// Estimate reports whether delivery is available for a postal code and, if
+// so, the expected lead time and cost range. Product ID is accepted in the
+// path for a future per-product carrier override, but today the estimate is
+// zone-wide.
+func (h *DeliveryHandler) Estimate(c *gin.Context) {
+	postalCode := c.Query("postal_code")
+	if postalCode == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'postal_code' query parameter"})
+		return
+	}
+
+	zone, err := h.zones.MatchPostalCode(c.Request.Context(), postalCode)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusOK, gin.H{"available": false})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check delivery availability"})
+		return
+	}
+
+	if cached, err := h.cache.Get(c.Request.Context(), zone.PostalPrefix, zone.CarrierName); err == nil && cached != nil {
+		c.JSON(http.StatusOK, cached)
+		return
+	}
+
+	estimate := redis.DeliveryEstimate{
+		Available:    true,
+		CarrierName:  zone.CarrierName,
+		LeadTimeDays: zone.LeadTimeDays,
+		CostMin:      zone.CostMin,
+		CostMax:      zone.CostMax,
+	}
+	_ = h.cache.Set(c.Request.Context(), zone.PostalPrefix, zone.CarrierName, estimate)
+
+	c.JSON(http.StatusOK, estimate)
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// DeliverySlotHandler lets a customer check which delivery slots still have
+// room on a given date.
+type DeliverySlotHandler struct {
+	slots *service.DeliverySlotService
+}
+
+// NewDeliverySlotHandler builds a DeliverySlotHandler with its dependencies.
+func NewDeliverySlotHandler(slots *service.DeliverySlotService) *DeliverySlotHandler {
+	return &DeliverySlotHandler{slots: slots}
+}
+
+// Available returns every slot with remaining capacity on ?date=YYYY-MM-DD.
+func (h *DeliverySlotHandler) Available(c *gin.Context) {
+	date, err := time.Parse(dateLayout, c.Query("date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "date must be formatted as YYYY-MM-DD"})
+		return
+	}
+
+	slots, err := h.slots.Available(c.Request.Context(), date)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load delivery slots"})
+		return
+	}
+	c.JSON(http.StatusOK, slots)
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// ExchangeRateHandler exposes currency conversion for the storefront to
+// render multi-currency prices and payment totals.
+type ExchangeRateHandler struct {
+	rates *service.ExchangeRateService
+}
+
+// NewExchangeRateHandler builds an ExchangeRateHandler with its dependencies.
+func NewExchangeRateHandler(rates *service.ExchangeRateService) *ExchangeRateHandler {
+	return &ExchangeRateHandler{rates: rates}
+}
+
+// Convert converts an amount between two currencies using the latest
+// cached FX rates.
+func (h *ExchangeRateHandler) Convert(c *gin.Context) {
+	amount, err := strconv.ParseFloat(c.Query("amount"), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid amount"})
+		return
+	}
+	from := c.Query("from")
+	to := c.Query("to")
+	if from == "" || to == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to are required"})
+		return
+	}
+
+	converted, err := h.rates.Convert(c.Request.Context(), amount, from, to)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "exchange rates unavailable"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"amount": converted, "currency": to})
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	goredis "github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// pingTimeout bounds how long a single dependency check in Readyz may take,
+// so a wedged connection doesn't hang the probe itself.
+const pingTimeout = 2 * time.Second
+
+// HealthHandler serves liveness and readiness probes.
+type HealthHandler struct {
+	db  *gorm.DB
+	rdb *goredis.Client
+}
+
+// NewHealthHandler builds a HealthHandler backed by the primary database and
+// the Redis client, since either becoming unreachable should fail readiness.
+func NewHealthHandler(db *gorm.DB, rdb *goredis.Client) *HealthHandler {
+	return &HealthHandler{db: db, rdb: rdb}
+}
+
+// dependencyStatus reports whether a single dependency check succeeded and
+// how long it took.
+type dependencyStatus struct {
+	Status    string `json:"status"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Healthz reports basic liveness of the API process: if it can respond at
+// all, it's alive. It never checks dependencies, so a database or Redis
+// outage doesn't get the process killed by a liveness probe.
+func (h *HealthHandler) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readyz reports whether the process is ready to serve traffic: PostgreSQL
+// and Redis are both pinged with a bounded timeout and their status and
+// latency are reported individually, so a load balancer or Kubernetes can
+// pull an instance out of rotation the moment either dependency is down.
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	database := h.pingDatabase(c.Request.Context())
+	cache := h.pingRedis(c.Request.Context())
+
+	status := http.StatusOK
+	if database.Status != "ok" || cache.Status != "ok" {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, gin.H{
+		"status": map[bool]string{true: "ok", false: "unavailable"}[status == http.StatusOK],
+		"dependencies": gin.H{
+			"database": database,
+			"redis":    cache,
+		},
+	})
+}
+
+func (h *HealthHandler) pingDatabase(ctx context.Context) dependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	start := time.Now()
+	sqlDB, err := h.db.DB()
+	if err == nil {
+		err = sqlDB.PingContext(ctx)
+	}
+	return toDependencyStatus(start, err)
+}
+
+func (h *HealthHandler) pingRedis(ctx context.Context) dependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := h.rdb.Ping(ctx).Err()
+	return toDependencyStatus(start, err)
+}
+
+func toDependencyStatus(start time.Time, err error) dependencyStatus {
+	latency := time.Since(start)
+	if err != nil {
+		return dependencyStatus{Status: "unavailable", LatencyMS: latency.Milliseconds(), Error: err.Error()}
+	}
+	return dependencyStatus{Status: "ok", LatencyMS: latency.Milliseconds()}
+}
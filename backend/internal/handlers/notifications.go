@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/pagination"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultNotificationPageSize = 20
+	maxNotificationPageSize     = 100
+)
+
+// NotificationHandler exposes the authenticated user's notification
+// history.
+type NotificationHandler struct {
+	logs *psql.NotificationLogRepository
+}
+
+// NewNotificationHandler builds a NotificationHandler with its
+// dependencies.
+func NewNotificationHandler(logs *psql.NotificationLogRepository) *NotificationHandler {
+	return &NotificationHandler{logs: logs}
+}
+
+// History returns a page of the authenticated user's notification
+// history, newest first, across every channel.
+func (h *NotificationHandler) History(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	page, perPage, offset := pagination.ParseParams(c, defaultNotificationPageSize, maxNotificationPageSize)
+
+	logs, total, err := h.logs.ListByUser(c.Request.Context(), uid, perPage, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load notification history"})
+		return
+	}
+	c.JSON(http.StatusOK, pagination.New[models.NotificationLog](logs, total, page, perPage))
+}
@@ -0,0 +1,336 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/apperr"
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/orderevents"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrderHandler exposes order operations available to a shop's customers.
+type OrderHandler struct {
+	db              *gorm.DB
+	orders          *psql.OrderRepository
+	products        *psql.ProductRepository
+	cart            *psql.CartRepository
+	appliedCoupons  *psql.CartCouponRepository
+	pickupPoints    *psql.PickupPointRepository
+	deliverySlots   *service.DeliverySlotService
+	promo           *service.CouponService
+	totals          *service.TotalsService
+	stockValidation *service.StockValidationService
+	ledger          *service.LedgerService
+	currency        string
+	orderEvents     *orderevents.Broker
+}
+
+// NewOrderHandler builds an OrderHandler with its dependencies. db is used
+// only to open the transaction Create runs the order/stock/coupon writes
+// in - every other operation still goes through the repositories above.
+func NewOrderHandler(db *gorm.DB, orders *psql.OrderRepository, products *psql.ProductRepository, cart *psql.CartRepository, appliedCoupons *psql.CartCouponRepository, pickupPoints *psql.PickupPointRepository, deliverySlots *service.DeliverySlotService, promo *service.CouponService, totals *service.TotalsService, stockValidation *service.StockValidationService, ledger *service.LedgerService, currency string, orderEvents *orderevents.Broker) *OrderHandler {
+	return &OrderHandler{db: db, orders: orders, products: products, cart: cart, appliedCoupons: appliedCoupons, pickupPoints: pickupPoints, deliverySlots: deliverySlots, promo: promo, totals: totals, stockValidation: stockValidation, ledger: ledger, currency: currency, orderEvents: orderEvents}
+}
+
+// CreateOrderRequest is the payload accepted by Create.
+type CreateOrderRequest struct {
+	Note   string `json:"note"`
+	Region string `json:"region"`
+}
+
+// Create places an order from the caller's cart: it re-validates stock and
+// the cart's applied coupon (both can have gone stale since the last
+// /cart/totals call), computes the authoritative total via TotalsService,
+// and atomically decrements stock and redeems the coupon alongside
+// inserting the order, so a coupon at its usage limit or a product that
+// just sold out can't slip through between the check and the write. The
+// cart (and applied coupon, if any) is cleared once the order exists.
+// PaymentHandler.Pay is what a client calls next to actually charge it.
+func (h *OrderHandler) Create(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	var req CreateOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	items, err := h.cart.ListByUser(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load cart"})
+		return
+	}
+	if len(items) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cart is empty"})
+		return
+	}
+	if issues := h.stockValidation.CheckCart(items); len(issues) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "some items are no longer available", "issues": issues})
+		return
+	}
+
+	subtotal := 0.0
+	categoryIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		subtotal += item.Product.DiscountedPrice() * float64(item.Quantity)
+		categoryIDs = append(categoryIDs, item.Product.CategoryID)
+	}
+
+	var appliedCoupon *models.Coupon
+	couponDiscount := 0.0
+	if cc, err := h.appliedCoupons.Get(c.Request.Context(), userID); err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load applied coupon"})
+			return
+		}
+	} else if discount, err := h.promo.Apply(cc.Coupon, subtotal, categoryIDs); err == nil {
+		couponDiscount = discount
+		coupon := cc.Coupon
+		appliedCoupon = &coupon
+	}
+	// A coupon that no longer qualifies (expired, used up, cart changed
+	// since it was applied) is silently dropped from the order rather than
+	// failing checkout, same as CartHandler.appliedCouponDiscount does for
+	// the totals preview.
+
+	totals := h.totals.Calculate(items, couponDiscount)
+
+	order := &models.Order{
+		UserID:      userID,
+		TotalAmount: totals.Total,
+		VATAmount:   totals.Tax,
+		Note:        req.Note,
+		Region:      req.Region,
+	}
+	for _, item := range items {
+		orderItem := models.OrderItem{
+			ID:          uuid.New(),
+			ProductID:   item.ProductID,
+			Size:        item.Size,
+			Quantity:    item.Quantity,
+			PriceAtTime: item.Product.DiscountedPrice(),
+		}
+		orderItem.Snapshot(item.Product)
+		order.Items = append(order.Items, orderItem)
+	}
+
+	err = psql.WithTx(c.Request.Context(), h.db, func(tx *psql.TxRepos) error {
+		for _, item := range items {
+			if err := tx.Products.DecrementStock(c.Request.Context(), item.ProductID, item.Quantity); err != nil {
+				return err
+			}
+		}
+		if appliedCoupon != nil {
+			ok, err := tx.Coupons.IncrementUsageIfUnderLimit(c.Request.Context(), appliedCoupon.ID)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return service.ErrCouponNotApplicable
+			}
+		}
+		if err := tx.Orders.Create(c.Request.Context(), order); err != nil {
+			return err
+		}
+		if err := tx.Cart.Clear(c.Request.Context(), userID); err != nil {
+			return err
+		}
+		if appliedCoupon != nil {
+			if err := tx.CartCoupon.Clear(c.Request.Context(), userID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrCouponNotApplicable) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "applied coupon reached its usage limit, remove it and try again"})
+			return
+		}
+		apperr.Respond(c, err)
+		return
+	}
+
+	if appliedCoupon != nil && couponDiscount > 0 {
+		if err := h.ledger.RecordCouponDiscount(c.Request.Context(), order.ID, appliedCoupon.ID, couponDiscount, h.currency); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to record ledger entry"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// Events streams an order's status changes as server-sent events, so the
+// "track my order" page can react to admin and payment-webhook updates
+// without polling. The connection is held open until the client
+// disconnects; there's no replay of events missed before it connected.
+func (h *OrderHandler) Events(c *gin.Context) {
+	userID, _ := c.Get(middleware.ContextUserID)
+
+	order, err := h.orders.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "order not found"})
+		return
+	}
+	if order.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "order belongs to another user"})
+		return
+	}
+
+	sub := h.orderEvents.Subscribe(c.Request.Context(), order.ID)
+	defer sub.Close()
+	ch := sub.Channel()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case msg, ok := <-ch:
+			if !ok {
+				return false
+			}
+			c.SSEvent("order-status", msg.Payload)
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+// ReorderResult reports the outcome of rebuilding a cart from a past order.
+type ReorderResult struct {
+	AddedCount int      `json:"added_count"`
+	Dropped    []string `json:"dropped"`
+}
+
+// Reorder rebuilds the caller's cart from a past order's items, skipping any
+// product that has since been discontinued or gone out of stock and
+// reporting which ones were dropped.
+func (h *OrderHandler) Reorder(c *gin.Context) {
+	userID, _ := c.Get(middleware.ContextUserID)
+
+	order, err := h.orders.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "order not found"})
+		return
+	}
+	if order.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "order belongs to another user"})
+		return
+	}
+
+	result := ReorderResult{Dropped: []string{}}
+	for _, item := range order.Items {
+		product, err := h.products.GetByID(c.Request.Context(), item.ProductID.String())
+		if err != nil || product.Discontinued || product.StockQuantity < item.Quantity {
+			result.Dropped = append(result.Dropped, item.ProductName)
+			continue
+		}
+
+		if err := h.cart.AddOrIncrement(c.Request.Context(), userID.(uint), product.ID, item.Size, item.Quantity, product.DiscountedPrice()); err != nil {
+			result.Dropped = append(result.Dropped, item.ProductName)
+			continue
+		}
+		result.AddedCount++
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// SetPickupPointRequest is the payload accepted by SetPickupPoint.
+type SetPickupPointRequest struct {
+	PickupPointID string `json:"pickup_point_id" binding:"required"`
+}
+
+// SetPickupPoint attaches a carrier pickup point to an order, so it's
+// delivered there instead of a home address.
+func (h *OrderHandler) SetPickupPoint(c *gin.Context) {
+	userID, _ := c.Get(middleware.ContextUserID)
+
+	order, err := h.orders.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "order not found"})
+		return
+	}
+	if order.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "order belongs to another user"})
+		return
+	}
+
+	var req SetPickupPointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pickupPointID, err := uuid.Parse(req.PickupPointID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pickup_point_id"})
+		return
+	}
+	if _, err := h.pickupPoints.GetByID(c.Request.Context(), req.PickupPointID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "pickup point not found"})
+		return
+	}
+
+	if err := h.orders.SetPickupPoint(c.Request.Context(), order.ID, pickupPointID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to attach pickup point"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// BookDeliverySlotRequest is the payload accepted by BookDeliverySlot.
+type BookDeliverySlotRequest struct {
+	SlotID string `json:"slot_id" binding:"required"`
+	Date   string `json:"date" binding:"required"`
+}
+
+// BookDeliverySlot claims a delivery slot for an order on a date, so the
+// warehouse knows which window to have it ready for.
+func (h *OrderHandler) BookDeliverySlot(c *gin.Context) {
+	userID, _ := c.Get(middleware.ContextUserID)
+
+	order, err := h.orders.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "order not found"})
+		return
+	}
+	if order.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "order belongs to another user"})
+		return
+	}
+
+	var req BookDeliverySlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	slotID, err := uuid.Parse(req.SlotID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid slot_id"})
+		return
+	}
+	date, err := time.Parse(dateLayout, req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "date must be formatted as YYYY-MM-DD"})
+		return
+	}
+
+	if err := h.deliverySlots.Book(c.Request.Context(), order.ID, slotID, date); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
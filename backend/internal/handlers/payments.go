@@ -0,0 +1,319 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/orderevents"
+	"github.com/YoungGoofy/shopping/internal/payments"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// webhookSignatureHeader carries the gateway's signature of the raw
+// request body, checked against Provider.VerifySignature.
+const webhookSignatureHeader = "X-Payment-Signature"
+
+// PaymentHandler starts and tracks payments against orders.
+type PaymentHandler struct {
+	orders       *psql.OrderRepository
+	payments     *psql.PaymentRepository
+	webhookLog   *psql.PaymentWebhookEventRepository
+	methods      *psql.PaymentMethodRepository
+	savedCards   *psql.SavedCardRepository
+	eligibility  *service.PaymentMethodEligibilityService
+	provider     payments.Provider
+	providerName string
+	currency     string
+	returnURL    string
+	maxRetries   int
+	ledger       *service.LedgerService
+	orderEvents  *orderevents.Broker
+}
+
+// NewPaymentHandler builds a PaymentHandler with its dependencies.
+// providerName identifies the gateway (e.g. "yookassa") and is stored on
+// each Payment row so reconciliation knows which gateway to ask.
+// maxRetries caps how many payment attempts an order may accumulate
+// before Pay refuses further ones and leaves the order for auto-cancel.
+func NewPaymentHandler(orders *psql.OrderRepository, paymentsRepo *psql.PaymentRepository, webhookLog *psql.PaymentWebhookEventRepository, methods *psql.PaymentMethodRepository, savedCards *psql.SavedCardRepository, eligibility *service.PaymentMethodEligibilityService, provider payments.Provider, providerName, currency, returnURL string, maxRetries int, ledger *service.LedgerService, orderEvents *orderevents.Broker) *PaymentHandler {
+	return &PaymentHandler{orders: orders, payments: paymentsRepo, webhookLog: webhookLog, methods: methods, savedCards: savedCards, eligibility: eligibility, provider: provider, providerName: providerName, currency: currency, returnURL: returnURL, maxRetries: maxRetries, ledger: ledger, orderEvents: orderEvents}
+}
+
+// EligiblePaymentMethods lists the payment methods available to a
+// customer for the given order amount and region, e.g. to render at
+// checkout before the customer commits to one.
+func (h *PaymentHandler) EligiblePaymentMethods(c *gin.Context) {
+	amount, _ := strconv.ParseFloat(c.Query("amount"), 64)
+	region := c.Query("region")
+
+	all, err := h.methods.ListEnabled(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load payment methods"})
+		return
+	}
+	c.JSON(http.StatusOK, h.eligibility.FilterEligible(all, amount, region))
+}
+
+// PayRequest names how the customer wants to pay: either a PaymentMethod
+// key chosen at checkout (see EligiblePaymentMethods), optionally asking
+// to tokenize the card for next time via SaveCard, or a SavedCardID to
+// charge a card tokenized on an earlier order in one click.
+type PayRequest struct {
+	PaymentMethod string `json:"payment_method"`
+	SaveCard      bool   `json:"save_card"`
+	SavedCardID   string `json:"saved_card_id"`
+}
+
+// Pay starts a payment for the caller's own order and returns the
+// gateway's confirmation URL for the client to redirect to, unless it was
+// charged directly against a saved card.
+func (h *PaymentHandler) Pay(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	var req PayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.PaymentMethod == "" && req.SavedCardID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "payment_method or saved_card_id is required"})
+		return
+	}
+
+	order, err := h.orders.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "order not found"})
+		return
+	}
+	if order.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "order belongs to another user"})
+		return
+	}
+	if order.PaymentStatus == models.PaymentStatusPaid {
+		c.JSON(http.StatusConflict, gin.H{"error": "order is already paid"})
+		return
+	}
+
+	if h.maxRetries > 0 {
+		attempts, err := h.payments.CountByOrder(c.Request.Context(), order.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check payment attempts"})
+			return
+		}
+		if attempts >= int64(h.maxRetries) {
+			c.JSON(http.StatusConflict, gin.H{"error": "maximum payment attempts exceeded"})
+			return
+		}
+	}
+
+	createReq := payments.CreatePaymentRequest{
+		OrderID:     order.ID.String(),
+		Amount:      order.TotalAmount,
+		Currency:    h.currency,
+		Description: fmt.Sprintf("Order %s", order.ID),
+		ReturnURL:   h.returnURL,
+	}
+
+	methodKey := req.PaymentMethod
+	if req.SavedCardID != "" {
+		card, err := h.savedCards.GetByID(c.Request.Context(), userID, req.SavedCardID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "saved card not found"})
+			return
+		}
+		createReq.SavedMethodID = card.GatewayMethodID
+		methodKey = "card"
+	} else {
+		method, err := h.methods.GetByKey(c.Request.Context(), req.PaymentMethod)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "unknown payment method"})
+			return
+		}
+		if !h.eligibility.IsEligible(*method, order.TotalAmount, order.Region) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "payment method not available for this order"})
+			return
+		}
+		createReq.SaveMethod = req.SaveCard
+	}
+	if err := h.orders.UpdatePaymentMethod(c.Request.Context(), order.ID, methodKey); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update order"})
+		return
+	}
+
+	if methodKey == models.PaymentMethodCashOnDelivery {
+		h.payCashOnDelivery(c, order)
+		return
+	}
+
+	result, err := h.provider.CreatePayment(c.Request.Context(), createReq)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "failed to start payment with gateway"})
+		return
+	}
+
+	payment := &models.Payment{
+		OrderID:          order.ID,
+		Provider:         h.providerName,
+		GatewayPaymentID: result.GatewayPaymentID,
+		Status:           models.PaymentStatusPending,
+		Amount:           order.TotalAmount,
+		Currency:         h.currency,
+		ConfirmationURL:  result.ConfirmationURL,
+	}
+	if err := h.payments.Create(c.Request.Context(), payment); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to record payment"})
+		return
+	}
+
+	if result.SavedMethod != nil {
+		if err := h.savedCards.Create(c.Request.Context(), &models.SavedCard{
+			UserID:          userID,
+			Provider:        h.providerName,
+			GatewayMethodID: result.SavedMethod.GatewayMethodID,
+			Last4:           result.SavedMethod.Last4,
+			ExpMonth:        result.SavedMethod.ExpMonth,
+			ExpYear:         result.SavedMethod.ExpYear,
+		}); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save card"})
+			return
+		}
+	}
+
+	if err := h.orders.UpdatePaymentStatus(c.Request.Context(), order.ID, models.PaymentStatusPending); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update order"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, payment)
+}
+
+// payCashOnDelivery records a COD payment without ever talking to the
+// gateway. It sits in PaymentStatusAwaitingDelivery until an admin marks
+// it collected, feeding into the same Payment/Order records a gateway
+// payment would.
+func (h *PaymentHandler) payCashOnDelivery(c *gin.Context, order *models.Order) {
+	payment := &models.Payment{
+		OrderID:          order.ID,
+		Provider:         "cod",
+		GatewayPaymentID: "cod-" + uuid.New().String(),
+		Status:           models.PaymentStatusAwaitingDelivery,
+		Amount:           order.TotalAmount,
+		Currency:         h.currency,
+	}
+	if err := h.payments.Create(c.Request.Context(), payment); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to record payment"})
+		return
+	}
+	if err := h.orders.UpdatePaymentStatus(c.Request.Context(), order.ID, models.PaymentStatusAwaitingDelivery); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update order"})
+		return
+	}
+	c.JSON(http.StatusCreated, payment)
+}
+
+// gatewayStatusToPaymentStatus maps a gateway's own status vocabulary onto
+// our PaymentStatus values. Unrecognized statuses map to "", meaning "log
+// the event but don't change anything" - safer than guessing.
+func gatewayStatusToPaymentStatus(status string) string {
+	switch status {
+	case "succeeded":
+		return models.PaymentStatusPaid
+	case "canceled":
+		return models.PaymentStatusFailed
+	case "pending", "waiting_for_capture":
+		return models.PaymentStatusPending
+	case "expired":
+		// YooKassa itself doesn't emit this today, but other gateways (and
+		// its own hosted confirmation page) time out a payment this way, so
+		// the mapping is here for when a provider does send it.
+		return models.PaymentStatusExpired
+	default:
+		return ""
+	}
+}
+
+// Webhook receives gateway payment notifications. Every notification is
+// logged regardless of outcome, so reconciliation never depends on the
+// gateway's own dashboard. Applying the same event twice is safe: the
+// status update just sets Payment/Order to the same value again, and the
+// ledger capture only fires on the actual unpaid/pending -> paid
+// transition, not on every redelivery of an already-applied event.
+func (h *PaymentHandler) Webhook(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+		return
+	}
+
+	if !h.provider.VerifySignature(body, c.GetHeader(webhookSignatureHeader)) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid signature"})
+		return
+	}
+
+	event, err := h.provider.ParseWebhookEvent(body)
+	eventType := "unparseable"
+	if err == nil {
+		eventType = event.EventType
+	}
+
+	logErr := h.webhookLog.Create(c.Request.Context(), &models.PaymentWebhookEvent{
+		Provider:         h.providerName,
+		GatewayPaymentID: event.GatewayPaymentID,
+		EventType:        eventType,
+		Payload:          string(body),
+	})
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to parse webhook payload"})
+		return
+	}
+	if logErr != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to log webhook event"})
+		return
+	}
+
+	newStatus := gatewayStatusToPaymentStatus(event.Status)
+	if newStatus == "" {
+		c.Status(http.StatusOK)
+		return
+	}
+
+	payment, err := h.payments.GetByGatewayID(c.Request.Context(), event.GatewayPaymentID)
+	if err != nil {
+		// Unknown payment ID: acknowledge anyway so the gateway stops retrying.
+		c.Status(http.StatusOK)
+		return
+	}
+
+	wasPaid := payment.Status == models.PaymentStatusPaid
+
+	if err := h.payments.UpdateStatus(c.Request.Context(), payment.ID, newStatus); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update payment"})
+		return
+	}
+	if err := h.orders.UpdatePaymentStatus(c.Request.Context(), payment.OrderID, newStatus); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update order"})
+		return
+	}
+	_ = h.orderEvents.PublishPaymentStatus(c.Request.Context(), payment.OrderID, newStatus)
+
+	// Only book a capture on the unpaid/pending -> paid transition. Gateways
+	// routinely redeliver already-acked events, and re-applying the status
+	// update is harmless, but re-running RecordCapture on a redelivery would
+	// double-book the same revenue in the ledger.
+	if newStatus == models.PaymentStatusPaid && !wasPaid {
+		if err := h.ledger.RecordCapture(c.Request.Context(), payment.OrderID, payment.ID, payment.Amount, payment.Currency); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to record ledger entry"})
+			return
+		}
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+// PickupPointHandler lets a customer search for a carrier pickup point.
+// Results are served from PickupPointRepository, kept current by
+// AdminPickupPointHandler.Sync rather than calling the carrier on every
+// search.
+type PickupPointHandler struct {
+	points *psql.PickupPointRepository
+}
+
+// NewPickupPointHandler builds a PickupPointHandler with its dependencies.
+func NewPickupPointHandler(points *psql.PickupPointRepository) *PickupPointHandler {
+	return &PickupPointHandler{points: points}
+}
+
+// Search returns every pickup point in a city.
+func (h *PickupPointHandler) Search(c *gin.Context) {
+	city := c.Query("city")
+	if city == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "city is required"})
+		return
+	}
+
+	points, err := h.points.Search(c.Request.Context(), city)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load pickup points"})
+		return
+	}
+	c.JSON(http.StatusOK, points)
+}
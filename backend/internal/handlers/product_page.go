@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/etag"
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/repository/redis"
+	"github.com/gin-gonic/gin"
+)
+
+const relatedProductsLimit = 8
+
+// ProductPageHandler composes everything a product detail page needs -
+// the product itself, its reviews, related products and a breadcrumb
+// trail - into a single response, so the frontend doesn't have to make
+// four round trips to render one page.
+type ProductPageHandler struct {
+	products     *psql.ProductRepository
+	reviews      *psql.ReviewRepository
+	translations *psql.ProductTranslationRepository
+	history      *redis.BrowsingHistoryRepository
+	views        *redis.ProductViewRepository
+}
+
+// NewProductPageHandler builds a ProductPageHandler with its dependencies.
+func NewProductPageHandler(products *psql.ProductRepository, reviews *psql.ReviewRepository, translations *psql.ProductTranslationRepository, history *redis.BrowsingHistoryRepository, views *redis.ProductViewRepository) *ProductPageHandler {
+	return &ProductPageHandler{products: products, reviews: reviews, translations: translations, history: history, views: views}
+}
+
+// Breadcrumb is one link in a breadcrumb trail.
+type Breadcrumb struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// Get returns a product page bundle, keyed by product ID. The response
+// carries an ETag, same as the collection endpoints, since this is read
+// far more often than the underlying product changes.
+func (h *ProductPageHandler) Get(c *gin.Context) {
+	productID := c.Param("id")
+
+	product, err := h.products.GetByID(c.Request.Context(), productID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "product not found"})
+		return
+	}
+
+	reviews, totalReviews, err := h.reviews.ListByProduct(c.Request.Context(), product.ID, psql.ReviewListOptions{
+		Sort:  "newest",
+		Limit: 10,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load reviews"})
+		return
+	}
+
+	related, err := h.products.ListByCategory(c.Request.Context(), product.CategoryID, productID, relatedProductsLimit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load related products"})
+		return
+	}
+
+	h.views.Record(c.Request.Context(), productID)
+	if uid, ok := userID(c); ok {
+		h.history.Record(c.Request.Context(), uid, productID)
+	}
+
+	h.applyTranslation(c, product)
+
+	etag.Respond(c, http.StatusOK, gin.H{
+		"product":       product,
+		"reviews":       reviews,
+		"total_reviews": totalReviews,
+		"related":       related,
+		"breadcrumbs":   breadcrumbsFor(product),
+	})
+}
+
+// applyTranslation overlays product's Name/Description with the requester's
+// locale, when a translation exists for it. A product with no translation
+// for that locale keeps its own Name/Description, which are treated as the
+// default locale's copy.
+func (h *ProductPageHandler) applyTranslation(c *gin.Context, product *models.Product) {
+	locale := middleware.LocaleFrom(c)
+	translation, err := h.translations.GetByProductAndLocale(c.Request.Context(), product.ID, locale)
+	if err != nil {
+		// Untranslated (or lookup failed) - keep the base row's own copy.
+		return
+	}
+	product.Name = translation.Name
+	product.Description = translation.Description
+}
+
+func breadcrumbsFor(product *models.Product) []Breadcrumb {
+	trail := []Breadcrumb{{Name: "Home", Path: "/"}}
+	if product.Category.Name != "" {
+		trail = append(trail, Breadcrumb{Name: product.Category.Name, Path: "/categories/" + product.Category.Name})
+	}
+	trail = append(trail, Breadcrumb{Name: product.Name, Path: "/products/" + product.ID.String()})
+	return trail
+}
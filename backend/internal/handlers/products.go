@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/cache"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/pagination"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/repository/redis"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultProductPageSize = 20
+	maxProductPageSize     = 100
+
+	homepageListLimit     = 12
+	trendingWindowDays    = 3
+	bestsellersWindowDays = 30
+	homepageCacheTTL      = 15 * time.Minute
+)
+
+// ProductHandler exposes the public product feed.
+type ProductHandler struct {
+	products *psql.ProductRepository
+	orders   *psql.OrderRepository
+	views    *redis.ProductViewRepository
+	cache    *cache.Cache
+}
+
+// NewProductHandler builds a ProductHandler with its dependencies.
+func NewProductHandler(products *psql.ProductRepository, orders *psql.OrderRepository, views *redis.ProductViewRepository, cache *cache.Cache) *ProductHandler {
+	return &ProductHandler{products: products, orders: orders, views: views, cache: cache}
+}
+
+// List returns products newest-first. It defaults to offset pagination
+// (page/page_size), same as every other list endpoint - but a client
+// paging deep into the feed (infinite scroll, a crawler) should pass a
+// cursor instead: once a "cursor" query param is present, List switches
+// to keyset pagination, which doesn't slow down the way OFFSET does on
+// deep pages.
+func (h *ProductHandler) List(c *gin.Context) {
+	if _, ok := c.GetQuery("cursor"); ok {
+		h.listByCursor(c)
+		return
+	}
+
+	page, perPage, offset := pagination.ParseParams(c, defaultProductPageSize, maxProductPageSize)
+	products, err := h.products.List(c.Request.Context(), perPage, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list products"})
+		return
+	}
+	total, err := h.products.Count(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list products"})
+		return
+	}
+	c.JSON(http.StatusOK, pagination.New(products, total, page, perPage))
+}
+
+func (h *ProductHandler) listByCursor(c *gin.Context) {
+	after, err := pagination.DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	limit := defaultProductPageSize
+	if raw := c.Query("limit"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+	if limit > maxProductPageSize {
+		limit = maxProductPageSize
+	}
+
+	products, err := h.products.ListByCursor(c.Request.Context(), after.CreatedAt, after.ID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list products"})
+		return
+	}
+
+	page := pagination.CursorPage[models.Product]{Items: products}
+	if len(products) == limit {
+		last := products[len(products)-1]
+		page.NextCursor = pagination.Cursor{CreatedAt: last.CreatedDate, ID: last.ID.String()}.Encode()
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// Trending returns the products with the most detail-page views over the
+// last trendingWindowDays days, for the homepage's "trending now" module.
+func (h *ProductHandler) Trending(c *gin.Context) {
+	const cacheKey = "trending"
+	var products []models.Product
+	if hit, _ := h.cache.Get(c.Request.Context(), cacheKey, &products); hit {
+		c.JSON(http.StatusOK, gin.H{"products": products})
+		return
+	}
+
+	ids, err := h.views.Trending(c.Request.Context(), trendingWindowDays, homepageListLimit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load trending products"})
+		return
+	}
+	products, err = h.orderedByIDs(c, ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load trending products"})
+		return
+	}
+
+	_ = h.cache.Set(c.Request.Context(), cacheKey, products)
+	c.JSON(http.StatusOK, gin.H{"products": products})
+}
+
+// Bestsellers returns the products with the most units sold over the last
+// bestsellersWindowDays days, for the homepage's "bestsellers" module.
+func (h *ProductHandler) Bestsellers(c *gin.Context) {
+	const cacheKey = "bestsellers"
+	var products []models.Product
+	if hit, _ := h.cache.Get(c.Request.Context(), cacheKey, &products); hit {
+		c.JSON(http.StatusOK, gin.H{"products": products})
+		return
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -bestsellersWindowDays)
+	rows, err := h.orders.TopProducts(c.Request.Context(), from, to, homepageListLimit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load bestsellers"})
+		return
+	}
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ProductID.String()
+	}
+	products, err = h.orderedByIDs(c, ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load bestsellers"})
+		return
+	}
+
+	_ = h.cache.Set(c.Request.Context(), cacheKey, products)
+	c.JSON(http.StatusOK, gin.H{"products": products})
+}
+
+// orderedByIDs fetches products by ID and returns them back in ids' order -
+// GetByIDs makes no ordering guarantee, but Trending/Bestsellers need their
+// ranking preserved for the homepage module to render best-first.
+func (h *ProductHandler) orderedByIDs(c *gin.Context, ids []string) ([]models.Product, error) {
+	fetched, err := h.products.GetByIDs(c.Request.Context(), ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]models.Product, len(fetched))
+	for _, p := range fetched {
+		byID[p.ID.String()] = p
+	}
+	ordered := make([]models.Product, 0, len(ids))
+	for _, id := range ids {
+		if p, ok := byID[id]; ok {
+			ordered = append(ordered, p)
+		}
+	}
+	return ordered, nil
+}
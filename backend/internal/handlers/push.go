@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+// PushHandler exposes registration for the authenticated user's Web Push
+// subscriptions.
+type PushHandler struct {
+	subscriptions *psql.PushSubscriptionRepository
+}
+
+// NewPushHandler builds a PushHandler with its dependencies.
+func NewPushHandler(subscriptions *psql.PushSubscriptionRepository) *PushHandler {
+	return &PushHandler{subscriptions: subscriptions}
+}
+
+// SubscriptionRequest is the payload accepted by Register and Unregister,
+// shaped like what PushSubscription.toJSON() produces in the browser.
+type SubscriptionRequest struct {
+	Endpoint string `json:"endpoint" binding:"required"`
+	Keys     struct {
+		P256DH string `json:"p256dh" binding:"required"`
+		Auth   string `json:"auth" binding:"required"`
+	} `json:"keys" binding:"required"`
+}
+
+// Register saves or refreshes the authenticated user's push subscription
+// for one browser.
+func (h *PushHandler) Register(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	var req SubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sub := &models.PushSubscription{
+		UserID:   uid,
+		Endpoint: req.Endpoint,
+		P256DH:   req.Keys.P256DH,
+		Auth:     req.Keys.Auth,
+	}
+	if err := h.subscriptions.Upsert(c.Request.Context(), sub); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save subscription"})
+		return
+	}
+	c.JSON(http.StatusOK, sub)
+}
+
+// Unregister removes the authenticated user's push subscription for one
+// browser, identified by its endpoint query parameter, as called from the
+// page's unsubscribe flow.
+func (h *PushHandler) Unregister(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	endpoint := c.Query("endpoint")
+	if endpoint == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "endpoint is required"})
+		return
+	}
+
+	if err := h.subscriptions.DeleteByEndpoint(c.Request.Context(), uid, endpoint); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove subscription"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// RecommendationHandler serves product recommendations built by
+// RecommendationService.
+type RecommendationHandler struct {
+	recommendations *service.RecommendationService
+}
+
+// NewRecommendationHandler builds a RecommendationHandler with its dependencies.
+func NewRecommendationHandler(recommendations *service.RecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{recommendations: recommendations}
+}
+
+// AlsoBought returns the "customers also bought" list for a product.
+func (h *RecommendationHandler) AlsoBought(c *gin.Context) {
+	alsoBought, err := h.recommendations.AlsoBought(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load recommendations"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"product_ids": alsoBought})
+}
+
+// ForYou returns a personalized recommendation list for the authenticated
+// user, built from the also-bought lists of the products they've viewed
+// most recently.
+func (h *RecommendationHandler) ForYou(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+	recommended, err := h.recommendations.ForYou(c.Request.Context(), uid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load recommendations"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"product_ids": recommended})
+}
@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/pagination"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultReviewPageSize = 20
+	maxReviewPageSize     = 100
+)
+
+// ReviewHandler exposes the product review API.
+type ReviewHandler struct {
+	reviews         *psql.ReviewRepository
+	orders          *psql.OrderRepository
+	votes           *psql.ReviewVoteRepository
+	maxPerDay       int
+	softMaxLength   int
+	requirePurchase bool
+	maxPhotos       int
+}
+
+// NewReviewHandler builds a ReviewHandler with its dependencies. maxPerDay
+// and softMaxLength are soft quotas: exceeding either does not reject the
+// review, it just flags it for moderation. requirePurchase turns the
+// verified-purchase check into a hard requirement instead of just a badge.
+func NewReviewHandler(reviews *psql.ReviewRepository, orders *psql.OrderRepository, votes *psql.ReviewVoteRepository, maxPerDay, softMaxLength int, requirePurchase bool, maxPhotos int) *ReviewHandler {
+	return &ReviewHandler{reviews: reviews, orders: orders, votes: votes, maxPerDay: maxPerDay, softMaxLength: softMaxLength, requirePurchase: requirePurchase, maxPhotos: maxPhotos}
+}
+
+// CreateReviewRequest is the payload accepted by Create.
+type CreateReviewRequest struct {
+	Rating  int    `json:"rating" binding:"required,min=1,max=5"`
+	Content string `json:"content" binding:"required"`
+}
+
+// Create submits a review for a product. A user who has posted too many
+// reviews in the last 24h, or whose content is unusually long, is not
+// blocked, but the review is flagged for the moderation queue.
+func (h *ReviewHandler) Create(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	var req CreateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if existing, err := h.reviews.GetByUserAndProduct(c.Request.Context(), userID, productID); err == nil {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":              "you've already reviewed this product, edit your existing review instead",
+			"existing_review_id": existing.ID,
+		})
+		return
+	}
+
+	recentCount, err := h.reviews.CountByUserSince(c.Request.Context(), userID, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check submission rate"})
+		return
+	}
+
+	verified, err := h.orders.HasDeliveredPurchase(c.Request.Context(), userID, productID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check purchase history"})
+		return
+	}
+	if h.requirePurchase && !verified {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only customers who bought this product can review it"})
+		return
+	}
+
+	review := &models.Review{
+		ProductID:        productID,
+		UserID:           userID,
+		Rating:           req.Rating,
+		Content:          req.Content,
+		Flagged:          int(recentCount) >= h.maxPerDay || len(req.Content) > h.softMaxLength,
+		Status:           models.ReviewStatusPending,
+		VerifiedPurchase: verified,
+	}
+
+	if err := h.reviews.Create(c.Request.Context(), review); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save review"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, review)
+}
+
+// UpdateReviewRequest is the payload accepted by Update.
+type UpdateReviewRequest struct {
+	Rating  int    `json:"rating" binding:"required,min=1,max=5"`
+	Content string `json:"content" binding:"required"`
+}
+
+// Update edits the caller's own review.
+func (h *ReviewHandler) Update(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	var req UpdateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	review, err := h.reviews.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+		return
+	}
+	if review.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "review belongs to another user"})
+		return
+	}
+
+	if err := h.reviews.Update(c.Request.Context(), userID, c.Param("id"), req.Rating, req.Content); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update review"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Delete removes the caller's own review.
+func (h *ReviewHandler) Delete(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	review, err := h.reviews.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+		return
+	}
+	if review.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "review belongs to another user"})
+		return
+	}
+
+	if err := h.reviews.Delete(c.Request.Context(), userID, c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete review"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListByProduct returns a page of a product's reviews, with no
+// authentication required. Query params: sort (newest, highest, lowest,
+// helpful), rating (1-5, exact match), with_photos, verified_only, page
+// (1-based, default 1), page_size (default 20, capped at 100).
+func (h *ReviewHandler) ListByProduct(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	page, pageSize, offset := pagination.ParseParams(c, defaultReviewPageSize, maxReviewPageSize)
+	rating, _ := strconv.Atoi(c.Query("rating"))
+
+	opts := psql.ReviewListOptions{
+		Sort:         c.Query("sort"),
+		Rating:       rating,
+		WithPhotos:   c.Query("with_photos") == "true",
+		VerifiedOnly: c.Query("verified_only") == "true",
+		Limit:        pageSize,
+		Offset:       offset,
+	}
+
+	reviews, total, err := h.reviews.ListByProduct(c.Request.Context(), productID, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load reviews"})
+		return
+	}
+	c.JSON(http.StatusOK, pagination.New(reviews, total, page, pageSize))
+}
+
+// VoteReviewRequest is the payload accepted by Vote.
+type VoteReviewRequest struct {
+	Helpful bool `json:"helpful"`
+}
+
+// Vote records the caller's helpful/unhelpful vote on a review, replacing
+// any earlier vote they cast on it.
+func (h *ReviewHandler) Vote(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return
+	}
+
+	var req VoteReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.votes.Cast(c.Request.Context(), reviewID, userID, req.Helpful); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save vote"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AddPhotos attaches uploaded images to the caller's own review, up to the
+// configured per-review limit. The images ride along with the review's own
+// moderation status rather than needing a separate approval step, so a
+// pending review's photos only go live once the review itself is approved.
+func (h *ReviewHandler) AddPhotos(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	review, err := h.reviews.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+		return
+	}
+	if review.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "review belongs to another user"})
+		return
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expected multipart form data"})
+		return
+	}
+	files := form.File["photos"]
+	if len(files) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'photos' file field"})
+		return
+	}
+	if len(review.Images)+len(files) > h.maxPhotos {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "too many photos", "max_photos": h.maxPhotos})
+		return
+	}
+
+	filenames := make([]string, 0, len(files))
+	for _, fh := range files {
+		file, err := fh.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read uploaded file"})
+			return
+		}
+		_, err = io.Copy(io.Discard, file)
+		file.Close()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read uploaded file"})
+			return
+		}
+		filenames = append(filenames, fh.Filename)
+	}
+
+	if err := h.reviews.AddImages(c.Request.Context(), userID, c.Param("id"), filenames); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save photos"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
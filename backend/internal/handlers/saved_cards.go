@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+// SavedCardHandler lets a customer manage the cards they've tokenized
+// with the gateway for one-click payment.
+type SavedCardHandler struct {
+	cards *psql.SavedCardRepository
+}
+
+// NewSavedCardHandler builds a SavedCardHandler with its dependencies.
+func NewSavedCardHandler(cards *psql.SavedCardRepository) *SavedCardHandler {
+	return &SavedCardHandler{cards: cards}
+}
+
+// List returns every card the caller has saved.
+func (h *SavedCardHandler) List(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	cards, err := h.cards.ListByUser(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load saved cards"})
+		return
+	}
+	c.JSON(http.StatusOK, cards)
+}
+
+// Delete removes a saved card owned by the caller.
+func (h *SavedCardHandler) Delete(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	if err := h.cards.Delete(c.Request.Context(), userID, c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete saved card"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/search"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// SearchHandler serves product search, backed by a search.Engine with a
+// PostgreSQL full-text fallback for when the engine is unavailable.
+type SearchHandler struct {
+	engine    search.Engine
+	products  *psql.ProductRepository
+	suggest   *service.SearchSuggestService
+	analytics *psql.SearchAnalyticsRepository
+}
+
+// NewSearchHandler builds a SearchHandler with its dependencies.
+func NewSearchHandler(engine search.Engine, products *psql.ProductRepository, suggest *service.SearchSuggestService, analytics *psql.SearchAnalyticsRepository) *SearchHandler {
+	return &SearchHandler{engine: engine, products: products, suggest: suggest, analytics: analytics}
+}
+
+// recordQueryLog logs query for the admin search analytics report. It's
+// best-effort: a logging failure shouldn't fail a search that otherwise
+// succeeded, so errors are logged, not returned.
+func (h *SearchHandler) recordQueryLog(c *gin.Context, query string, resultCount int, source string) {
+	log := &models.SearchQueryLog{Query: query, ResultCount: resultCount, Source: source}
+	if uid, ok := userID(c); ok {
+		log.UserID = &uid
+	}
+	if err := h.analytics.RecordQuery(c.Request.Context(), log); err != nil {
+		logrus.WithError(err).WithField("query", query).Warn("search: failed to record query analytics")
+	}
+}
+
+// Search returns products matching q, ranked by the search engine's
+// relevance scoring. If the engine call fails - most commonly because the
+// engine is down and its circuit breaker is open - the same query is
+// answered from PostgreSQL full-text search instead, at reduced ranking
+// quality but with the endpoint still up.
+func (h *SearchHandler) Search(c *gin.Context) {
+	query := c.Query("q")
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'q' query parameter"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultSearchLimit)))
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	// Facets are always computed from PostgreSQL: the engine's own faceting
+	// API differs per provider, and this endpoint's job is a single
+	// response the storefront filter sidebar can render from regardless of
+	// which result source served the hits.
+	facets, err := h.products.SearchFacets(c.Request.Context(), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "search failed"})
+		return
+	}
+
+	if err := h.suggest.RecordQuery(c.Request.Context(), query); err != nil {
+		logrus.WithError(err).WithField("query", query).Warn("search: failed to record query popularity")
+	}
+
+	if hits, err := h.engine.Search(c.Request.Context(), query, limit); err == nil {
+		h.recordQueryLog(c, query, len(hits), "search_engine")
+		c.JSON(http.StatusOK, gin.H{"results": hits, "facets": facets, "source": "search_engine"})
+		return
+	}
+
+	products, err := h.products.SearchFTS(c.Request.Context(), query, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "search failed"})
+		return
+	}
+
+	source := "postgres_fallback"
+	if len(products) == 0 {
+		if fuzzy, err := h.products.SearchFuzzy(c.Request.Context(), query, limit); err == nil && len(fuzzy) > 0 {
+			products = fuzzy
+			source = "postgres_fuzzy"
+		}
+	}
+	h.recordQueryLog(c, query, len(products), source)
+	c.JSON(http.StatusOK, gin.H{"results": products, "facets": facets, "source": source})
+}
+
+// clickRequest is the body of POST /api/search/click.
+type clickRequest struct {
+	Query     string `json:"query" binding:"required"`
+	ProductID string `json:"product_id" binding:"required"`
+	Position  int    `json:"position"`
+}
+
+// Click records a searcher clicking through to a product from a search
+// results page, so the admin report can tell a query that returns plenty
+// of results but never gets clicked apart from one that's simply rare.
+func (h *SearchHandler) Click(c *gin.Context) {
+	var req clickRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query and product_id are required"})
+		return
+	}
+	productID, err := uuid.Parse(req.ProductID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product_id"})
+		return
+	}
+
+	log := &models.SearchClickLog{Query: req.Query, ProductID: productID, Position: req.Position}
+	if err := h.analytics.RecordClick(c.Request.Context(), log); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to record click"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Suggest returns autocomplete completions for the partial query q -
+// matching product names, matching categories, and popular past queries
+// with the same prefix - so the storefront search box can render all
+// three sections from a single fast request.
+func (h *SearchHandler) Suggest(c *gin.Context) {
+	prefix := c.Query("q")
+	if prefix == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'q' query parameter"})
+		return
+	}
+
+	suggestions, err := h.suggest.Suggest(c.Request.Context(), prefix)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "suggest failed"})
+		return
+	}
+	c.JSON(http.StatusOK, suggestions)
+}
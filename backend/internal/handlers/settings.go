@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// SettingsHandler exposes the public subset of the shop's runtime settings.
+type SettingsHandler struct {
+	settings *service.ShopSettingsService
+}
+
+// NewSettingsHandler builds a SettingsHandler with its dependencies.
+func NewSettingsHandler(settings *service.ShopSettingsService) *SettingsHandler {
+	return &SettingsHandler{settings: settings}
+}
+
+// Get returns the shop name, currency and support email. MaintenanceMode
+// and anything else added to ShopSettings later stays admin-only unless
+// explicitly added here.
+func (h *SettingsHandler) Get(c *gin.Context) {
+	settings, err := h.settings.Get(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load settings"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"name":          settings.Name,
+		"currency":      settings.Currency,
+		"support_email": settings.SupportEmail,
+	})
+}
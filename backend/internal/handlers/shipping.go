@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ShippingHandler prices delivery options for a cart before the customer
+// commits to an account or checkout.
+type ShippingHandler struct {
+	products         *psql.ProductRepository
+	methods          *psql.ShippingMethodRepository
+	freeShippingOver float64
+}
+
+// NewShippingHandler builds a ShippingHandler with its dependencies.
+// freeShippingOver mirrors the flat-rate policy from ShippingConfig: a
+// quoted subtotal at or above it zeroes every option's price.
+func NewShippingHandler(products *psql.ProductRepository, methods *psql.ShippingMethodRepository, freeShippingOver float64) *ShippingHandler {
+	return &ShippingHandler{products: products, methods: methods, freeShippingOver: freeShippingOver}
+}
+
+// QuoteItem is one product/quantity line in a QuoteRequest.
+type QuoteItem struct {
+	ProductID string `json:"product_id" binding:"required"`
+	Quantity  int    `json:"quantity" binding:"required,gt=0"`
+}
+
+// QuoteRequest is the payload accepted by Quote. Items and Region are taken
+// directly from the request rather than a server-side cart, so a guest can
+// get a quote before signing in.
+type QuoteRequest struct {
+	Items   []QuoteItem `json:"items" binding:"required,min=1"`
+	Country string      `json:"country"`
+	Region  string      `json:"region"`
+	City    string      `json:"city"`
+}
+
+// QuoteOption is one priced shipping method in a Quote response.
+type QuoteOption struct {
+	ID      uuid.UUID `json:"id"`
+	Name    string    `json:"name"`
+	Carrier string    `json:"carrier"`
+	Price   float64   `json:"price"`
+}
+
+// Quote prices every shipping method available for the given cart contents
+// and destination region.
+func (h *ShippingHandler) Quote(c *gin.Context) {
+	var req QuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	subtotal := 0.0
+	weightGrams := 0
+	for _, item := range req.Items {
+		product, err := h.products.GetByID(c.Request.Context(), item.ProductID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "unknown product: " + item.ProductID})
+			return
+		}
+		subtotal += product.DiscountedPrice() * float64(item.Quantity)
+		weightGrams += product.WeightGrams * item.Quantity
+	}
+
+	methods, err := h.methods.ListEnabled(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load shipping methods"})
+		return
+	}
+
+	freeShipping := h.freeShippingOver > 0 && subtotal >= h.freeShippingOver
+	options := make([]QuoteOption, 0, len(methods))
+	for _, method := range methods {
+		if !method.AvailableIn(req.Country, req.Region, req.City) {
+			continue
+		}
+		price := method.PriceFor(weightGrams, subtotal) + method.ZoneSurcharge(req.Country, req.Region, req.City)
+		if freeShipping {
+			price = 0
+		}
+		options = append(options, QuoteOption{ID: method.ID, Name: method.Name, Carrier: method.Carrier, Price: price})
+	}
+
+	if len(options) == 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "no shipping methods serve this destination"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"subtotal":                subtotal,
+		"free_shipping_threshold": h.freeShippingOver,
+		"free_shipping":           freeShipping,
+		"options":                 options,
+	})
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strconv"
+
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+// SitemapHandler serves an XML sitemap of indexable products and categories.
+type SitemapHandler struct {
+	products   *psql.ProductRepository
+	categories *psql.CategoryRepository
+	baseURL    string
+}
+
+// NewSitemapHandler builds a SitemapHandler with its dependencies. baseURL is
+// the public storefront origin URLs are built against (e.g. "https://shop.example.com").
+func NewSitemapHandler(products *psql.ProductRepository, categories *psql.CategoryRepository, baseURL string) *SitemapHandler {
+	return &SitemapHandler{products: products, categories: categories, baseURL: baseURL}
+}
+
+type sitemapURL struct {
+	Loc string `xml:"loc"`
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// Serve writes the sitemap.xml body, omitting any product or category
+// flagged non-indexable.
+func (h *SitemapHandler) Serve(c *gin.Context) {
+	products, err := h.products.ListIndexable(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build sitemap"})
+		return
+	}
+	categories, err := h.categories.ListIndexable(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build sitemap"})
+		return
+	}
+
+	set := sitemapURLSet{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	for _, p := range products {
+		set.URLs = append(set.URLs, sitemapURL{Loc: h.baseURL + "/products/" + p.ID.String()})
+	}
+	for _, cat := range categories {
+		set.URLs = append(set.URLs, sitemapURL{Loc: h.baseURL + "/categories/" + strconv.FormatUint(uint64(cat.ID), 10)})
+	}
+
+	c.Header("Content-Type", "application/xml")
+	c.XML(http.StatusOK, set)
+}
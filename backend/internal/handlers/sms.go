@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+// SMSHandler receives delivery-status callbacks from the SMS provider.
+type SMSHandler struct {
+	logs *psql.SMSLogRepository
+}
+
+// NewSMSHandler builds an SMSHandler with its dependencies.
+func NewSMSHandler(logs *psql.SMSLogRepository) *SMSHandler {
+	return &SMSHandler{logs: logs}
+}
+
+// smsDeliveredStatuses and smsFailedStatuses translate Twilio's own
+// MessageStatus values (see
+// https://www.twilio.com/docs/sms/api/message-resource#message-status-values)
+// onto this codebase's SMSLog.Status values. Every other status ("queued",
+// "sent" and similar) is an intermediate state the callback doesn't need
+// to act on.
+var (
+	smsDeliveredStatuses = map[string]bool{"delivered": true}
+	smsFailedStatuses    = map[string]bool{"failed": true, "undelivered": true}
+)
+
+// Callback applies a provider delivery-status update to the matching
+// SMSLog entry. It's posted form-encoded, matching Twilio's own webhook
+// format; a status the callback doesn't recognize is acknowledged and
+// ignored rather than treated as an error, since providers add new
+// intermediate statuses over time.
+func (h *SMSHandler) Callback(c *gin.Context) {
+	messageSID := c.PostForm("MessageSid")
+	status := c.PostForm("MessageStatus")
+	if messageSID == "" || status == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing MessageSid or MessageStatus"})
+		return
+	}
+
+	var newStatus string
+	var deliveredDate *time.Time
+	switch {
+	case smsDeliveredStatuses[status]:
+		newStatus = models.SMSStatusDelivered
+		now := time.Now()
+		deliveredDate = &now
+	case smsFailedStatuses[status]:
+		newStatus = models.SMSStatusFailed
+	default:
+		c.Status(http.StatusOK)
+		return
+	}
+
+	if err := h.logs.UpdateStatus(c.Request.Context(), messageSID, newStatus, deliveredDate); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update sms log"})
+		return
+	}
+	c.Status(http.StatusOK)
+}
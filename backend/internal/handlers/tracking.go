@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+// TrackingHandler exposes an order's shipment tracking history to the
+// customer who placed it.
+type TrackingHandler struct {
+	orders    *psql.OrderRepository
+	shipments *psql.ShipmentRepository
+}
+
+// NewTrackingHandler builds a TrackingHandler with its dependencies.
+func NewTrackingHandler(orders *psql.OrderRepository, shipments *psql.ShipmentRepository) *TrackingHandler {
+	return &TrackingHandler{orders: orders, shipments: shipments}
+}
+
+// Tracking returns the shipment and event history registered against an
+// order, scoped to the caller.
+func (h *TrackingHandler) Tracking(c *gin.Context) {
+	userID, _ := c.Get(middleware.ContextUserID)
+
+	order, err := h.orders.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "order not found"})
+		return
+	}
+	if order.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "order belongs to another user"})
+		return
+	}
+
+	shipment, err := h.shipments.GetByOrderID(c.Request.Context(), order.ID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no shipment registered for this order"})
+		return
+	}
+	c.JSON(http.StatusOK, shipment)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/YoungGoofy/shopping/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// unsubscribeColumns maps the "kind" embedded in an unsubscribe link to the
+// User column it turns off.
+var unsubscribeColumns = map[string]string{
+	"order_updates": "email_order_updates",
+	"marketing":     "email_marketing",
+	"sms":           "sms_notifications",
+}
+
+// UnsubscribeHandler lets the recipient of a notification opt out of that
+// notification kind via a signed link, without having to sign in.
+type UnsubscribeHandler struct {
+	userCache *service.UserCacheService
+	jwtSecret string
+}
+
+// NewUnsubscribeHandler builds an UnsubscribeHandler with its dependencies.
+func NewUnsubscribeHandler(userCache *service.UserCacheService, jwtSecret string) *UnsubscribeHandler {
+	return &UnsubscribeHandler{userCache: userCache, jwtSecret: jwtSecret}
+}
+
+// Unsubscribe validates the token in the query string and turns off the
+// preference it names.
+func (h *UnsubscribeHandler) Unsubscribe(c *gin.Context) {
+	claims, err := utils.ParseUnsubscribeToken(h.jwtSecret, c.Query("token"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or expired unsubscribe link"})
+		return
+	}
+
+	column, ok := unsubscribeColumns[claims.Kind]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown preference"})
+		return
+	}
+
+	if err := h.userCache.SetPreferenceColumn(c.Request.Context(), claims.UserID, column, false); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update preference"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"unsubscribed": claims.Kind})
+}
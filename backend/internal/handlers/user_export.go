@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/queue"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// UserExportHandler lets a customer request and retrieve a personal-data
+// export archive.
+type UserExportHandler struct {
+	export *service.UserExportService
+	jobs   *queue.Queue
+}
+
+// NewUserExportHandler builds a UserExportHandler with its dependencies.
+func NewUserExportHandler(export *service.UserExportService, jobs *queue.Queue) *UserExportHandler {
+	return &UserExportHandler{export: export, jobs: jobs}
+}
+
+// Export kicks off an asynchronous export of the caller's profile,
+// addresses, orders and reviews, returning a job ID to poll. There is no
+// mailer in this codebase yet, so the archive is fetched from the status
+// endpoint once ready rather than emailed.
+func (h *UserExportHandler) Export(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	// The job outlives this request, so it gets its own context rather than
+	// c.Request.Context(), which is cancelled as soon as we respond below.
+	jobID := h.jobs.Enqueue(func() (interface{}, error) {
+		return h.export.Build(context.Background(), userID)
+	})
+
+	c.JSON(http.StatusAccepted, gin.H{"job_id": jobID})
+}
+
+// ExportStatus returns the status of a previously submitted export job.
+func (h *UserExportHandler) ExportStatus(c *gin.Context) {
+	job, ok := h.jobs.Get(c.Param("jobID"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+// ExportDownload streams the finished archive for a completed export job.
+func (h *UserExportHandler) ExportDownload(c *gin.Context) {
+	job, ok := h.jobs.Get(c.Param("jobID"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+		return
+	}
+	if job.Status != queue.StatusDone {
+		c.JSON(http.StatusConflict, gin.H{"error": "export is not ready yet"})
+		return
+	}
+
+	archive, ok := job.Result.(*service.UserExportArchive)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "export result is invalid"})
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=\""+archive.Filename+"\"")
+	c.Data(http.StatusOK, "application/zip", archive.Data)
+}
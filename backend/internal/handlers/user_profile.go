@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/redis"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/YoungGoofy/shopping/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// UserProfileHandler lets a customer view and edit their own account
+// profile.
+type UserProfileHandler struct {
+	tokens    *redis.TokenRepository
+	media     *service.MediaService
+	userCache *service.UserCacheService
+}
+
+// NewUserProfileHandler builds a UserProfileHandler with its dependencies.
+func NewUserProfileHandler(tokens *redis.TokenRepository, media *service.MediaService, userCache *service.UserCacheService) *UserProfileHandler {
+	return &UserProfileHandler{tokens: tokens, media: media, userCache: userCache}
+}
+
+// UserProfileRequest is the payload accepted by Update.
+type UserProfileRequest struct {
+	FirstName  string `json:"first_name" binding:"required"`
+	LastName   string `json:"last_name" binding:"required"`
+	Country    string `json:"country" binding:"required"`
+	Phone      string `json:"phone" binding:"required"`
+	City       string `json:"city"`
+	Street     string `json:"street"`
+	Building   string `json:"building"`
+	Apartment  string `json:"apartment"`
+	PostalCode string `json:"postal_code"`
+}
+
+// Get returns the caller's own profile.
+func (h *UserProfileHandler) Get(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	user, err := h.userCache.GetByID(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// Update saves changes to the caller's own profile. The token cached in
+// Redis is keyed by user ID rather than by profile contents, so a profile
+// edit has nothing there to invalidate.
+func (h *UserProfileHandler) Update(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	user, err := h.userCache.GetByID(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
+
+	var req UserProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	phone := utils.NormalizePhone(req.Phone)
+	if !utils.IsValidE164(phone) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "phone must be a valid E.164 number, e.g. +14155552671"})
+		return
+	}
+
+	// The address is optional overall, but once any component is given the
+	// rest are required so shipping integrations never see a half-filled
+	// one.
+	addressGiven := req.City != "" || req.Street != "" || req.Building != "" || req.PostalCode != ""
+	if addressGiven {
+		if req.City == "" || req.Street == "" || req.Building == "" || req.PostalCode == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "city, street, building and postal_code are all required together"})
+			return
+		}
+		if !utils.IsValidPostalCode(req.Country, req.PostalCode) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "postal_code is not valid for country " + req.Country})
+			return
+		}
+	}
+
+	user.FirstName = req.FirstName
+	user.LastName = req.LastName
+	user.Country = req.Country
+	user.Phone = phone
+	user.City = req.City
+	user.Street = req.Street
+	user.Building = req.Building
+	user.Apartment = req.Apartment
+	user.PostalCode = req.PostalCode
+
+	if err := h.userCache.UpdateProfile(c.Request.Context(), user); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update profile"})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// PreferencesRequest is the payload accepted by UpdatePreferences.
+type PreferencesRequest struct {
+	EmailOrderUpdates bool `json:"email_order_updates"`
+	EmailMarketing    bool `json:"email_marketing"`
+	SMSNotifications  bool `json:"sms_notifications"`
+	PushNotifications bool `json:"push_notifications"`
+}
+
+// UpdatePreferences saves the caller's communication preferences. The
+// mailer and SMS subsystems don't gate sends on these flags yet, but
+// service.PushNotificationService does check PushNotifications before
+// pushing an order-status or back-in-stock update.
+func (h *UserProfileHandler) UpdatePreferences(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	var req PreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user := &models.User{
+		ID:                userID,
+		EmailOrderUpdates: req.EmailOrderUpdates,
+		EmailMarketing:    req.EmailMarketing,
+		SMSNotifications:  req.SMSNotifications,
+		PushNotifications: req.PushNotifications,
+	}
+	if err := h.userCache.UpdatePreferences(c.Request.Context(), user); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update preferences"})
+		return
+	}
+	c.JSON(http.StatusOK, req)
+}
+
+// UploadAvatar assigns a new avatar to the caller's account.
+func (h *UserProfileHandler) UploadAvatar(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	fh, err := c.FormFile("avatar")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'avatar' file field"})
+		return
+	}
+
+	avatarURL, err := h.media.AssignAvatar(fh)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.userCache.UpdateAvatar(c.Request.Context(), userID, avatarURL); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save avatar"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"avatar_url": avatarURL})
+}
+
+// RemoveAvatar clears the caller's avatar.
+func (h *UserProfileHandler) RemoveAvatar(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	if err := h.userCache.UpdateAvatar(c.Request.Context(), userID, ""); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove avatar"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteAccount anonymizes the caller's own account and revokes their
+// session. The row itself, along with any orders and reviews it's tied to,
+// is kept - only the PII on the user row is scrubbed.
+func (h *UserProfileHandler) DeleteAccount(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+
+	if err := h.userCache.Anonymize(c.Request.Context(), userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete account"})
+		return
+	}
+	if err := h.tokens.Revoke(c.Request.Context(), userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "account deleted but failed to revoke existing session"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+)
+
+// favoriteCategoriesLimit caps how many categories UserStatsHandler.Get
+// ranks, so a customer with a long history doesn't get a huge response.
+const favoriteCategoriesLimit = 5
+
+// UserStatsHandler serves the aggregated stats shown on a customer's
+// account page.
+type UserStatsHandler struct {
+	orders  *psql.OrderRepository
+	refunds *psql.RefundRepository
+}
+
+// NewUserStatsHandler builds a UserStatsHandler with its dependencies.
+func NewUserStatsHandler(orders *psql.OrderRepository, refunds *psql.RefundRepository) *UserStatsHandler {
+	return &UserStatsHandler{orders: orders, refunds: refunds}
+}
+
+// UserStats is the response returned by Get.
+type UserStats struct {
+	OrderCount           int                     `json:"order_count"`
+	TotalSpent           float64                 `json:"total_spent"`
+	FavoriteCategories   []psql.FavoriteCategory `json:"favorite_categories"`
+	ActiveReturnRequests int64                   `json:"active_return_requests"`
+}
+
+// Get returns the caller's order count, total spend, favorite categories
+// and active return requests in one response.
+func (h *UserStatsHandler) Get(c *gin.Context) {
+	userID := c.MustGet(middleware.ContextUserID).(uint)
+	ctx := c.Request.Context()
+
+	orderStats, err := h.orders.Stats(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load stats"})
+		return
+	}
+
+	favorites, err := h.orders.FavoriteCategories(ctx, userID, favoriteCategoriesLimit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load stats"})
+		return
+	}
+
+	pendingReturns, err := h.refunds.CountPendingByUser(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load stats"})
+		return
+	}
+
+	c.JSON(http.StatusOK, UserStats{
+		OrderCount:           orderStats.OrderCount,
+		TotalSpent:           orderStats.TotalSpent,
+		FavoriteCategories:   favorites,
+		ActiveReturnRequests: pendingReturns,
+	})
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// WishlistHandler exposes the wishlist API.
+type WishlistHandler struct {
+	wishlist *psql.WishlistRepository
+	share    *psql.WishlistShareRepository
+	cart     *psql.CartRepository
+}
+
+// NewWishlistHandler builds a WishlistHandler with its dependencies.
+func NewWishlistHandler(wishlist *psql.WishlistRepository, share *psql.WishlistShareRepository, cart *psql.CartRepository) *WishlistHandler {
+	return &WishlistHandler{wishlist: wishlist, share: share, cart: cart}
+}
+
+// List returns the authenticated user's wishlist.
+func (h *WishlistHandler) List(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	items, err := h.wishlist.ListByUser(c.Request.Context(), uid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load wishlist"})
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+// AddToWishlistRequest is the payload accepted by Add.
+type AddToWishlistRequest struct {
+	ProductID string `json:"product_id" binding:"required,uuid"`
+}
+
+// Add puts a product onto the authenticated user's wishlist.
+func (h *WishlistHandler) Add(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	var req AddToWishlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	productID, err := uuid.Parse(req.ProductID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product_id"})
+		return
+	}
+
+	item, err := h.wishlist.Add(c.Request.Context(), uid, productID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add to wishlist"})
+		return
+	}
+	c.JSON(http.StatusCreated, item)
+}
+
+// Remove deletes a wishlist item.
+func (h *WishlistHandler) Remove(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	if err := h.wishlist.Remove(c.Request.Context(), uid, c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove wishlist item"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// MoveToCart moves a wishlist item straight into the authenticated user's
+// cart, defaulting to size "" and quantity 1 since a wishlist entry does not
+// capture either.
+func (h *WishlistHandler) MoveToCart(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	item, err := h.wishlist.GetByID(c.Request.Context(), uid, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "wishlist item not found"})
+		return
+	}
+
+	if err := h.cart.AddOrIncrement(c.Request.Context(), uid, item.ProductID, "", 1, item.Product.DiscountedPrice()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add to cart"})
+		return
+	}
+	if err := h.wishlist.Remove(c.Request.Context(), uid, c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove wishlist item"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// EnableShare publishes a read-only link to the authenticated user's
+// wishlist and returns its token.
+func (h *WishlistHandler) EnableShare(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	token := uuid.New().String()
+	if err := h.share.Enable(c.Request.Context(), uid, token); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to enable sharing"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"token": token})
+}
+
+// DisableShare revokes any published link to the authenticated user's wishlist.
+func (h *WishlistHandler) DisableShare(c *gin.Context) {
+	uid := c.MustGet(middleware.ContextUserID).(uint)
+
+	if err := h.share.Disable(c.Request.Context(), uid); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to disable sharing"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Public returns a shared wishlist by its token, with no authentication
+// required.
+func (h *WishlistHandler) Public(c *gin.Context) {
+	share, err := h.share.GetByToken(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "shared wishlist not found"})
+		return
+	}
+
+	items, err := h.wishlist.ListByUser(c.Request.Context(), share.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load wishlist"})
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
@@ -0,0 +1,83 @@
+// Package i18n picks a locale from a request's Accept-Language header and
+// translates the small, stable set of strings this API owns directly
+// (error codes, standard notifications). Translatable product/category
+// content lives in the database instead - see ProductTranslation and
+// CategoryTranslation in internal/models - since that's authored content,
+// not a message catalog.
+package i18n
+
+import "strings"
+
+const (
+	LocaleEN = "en"
+	LocaleRU = "ru"
+
+	// DefaultLocale is used when the request has no Accept-Language header,
+	// or names a locale this API doesn't have translations for.
+	DefaultLocale = LocaleEN
+)
+
+// Supported lists every locale this API has translations for.
+var Supported = []string{LocaleEN, LocaleRU}
+
+// messages maps a message key (an apperr.Code, most often) to its
+// translation per locale.
+var messages = map[string]map[string]string{
+	"not_found": {
+		LocaleEN: "not found",
+		LocaleRU: "не найдено",
+	},
+	"conflict": {
+		LocaleEN: "conflict",
+		LocaleRU: "конфликт",
+	},
+	"insufficient_stock": {
+		LocaleEN: "insufficient stock",
+		LocaleRU: "недостаточно товара на складе",
+	},
+	"internal_error": {
+		LocaleEN: "internal server error",
+		LocaleRU: "внутренняя ошибка сервера",
+	},
+}
+
+// T translates key into locale, falling back to DefaultLocale and then to
+// key itself if no translation is registered.
+func T(locale, key string) string {
+	translations, ok := messages[key]
+	if !ok {
+		return key
+	}
+	if msg, ok := translations[locale]; ok {
+		return msg
+	}
+	if msg, ok := translations[DefaultLocale]; ok {
+		return msg
+	}
+	return key
+}
+
+// IsSupported reports whether locale has translations registered.
+func IsSupported(locale string) bool {
+	for _, s := range Supported {
+		if s == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseAcceptLanguage picks the first locale in header (RFC 7231's
+// comma-separated, q-weighted list) that this API supports, ignoring the
+// q-values themselves - the header is already sent in preference order, so
+// the first supported match is the best one. Falls back to DefaultLocale.
+func ParseAcceptLanguage(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		tag := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		lang := strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+		if IsSupported(lang) {
+			return lang
+		}
+	}
+	return DefaultLocale
+}
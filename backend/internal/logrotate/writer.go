@@ -0,0 +1,141 @@
+// Package logrotate provides a minimal size- and age-based rotating file
+// writer, since this module doesn't vendor an external rotation library
+// (lumberjack et al.) and pulling one in isn't possible without network
+// access to fetch it.
+package logrotate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Writer is an io.Writer that appends to a file, rotating it once it
+// reaches maxSizeBytes and pruning rotated files beyond maxBackups or
+// older than maxAge.
+type Writer struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	maxAge     time.Duration
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// New opens (creating if necessary) the file at path for appending.
+// maxSizeMB is the size at which it rotates; maxBackups and maxAgeDays
+// bound how many rotated files are kept (0 means unbounded for that
+// dimension).
+func New(path string, maxSizeMB, maxBackups, maxAgeDays int) (*Writer, error) {
+	w := &Writer{
+		path:       path,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		maxBackups: maxBackups,
+		maxAge:     time.Duration(maxAgeDays) * 24 * time.Hour,
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *Writer) open() error {
+	f, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("logrotate: open %s: %w", w.path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("logrotate: stat %s: %w", w.path, err)
+	}
+	w.file = f
+	w.size = info.Size()
+	return nil
+}
+
+// Write appends p to the current file, rotating first if it would push the
+// file past maxSize.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.maxSize > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *Writer) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("logrotate: close %s: %w", w.path, err)
+	}
+
+	rotated := fmt.Sprintf("%s.%s", w.path, time.Now().UTC().Format("20060102T150405.000000000"))
+	if err := os.Rename(w.path, rotated); err != nil {
+		return fmt.Errorf("logrotate: rotate %s: %w", w.path, err)
+	}
+
+	if err := w.open(); err != nil {
+		return err
+	}
+	w.prune()
+	return nil
+}
+
+// prune deletes rotated files beyond maxBackups (oldest first) and any
+// older than maxAge. Errors are ignored - a failed cleanup pass shouldn't
+// take down logging.
+func (w *Writer) prune() {
+	dir := filepath.Dir(w.path)
+	prefix := filepath.Base(w.path) + "."
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	var backups []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), prefix) {
+			backups = append(backups, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(backups) // the timestamp suffix sorts lexically in chronological order
+
+	if w.maxAge > 0 {
+		cutoff := time.Now().Add(-w.maxAge)
+		kept := backups[:0]
+		for _, b := range backups {
+			if info, err := os.Stat(b); err == nil && info.ModTime().Before(cutoff) {
+				os.Remove(b)
+				continue
+			}
+			kept = append(kept, b)
+		}
+		backups = kept
+	}
+
+	if w.maxBackups > 0 && len(backups) > w.maxBackups {
+		for _, b := range backups[:len(backups)-w.maxBackups] {
+			os.Remove(b)
+		}
+	}
+}
+
+// Close closes the underlying file.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
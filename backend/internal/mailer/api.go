@@ -0,0 +1,85 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/breaker"
+)
+
+// apiBreakerFailureThreshold and apiBreakerCooldown match the other
+// external-dependency breakers in this codebase (search.MeilisearchEngine,
+// TokenRepository); there's no traffic-derived tuning behind them.
+const (
+	apiBreakerFailureThreshold = 5
+	apiBreakerCooldown         = 30 * time.Second
+)
+
+// APIProvider sends mail through a transactional email API (Postmark,
+// SendGrid and similar all accept roughly this shape: an endpoint, a
+// bearer-style API key, and a JSON body naming from/to/subject/html/text).
+// It exists so a deployment can swap SMTP for a hosted provider without
+// EmailService or its callers changing.
+type APIProvider struct {
+	host    string
+	apiKey  string
+	client  *http.Client
+	breaker *breaker.Breaker
+}
+
+// NewAPIProvider builds an APIProvider talking to host (e.g.
+// "https://api.postmarkapp.com"), authenticating with apiKey.
+func NewAPIProvider(host, apiKey string) *APIProvider {
+	return &APIProvider{
+		host:    host,
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		breaker: breaker.New(apiBreakerFailureThreshold, apiBreakerCooldown),
+	}
+}
+
+type apiSendRequest struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	HTML    string `json:"html_body"`
+	Text    string `json:"text_body"`
+}
+
+// Send delivers msg through the configured API, guarded by a circuit
+// breaker so a struggling provider fails fast instead of blocking every
+// send on its timeout.
+func (p *APIProvider) Send(ctx context.Context, msg Message) error {
+	if !p.breaker.Allow() {
+		return fmt.Errorf("mailer: circuit open, email API unavailable")
+	}
+
+	body, err := json.Marshal(apiSendRequest{From: msg.From, To: msg.To, Subject: msg.Subject, HTML: msg.HTML, Text: msg.Text})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.host+"/email", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		p.breaker.Failure()
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		p.breaker.Failure()
+		return fmt.Errorf("mailer: email API responded with status %d", resp.StatusCode)
+	}
+	p.breaker.Success()
+	return nil
+}
@@ -0,0 +1,24 @@
+// Package mailer defines the provider-agnostic interface for sending a
+// rendered email, plus concrete SMTP and HTTP-API-backed implementations.
+// It has no opinion on retrying or logging deliveries - that's
+// service.EmailService's job, layered on top.
+package mailer
+
+import "context"
+
+// Message is a fully-rendered email ready to hand to a Provider.
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// Provider is implemented by an email delivery backend.
+type Provider interface {
+	// Send delivers msg. A returned error is assumed transient (network
+	// blip, provider hiccup) unless the caller knows otherwise - callers
+	// that want to retry should do so above this interface.
+	Send(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,66 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+// SMTPProvider sends mail through a standard SMTP relay, authenticating
+// with PLAIN auth when a username is configured.
+type SMTPProvider struct {
+	host     string
+	port     int
+	username string
+	password string
+}
+
+// NewSMTPProvider builds an SMTPProvider talking to host:port. username and
+// password may be empty for a relay that doesn't require auth (e.g. a
+// local Postfix instance).
+func NewSMTPProvider(host string, port int, username, password string) *SMTPProvider {
+	return &SMTPProvider{host: host, port: port, username: username, password: password}
+}
+
+// Send delivers msg over SMTP. ctx is not honored mid-dial - net/smtp has
+// no context support - but callers still pass it for a consistent
+// Provider signature and so a future switch to a context-aware client is
+// a one-line change.
+func (p *SMTPProvider) Send(ctx context.Context, msg Message) error {
+	addr := fmt.Sprintf("%s:%d", p.host, p.port)
+
+	var auth smtp.Auth
+	if p.username != "" {
+		auth = smtp.PlainAuth("", p.username, p.password, p.host)
+	}
+
+	return smtp.SendMail(addr, auth, msg.From, []string{msg.To}, buildMIMEMessage(msg))
+}
+
+// buildMIMEMessage renders msg as a multipart/alternative email, so a
+// recipient's client can pick the HTML part and fall back to the text part
+// if it can't render HTML.
+func buildMIMEMessage(msg Message) []byte {
+	const boundary = "shopping-mailer-boundary"
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", msg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&b, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+
+	fmt.Fprintf(&b, "--%s\r\n", boundary)
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(msg.Text)
+	b.WriteString("\r\n\r\n")
+
+	fmt.Fprintf(&b, "--%s\r\n", boundary)
+	b.WriteString("Content-Type: text/html; charset=UTF-8\r\n\r\n")
+	b.WriteString(msg.HTML)
+	b.WriteString("\r\n\r\n")
+
+	fmt.Fprintf(&b, "--%s--\r\n", boundary)
+	return []byte(b.String())
+}
@@ -0,0 +1,60 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// Rendered is one template's output, ready to drop into a Message.
+type Rendered struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// Renderer renders a named template's subject/HTML/text parts from data.
+// Every template also receives ShopName and SupportEmail from the caller
+// (EmailService fills these in from ShopSettings), so a template can sign
+// off as "The <ShopName> Team" or point a reader at support without every
+// call site having to pass them explicitly.
+type Renderer struct {
+	html    *htmltemplate.Template
+	text    *texttemplate.Template
+	subject *texttemplate.Template
+}
+
+// NewRenderer parses every embedded template. It panics on a parse error,
+// same as every other embedded-asset loader in this codebase (see
+// internal/migrate's SQL embed) - a broken template is a build-time bug,
+// not a runtime condition to recover from.
+func NewRenderer() *Renderer {
+	return &Renderer{
+		html:    htmltemplate.Must(htmltemplate.ParseFS(templateFS, "templates/*.html.tmpl")),
+		text:    texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/*.txt.tmpl")),
+		subject: texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/*.subject.tmpl")),
+	}
+}
+
+// Render renders name's subject, HTML and text parts against data. name is
+// the template's base filename, e.g. "verification" for
+// verification.html.tmpl/verification.txt.tmpl/verification.subject.tmpl.
+func (r *Renderer) Render(name string, data interface{}) (Rendered, error) {
+	var subject, html, text bytes.Buffer
+
+	if err := r.subject.ExecuteTemplate(&subject, name+".subject.tmpl", data); err != nil {
+		return Rendered{}, err
+	}
+	if err := r.html.ExecuteTemplate(&html, name+".html.tmpl", data); err != nil {
+		return Rendered{}, err
+	}
+	if err := r.text.ExecuteTemplate(&text, name+".txt.tmpl", data); err != nil {
+		return Rendered{}, err
+	}
+
+	return Rendered{Subject: subject.String(), HTML: html.String(), Text: text.String()}, nil
+}
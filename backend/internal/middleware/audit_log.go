@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditLogWriter is the write access AuditLog needs. It's satisfied by
+// *psql.AuditLogRepository; the interface is declared here, not imported
+// from repository/psql, so this package doesn't have to depend on it -
+// repository/psql already depends on apperr, which depends on middleware.
+type AuditLogWriter interface {
+	Create(ctx context.Context, log *models.AuditLog) error
+}
+
+// AuditLog records every mutating admin request (POST/PUT/PATCH/DELETE)
+// that completes without a client or server error: the actor, an action
+// string (method and route pattern), the :id path param if the route has
+// one, the request body as submitted, and the caller's IP. It doesn't
+// compute a before/after diff - that needs the entity's prior state, which
+// only the handler issuing the write actually has fetched - so Payload is
+// the request as sent, not a diff against what changed.
+func AuditLog(auditLogs AuditLogWriter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !isMutatingMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			body, _ = io.ReadAll(c.Request.Body)
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		c.Next()
+
+		if c.Writer.Status() >= http.StatusBadRequest {
+			return
+		}
+
+		entry := &models.AuditLog{
+			Action:     c.Request.Method + " " + c.FullPath(),
+			EntityType: entityTypeFromAdminPath(c.FullPath()),
+			EntityID:   c.Param("id"),
+			Payload:    string(body),
+			IPAddress:  c.ClientIP(),
+		}
+		if actorID, ok := c.Get(ContextUserID); ok {
+			if id, ok := actorID.(uint); ok {
+				entry.ActorID = id
+			}
+		}
+
+		if err := auditLogs.Create(c.Request.Context(), entry); err != nil {
+			Logger(c).WithError(err).Error("failed to record audit log entry")
+		}
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// entityTypeFromAdminPath extracts the resource segment right after admin/,
+// e.g. "/api/v1/admin/orders/:id/status" or "/api/admin/orders/:id/status"
+// -> "orders". Routes are registered under both /api/v1 and the unversioned
+// /api compatibility alias, so either prefix must resolve the same way.
+func entityTypeFromAdminPath(path string) string {
+	const marker = "/admin/"
+	i := strings.Index(path, marker)
+	if i < 0 {
+		return ""
+	}
+	rest := path[i+len(marker):]
+	if j := strings.Index(rest, "/"); j >= 0 {
+		return rest[:j]
+	}
+	return rest
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/YoungGoofy/shopping/internal/config"
+	"github.com/gin-gonic/gin"
+)
+
+// CORS answers cross-origin requests according to cfg: it echoes back the
+// request's Origin if it's on the allowlist (or allows any origin with "*"),
+// sets the configured allowed methods/headers, and short-circuits preflight
+// OPTIONS requests with a 204 and a cached Access-Control-Max-Age. cfg.
+// AllowedOrigins is expected to have already been defaulted by
+// config.Config.Validate.
+func CORS(cfg config.CORSConfig) gin.HandlerFunc {
+	allowAny := false
+	origins := make(map[string]struct{}, len(cfg.AllowedOrigins))
+	for _, o := range cfg.AllowedOrigins {
+		if o == "*" {
+			allowAny = true
+			continue
+		}
+		origins[o] = struct{}{}
+	}
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+	maxAge := strconv.Itoa(cfg.MaxAgeSeconds)
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		_, allowed := origins[origin]
+		if !allowed && !allowAny {
+			c.Next()
+			return
+		}
+
+		if allowAny && !cfg.AllowCredentials {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Header("Vary", "Origin")
+		}
+		if cfg.AllowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+
+		if c.Request.Method == http.MethodOptions {
+			c.Header("Access-Control-Allow-Methods", methods)
+			c.Header("Access-Control-Allow-Headers", headers)
+			c.Header("Access-Control-Max-Age", maxAge)
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
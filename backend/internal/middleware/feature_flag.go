@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeatureFlagChecker is the flag evaluation FeatureEnabled/RequireFeature
+// need. It's satisfied by *service.FeatureFlagService; the interface is
+// declared here, not imported from service, so this package doesn't have
+// to depend on it - service already depends on repository/psql, which
+// depends on apperr, which depends on middleware.
+type FeatureFlagChecker interface {
+	IsEnabled(ctx context.Context, key string, userID uint) bool
+}
+
+// FeatureEnabled evaluates key for the request's authenticated user (0 for
+// a guest), for handlers that need to branch on a flag rather than gate
+// the whole route on it. It must run after Auth/OptionalAuth if the flag's
+// rollout should key off the real user rather than always bucketing guests
+// together.
+func FeatureEnabled(c *gin.Context, flags FeatureFlagChecker, key string) bool {
+	var userID uint
+	if v, ok := c.Get(ContextUserID); ok {
+		userID = v.(uint)
+	}
+	return flags.IsEnabled(c.Request.Context(), key, userID)
+}
+
+// RequireFeature aborts the request with 404 unless key is enabled for the
+// caller, so a route for an unshipped feature doesn't even reveal it
+// exists to whoever it hasn't rolled out to.
+func RequireFeature(flags FeatureFlagChecker, key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !FeatureEnabled(c, flags, key) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "not found"})
+			return
+		}
+		c.Next()
+	}
+}
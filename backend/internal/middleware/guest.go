@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ContextGuestCookie is the gin.Context key holding the guest session cookie value.
+const ContextGuestCookie = "guestCookie"
+
+// GuestCookieName is the cookie used to identify an unauthenticated shopper's cart.
+const GuestCookieName = "guest_cart_id"
+
+// GuestCookie ensures every request carries a stable guest_cart_id cookie,
+// issuing one if it is missing, and exposes its value on the context so
+// handlers can key guest-scoped state (like the Redis cart) by it.
+func GuestCookie() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cookie, err := c.Cookie(GuestCookieName)
+		if err != nil || cookie == "" {
+			cookie = uuid.New().String()
+			c.SetCookie(GuestCookieName, cookie, int((30 * 24 * time.Hour).Seconds()), "/", "", false, true)
+		}
+		c.Set(ContextGuestCookie, cookie)
+		c.Next()
+	}
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// gzipCompressibleTypes lists the Content-Type prefixes worth compressing.
+// Images, video, and archives are already compressed, so gzipping them again
+// just burns CPU for no size benefit.
+var gzipCompressibleTypes = []string{
+	"application/json",
+	"application/javascript",
+	"application/xml",
+	"text/",
+	"image/svg+xml",
+}
+
+// gzipBufferedWriter buffers the response body instead of writing it straight
+// through, so Gzip can decide whether to compress once the full body (and its
+// size) is known, and set Content-Encoding/Content-Length before anything
+// reaches the client.
+type gzipBufferedWriter struct {
+	gin.ResponseWriter
+	buf    bytes.Buffer
+	status int
+}
+
+func (w *gzipBufferedWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *gzipBufferedWriter) Write(b []byte) (int, error) {
+	return w.buf.Write(b)
+}
+
+func (w *gzipBufferedWriter) WriteString(s string) (int, error) {
+	return w.buf.WriteString(s)
+}
+
+func (w *gzipBufferedWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// Gzip compresses response bodies with gzip when the client sends
+// "Accept-Encoding: gzip", the body is at least minSizeBytes, and its
+// Content-Type is one worth compressing (JSON, XML, plain text, SVG, JS -
+// this is what makes product listings and the API's swagger JSON, once that
+// route exists, cheaper to send over mobile connections). Smaller bodies are
+// left alone, since gzip's own overhead outweighs the savings on them.
+func Gzip(minSizeBytes int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// SSE responses (order status streaming, in particular) are written
+		// incrementally over a connection that's meant to stay open; buffering
+		// the whole thing here would hold every event back until the client
+		// disconnects, which defeats the point of streaming them.
+		if !strings.Contains(c.GetHeader("Accept-Encoding"), "gzip") || strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+			c.Next()
+			return
+		}
+
+		bw := &gzipBufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = bw
+		c.Next()
+
+		body := bw.buf.Bytes()
+		contentType := bw.Header().Get("Content-Type")
+		if len(body) < minSizeBytes || bw.Header().Get("Content-Encoding") != "" || !isGzipCompressible(contentType) {
+			bw.ResponseWriter.WriteHeader(bw.Status())
+			_, _ = bw.ResponseWriter.Write(body)
+			return
+		}
+
+		bw.Header().Set("Content-Encoding", "gzip")
+		bw.Header().Add("Vary", "Accept-Encoding")
+		bw.Header().Del("Content-Length")
+		bw.ResponseWriter.WriteHeader(bw.Status())
+
+		gz := gzip.NewWriter(bw.ResponseWriter)
+		_, _ = gz.Write(body)
+		_ = gz.Close()
+	}
+}
+
+func isGzipCompressible(contentType string) bool {
+	for _, prefix := range gzipCompressibleTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"github.com/YoungGoofy/shopping/internal/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+const localeKey = "locale"
+
+// Locale picks a locale from the request's Accept-Language header and
+// attaches it to c, so handlers and apperr can respond in it via
+// LocaleFrom instead of every caller parsing the header itself.
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(localeKey, i18n.ParseAcceptLanguage(c.GetHeader("Accept-Language")))
+		c.Next()
+	}
+}
+
+// LocaleFrom returns the locale Locale attached to c, or i18n.DefaultLocale
+// if the middleware wasn't installed.
+func LocaleFrom(c *gin.Context) string {
+	if v, ok := c.Get(localeKey); ok {
+		if locale, ok := v.(string); ok {
+			return locale
+		}
+	}
+	return i18n.DefaultLocale
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// maintenanceRetryAfterSeconds is sent as a Retry-After hint so a client
+// backs off for a while instead of hammering the shop while it's down.
+const maintenanceRetryAfterSeconds = "300"
+
+// ShopSettingsReader is the settings lookup Maintenance needs. It's
+// satisfied by *service.ShopSettingsService; the interface is declared
+// here, not imported from service, so this package doesn't have to depend
+// on it - service already depends on repository/psql, which depends on
+// apperr, which depends on middleware.
+type ShopSettingsReader interface {
+	Get(ctx context.Context) (*models.ShopSettings, error)
+}
+
+// Maintenance rejects requests with 503 while ShopSettings.MaintenanceMode
+// is on, letting /health and everything under /api/admin (versioned or via
+// the unversioned compatibility alias) through so the admin who needs to
+// flip it back off, and whatever's probing liveness, both keep working.
+func Maintenance(settings ShopSettingsReader) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		if path == "/healthz" || path == "/readyz" ||
+			strings.HasPrefix(path, "/api/admin") || strings.HasPrefix(path, "/api/v1/admin") {
+			c.Next()
+			return
+		}
+
+		current, err := settings.Get(c.Request.Context())
+		if err == nil && current.MaintenanceMode {
+			c.Header("Retry-After", maintenanceRetryAfterSeconds)
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"error":       "the shop is temporarily down for maintenance",
+				"retry_after": maintenanceRetryAfterSeconds,
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Recovery recovers from a panic in a later handler and logs it as an
+// Error-level entry through Logger(c) - with the request ID and, if the
+// request was authenticated, the user ID attached - instead of letting
+// gin's own recovery middleware handle it silently. Routing panics through
+// logrus this way means any hook attached to it, such as external error
+// reporting, sees them the same way it sees any other logged error.
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				entry := Logger(c)
+				if userID, ok := c.Get(ContextUserID); ok {
+					entry = entry.WithField("user_id", userID)
+				}
+				entry.WithField("panic", r).Error("panic recovered")
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			}
+		}()
+		c.Next()
+	}
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// RequestIDHeader is the header a request ID is read from and echoed back
+// on, so a client (or a proxy in front of us) can supply its own and have
+// it show up in our logs verbatim.
+const RequestIDHeader = "X-Request-ID"
+
+const requestIDKey = "request_id"
+const logEntryKey = "log"
+
+// RequestID attaches a per-request identifier to c - the incoming
+// X-Request-ID header if the caller supplied one, otherwise a fresh UUID -
+// and a logrus.Entry tagged with it, so a handler can log with Logger(c)
+// and get request_id attached automatically instead of threading it
+// through by hand. The ID is also echoed back in the response header so
+// support can correlate a user's report with the log lines it produced.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" {
+			id = uuid.New().String()
+		}
+		c.Set(requestIDKey, id)
+		c.Set(logEntryKey, logrus.WithField(requestIDKey, id))
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// RequestIDFrom returns the request ID RequestID attached to c, or "" if
+// the middleware wasn't installed.
+func RequestIDFrom(c *gin.Context) string {
+	if v, ok := c.Get(requestIDKey); ok {
+		if id, ok := v.(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// Logger returns the request-scoped log entry RequestID attached to c. If
+// the middleware wasn't installed - a handler invoked directly in a test,
+// say - it falls back to a bare entry rather than panicking.
+func Logger(c *gin.Context) *logrus.Entry {
+	if v, ok := c.Get(logEntryKey); ok {
+		if entry, ok := v.(*logrus.Entry); ok {
+			return entry
+		}
+	}
+	return logrus.NewEntry(logrus.StandardLogger())
+}
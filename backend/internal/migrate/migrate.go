@@ -0,0 +1,172 @@
+// Package migrate applies versioned SQL migrations embedded in the binary,
+// replacing the ad-hoc AutoMigrate call that used to run against whatever
+// the current model structs looked like on every boot.
+package migrate
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+// migration is one numbered schema change, split into its forward and (if
+// present) reverse SQL.
+type migration struct {
+	version int
+	name    string
+	up      string
+	down    string
+}
+
+// loadMigrations reads every embedded *.up.sql/*.down.sql pair and returns
+// them ordered oldest first.
+func loadMigrations() ([]migration, error) {
+	entries, err := fs.ReadDir(files, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("migrate: read embedded sql dir: %w", err)
+	}
+
+	byVersion := map[int]*migration{}
+	for _, entry := range entries {
+		version, name, direction, ok := parseFilename(entry.Name())
+		if !ok {
+			continue
+		}
+		content, err := files.ReadFile("sql/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("migrate: read %s: %w", entry.Name(), err)
+		}
+		m, exists := byVersion[version]
+		if !exists {
+			m = &migration{version: version, name: name}
+			byVersion[version] = m
+		}
+		if direction == "up" {
+			m.up = string(content)
+		} else {
+			m.down = string(content)
+		}
+	}
+
+	migrations := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+	return migrations, nil
+}
+
+// parseFilename splits "0001_initial_schema.up.sql" into (1,
+// "initial_schema", "up", true).
+func parseFilename(filename string) (version int, name string, direction string, ok bool) {
+	base := strings.TrimSuffix(filename, ".sql")
+	switch {
+	case strings.HasSuffix(base, ".up"):
+		direction = "up"
+		base = strings.TrimSuffix(base, ".up")
+	case strings.HasSuffix(base, ".down"):
+		direction = "down"
+		base = strings.TrimSuffix(base, ".down")
+	default:
+		return 0, "", "", false
+	}
+
+	parts := strings.SplitN(base, "_", 2)
+	if len(parts) != 2 {
+		return 0, "", "", false
+	}
+	version, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", "", false
+	}
+	return version, parts[1], direction, true
+}
+
+const createTrackingTable = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+	version    BIGINT PRIMARY KEY,
+	name       TEXT NOT NULL,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);`
+
+// Up applies every embedded migration newer than the highest version
+// already recorded in schema_migrations, each inside its own transaction.
+func Up(db *sql.DB) error {
+	if _, err := db.Exec(createTrackingTable); err != nil {
+		return fmt.Errorf("migrate: create schema_migrations: %w", err)
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		var applied bool
+		if err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", m.version).Scan(&applied); err != nil {
+			return fmt.Errorf("migrate: check version %d: %w", m.version, err)
+		}
+		if applied {
+			continue
+		}
+
+		tx, err := db.Begin()
+		if err != nil {
+			return fmt.Errorf("migrate: begin version %d: %w", m.version, err)
+		}
+		if _, err := tx.Exec(m.up); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migrate: apply version %d (%s): %w", m.version, m.name, err)
+		}
+		if _, err := tx.Exec("INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", m.version, m.name); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migrate: record version %d: %w", m.version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("migrate: commit version %d: %w", m.version, err)
+		}
+	}
+	return nil
+}
+
+// Verify reports whether every embedded migration has actually been
+// applied, so the server can refuse to start against a database that was
+// never migrated (or was only partially migrated) instead of failing
+// obscurely the first time a repository queries a table that doesn't exist.
+func Verify(db *sql.DB) error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	var tableExists bool
+	if err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'schema_migrations')").Scan(&tableExists); err != nil {
+		return fmt.Errorf("migrate: check schema_migrations table: %w", err)
+	}
+	if !tableExists {
+		return fmt.Errorf("migrate: schema_migrations table does not exist - run '%s migrate' first", os.Args[0])
+	}
+
+	var missing []string
+	for _, m := range migrations {
+		var applied bool
+		if err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", m.version).Scan(&applied); err != nil {
+			return fmt.Errorf("migrate: check version %d: %w", m.version, err)
+		}
+		if !applied {
+			missing = append(missing, fmt.Sprintf("%04d_%s", m.version, m.name))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("migrate: database is missing migrations %s - run '%s migrate' before starting the server", strings.Join(missing, ", "), os.Args[0])
+	}
+	return nil
+}
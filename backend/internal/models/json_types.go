@@ -0,0 +1,192 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// StringList is a []string stored as a JSON-encoded column.
+type StringList []string
+
+// Value implements driver.Valuer.
+func (s StringList) Value() (driver.Value, error) {
+	if s == nil {
+		return "[]", nil
+	}
+	return json.Marshal(s)
+}
+
+// Scan implements sql.Scanner.
+func (s *StringList) Scan(value interface{}) error {
+	if value == nil {
+		*s = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		if str, ok := value.(string); ok {
+			bytes = []byte(str)
+		} else {
+			return fmt.Errorf("models: cannot scan %T into StringList", value)
+		}
+	}
+	return json.Unmarshal(bytes, s)
+}
+
+// StringMap is a map[string]string stored as a JSON-encoded column, used for
+// per-language translated fields.
+type StringMap map[string]string
+
+// Value implements driver.Valuer.
+func (m StringMap) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	return json.Marshal(m)
+}
+
+// Scan implements sql.Scanner.
+func (m *StringMap) Scan(value interface{}) error {
+	if value == nil {
+		*m = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		if str, ok := value.(string); ok {
+			bytes = []byte(str)
+		} else {
+			return fmt.Errorf("models: cannot scan %T into StringMap", value)
+		}
+	}
+	return json.Unmarshal(bytes, m)
+}
+
+// ShippingPriceRule adds a surcharge to a ShippingMethod's BasePrice once a
+// cart's total weight or total price passes a threshold. A rule with
+// MinWeightGrams set is evaluated against cart weight; one with MinTotal
+// set is evaluated against cart total. Every rule whose threshold is met
+// applies, so a heavy, high-value cart can stack more than one surcharge.
+type ShippingPriceRule struct {
+	MinWeightGrams int     `json:"min_weight_grams,omitempty"`
+	MinTotal       float64 `json:"min_total,omitempty"`
+	Surcharge      float64 `json:"surcharge"`
+}
+
+// ShippingPriceRules is a []ShippingPriceRule stored as a JSON-encoded column.
+type ShippingPriceRules []ShippingPriceRule
+
+// Value implements driver.Valuer.
+func (r ShippingPriceRules) Value() (driver.Value, error) {
+	if r == nil {
+		return "[]", nil
+	}
+	return json.Marshal(r)
+}
+
+// Scan implements sql.Scanner.
+func (r *ShippingPriceRules) Scan(value interface{}) error {
+	if value == nil {
+		*r = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		if str, ok := value.(string); ok {
+			bytes = []byte(str)
+		} else {
+			return fmt.Errorf("models: cannot scan %T into ShippingPriceRules", value)
+		}
+	}
+	return json.Unmarshal(bytes, r)
+}
+
+// ShippingZoneRule ties a destination (country/region/city, each optional)
+// to a surcharge and gates whether a ShippingMethod serves it at all. An
+// empty field matches any value for that field, so a rule can be as broad
+// as a whole country or as narrow as a single city.
+type ShippingZoneRule struct {
+	Country   string  `json:"country,omitempty"`
+	Region    string  `json:"region,omitempty"`
+	City      string  `json:"city,omitempty"`
+	Surcharge float64 `json:"surcharge,omitempty"`
+}
+
+// Matches reports whether a destination falls inside this zone.
+func (z ShippingZoneRule) Matches(country, region, city string) bool {
+	if z.Country != "" && z.Country != country {
+		return false
+	}
+	if z.Region != "" && z.Region != region {
+		return false
+	}
+	if z.City != "" && z.City != city {
+		return false
+	}
+	return true
+}
+
+// ShippingZoneRules is a []ShippingZoneRule stored as a JSON-encoded column.
+type ShippingZoneRules []ShippingZoneRule
+
+// Value implements driver.Valuer.
+func (r ShippingZoneRules) Value() (driver.Value, error) {
+	if r == nil {
+		return "[]", nil
+	}
+	return json.Marshal(r)
+}
+
+// Scan implements sql.Scanner.
+func (r *ShippingZoneRules) Scan(value interface{}) error {
+	if value == nil {
+		*r = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		if str, ok := value.(string); ok {
+			bytes = []byte(str)
+		} else {
+			return fmt.Errorf("models: cannot scan %T into ShippingZoneRules", value)
+		}
+	}
+	return json.Unmarshal(bytes, r)
+}
+
+// CartShareLine is a single product/size/quantity captured in a cart
+// snapshot.
+type CartShareLine struct {
+	ProductID string `json:"product_id"`
+	Size      string `json:"size"`
+	Quantity  int    `json:"quantity"`
+}
+
+// CartShareLines is a []CartShareLine stored as a JSON-encoded column.
+type CartShareLines []CartShareLine
+
+// Value implements driver.Valuer.
+func (l CartShareLines) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	return json.Marshal(l)
+}
+
+// Scan implements sql.Scanner.
+func (l *CartShareLines) Scan(value interface{}) error {
+	if value == nil {
+		*l = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		if str, ok := value.(string); ok {
+			bytes = []byte(str)
+		} else {
+			return fmt.Errorf("models: cannot scan %T into CartShareLines", value)
+		}
+	}
+	return json.Unmarshal(bytes, l)
+}
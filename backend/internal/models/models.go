@@ -0,0 +1,1130 @@
+// Package models contains the GORM entities shared across the backend.
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// User is a registered shop account.
+type User struct {
+	ID           uint   `gorm:"primaryKey" json:"id"`
+	Email        string `gorm:"uniqueIndex;not null" json:"email"`
+	PasswordHash string `gorm:"not null" json:"-"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	Country      string `json:"country"`
+	Phone        string `json:"phone"`
+	// Structured profile address, replacing a single free-text field so
+	// shipping integrations get components they can rely on instead of an
+	// unparsable string. PostalCode is validated per Country where we know
+	// the format (see utils.IsValidPostalCode).
+	City       string `json:"city"`
+	Street     string `json:"street"`
+	Building   string `json:"building"`
+	Apartment  string `json:"apartment,omitempty"`
+	PostalCode string `json:"postal_code"`
+	AvatarURL  string `json:"avatar_url,omitempty"`
+	Role       string `gorm:"not null;default:'user'" json:"role"`
+	// Anonymized marks a deleted account whose PII has been scrubbed. The
+	// row is kept rather than removed so orders placed under it remain
+	// intact for accounting.
+	Anonymized bool `gorm:"not null;default:false" json:"anonymized"`
+	// Blocked prevents future logins and revokes any existing session, for
+	// an admin acting on abuse/fraud without deleting the account outright.
+	Blocked     bool      `gorm:"not null;default:false" json:"blocked"`
+	CreatedDate time.Time `gorm:"autoCreateTime" json:"created_date"`
+
+	// Communication preferences. EmailOrderUpdates defaults on since it
+	// covers transactional messages (order confirmation, shipping); the
+	// other two default off and require explicit opt-in.
+	EmailOrderUpdates bool `gorm:"not null;default:true" json:"email_order_updates"`
+	EmailMarketing    bool `gorm:"not null;default:false" json:"email_marketing"`
+	SMSNotifications  bool `gorm:"not null;default:false" json:"sms_notifications"`
+	// PushNotifications gates both order-status and back-in-stock browser
+	// pushes; unlike SMS there's only one push channel today so it doesn't
+	// need to be split further. Defaults on like EmailOrderUpdates since a
+	// subscription is itself an opt-in action (see PushSubscription).
+	PushNotifications bool `gorm:"not null;default:true" json:"push_notifications"`
+
+	Orders []Order `json:"-"`
+}
+
+// Category groups products for browsing and filtering.
+type Category struct {
+	ID   uint   `gorm:"primaryKey" json:"id"`
+	Name string `gorm:"uniqueIndex;not null" json:"name"`
+
+	// Indexable controls whether this category appears in the sitemap and
+	// omits a noindex hint from the API. Defaults to true; admins flip it
+	// for out-of-season or duplicate content.
+	Indexable bool `gorm:"not null;default:true" json:"indexable"`
+}
+
+// Product is a piece of merchandise sold in the shop.
+type Product struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Name          string    `gorm:"not null" json:"name"`
+	Description   string    `json:"description"`
+	Price         float64   `gorm:"not null" json:"price"`
+	StockQuantity int       `gorm:"not null;default:0" json:"stock_quantity"`
+	Size          string    `json:"size"`
+	Color         string    `json:"color"`
+	Gender        string    `json:"gender"`
+	// WeightGrams is the shipped weight of a single unit, used by
+	// ShippingMethod.PriceFor to apply weight-based surcharges.
+	WeightGrams     int        `gorm:"not null;default:0" json:"weight_grams"`
+	DiscountPercent int        `gorm:"default:0" json:"discount_percent"`
+	CategoryID      uint       `json:"category_id"`
+	Category        Category   `json:"category"`
+	ArticleNumber   string     `gorm:"uniqueIndex" json:"article_number"`
+	Discontinued    bool       `gorm:"not null;default:false" json:"discontinued"`
+	Images          StringList `gorm:"type:jsonb" json:"images"`
+	Attributes      StringMap  `gorm:"type:jsonb" json:"attributes"`
+	SizeChartURL    string     `json:"size_chart_url"`
+	Translations    StringMap  `gorm:"type:jsonb" json:"translations"`
+	Published       bool       `gorm:"not null;default:false" json:"published"`
+	// Indexable controls whether this product appears in the sitemap and
+	// omits a noindex hint from the API. Defaults to true; admins flip it
+	// for out-of-season or duplicate content.
+	Indexable   bool      `gorm:"not null;default:true" json:"indexable"`
+	CreatedDate time.Time `gorm:"autoCreateTime" json:"created_date"`
+
+	// AverageRating and ReviewCount are denormalized from Review so listing
+	// and detail responses can render stars without a join or aggregate
+	// query on every request. Kept in sync by ReviewRepository whenever a
+	// review is created, edited, or deleted.
+	AverageRating float64 `gorm:"not null;default:0" json:"average_rating"`
+	ReviewCount   int     `gorm:"not null;default:0" json:"review_count"`
+
+	// Version is an optimistic-lock counter, incremented on every update.
+	// ProductRepository.Update conditions on the caller's Version matching
+	// the row's current one, so two concurrent edits can't silently
+	// overwrite each other - the loser gets apperr.ErrConflict instead.
+	Version int `gorm:"not null;default:0" json:"version"`
+
+	// DeletedAt makes product removal a soft delete: GORM sets it instead of
+	// removing the row, so every normal query keeps excluding the product
+	// while OrderItem's OnDelete:RESTRICT foreign key to it never actually
+	// fires. ProductRepository.ListDeleted and Restore are the only places
+	// that reach past it, via Unscoped.
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// DiscountedPrice returns the price after applying DiscountPercent.
+func (p Product) DiscountedPrice() float64 {
+	if p.DiscountPercent <= 0 {
+		return p.Price
+	}
+	return p.Price * (1 - float64(p.DiscountPercent)/100)
+}
+
+// SavingsAmount returns how much cheaper DiscountedPrice is than Price.
+func (p Product) SavingsAmount() float64 {
+	return p.Price - p.DiscountedPrice()
+}
+
+// DiscountedPriceForCollection returns the price after applying whichever
+// discount takes precedence: the product's own DiscountPercent if it has
+// one, otherwise the collection's, as long as the collection's is active.
+func (p Product) DiscountedPriceForCollection(collection Collection) float64 {
+	percent := p.DiscountPercent
+	if percent <= 0 && collection.DiscountActive() {
+		percent = collection.DiscountPercent
+	}
+	if percent <= 0 {
+		return p.Price
+	}
+	return p.Price * (1 - float64(percent)/100)
+}
+
+// MarshalJSON adds the pre-computed pricing fields (original_price,
+// discounted_price, savings_amount, savings_percent) so every client renders
+// discounts identically instead of each re-deriving them from
+// discount_percent, plus a noindex hint mirroring Indexable for crawlers
+// that read API responses directly. productAlias avoids infinite recursion
+// into MarshalJSON.
+func (p Product) MarshalJSON() ([]byte, error) {
+	type productAlias Product
+	return json.Marshal(struct {
+		productAlias
+		OriginalPrice   float64 `json:"original_price"`
+		DiscountedPrice float64 `json:"discounted_price"`
+		SavingsAmount   float64 `json:"savings_amount"`
+		SavingsPercent  int     `json:"savings_percent"`
+		Noindex         bool    `json:"noindex"`
+	}{
+		productAlias:    productAlias(p),
+		OriginalPrice:   p.Price,
+		DiscountedPrice: p.DiscountedPrice(),
+		Noindex:         !p.Indexable,
+		SavingsAmount:   p.SavingsAmount(),
+		SavingsPercent:  p.DiscountPercent,
+	})
+}
+
+// Order is a placed purchase belonging to a user.
+type Order struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID        uint      `gorm:"not null;index" json:"user_id"`
+	TotalAmount   float64   `gorm:"not null" json:"total_amount"`
+	VATAmount     float64   `gorm:"not null;default:0" json:"vat_amount"`
+	Status        string    `gorm:"not null;default:'pending'" json:"status"`
+	PaymentStatus string    `gorm:"not null;default:'unpaid'" json:"payment_status"`
+	CreatedDate   time.Time `gorm:"autoCreateTime" json:"created_date"`
+	// PaymentMethod is the PaymentMethod.Key the customer chose at checkout,
+	// e.g. "card" or "cash_on_delivery". Empty until they reach payment.
+	PaymentMethod string `json:"payment_method,omitempty"`
+	Region        string `json:"region,omitempty"`
+	// PickupPointID is set when the customer chose to collect the order
+	// from a carrier pickup point instead of having it delivered to an
+	// address. Nil means home delivery.
+	PickupPointID *uuid.UUID `gorm:"type:uuid" json:"pickup_point_id,omitempty"`
+	// DeliverySlotID and DeliveryDate record the delivery window the
+	// customer booked at checkout, visible to the warehouse on the pick
+	// list. Nil means no slot was booked.
+	DeliverySlotID *uuid.UUID `gorm:"type:uuid" json:"delivery_slot_id,omitempty"`
+	DeliveryDate   *time.Time `gorm:"type:date" json:"delivery_date,omitempty"`
+
+	// Note is left by the customer at checkout (e.g. delivery instructions).
+	Note string `json:"note"`
+	// AdminNote and Tags are internal, set by back-office staff (e.g. "VIP",
+	// "fragile"), and surfaced on the warehouse pick list and CSV export.
+	AdminNote string     `json:"admin_note"`
+	Tags      StringList `gorm:"type:jsonb" json:"tags"`
+
+	// Version is an optimistic-lock counter, incremented on every status
+	// change. OrderRepository.UpdateStatus conditions on the caller's
+	// Version matching the row's current one, so two concurrent status
+	// changes can't silently overwrite each other - the loser gets
+	// apperr.ErrConflict instead.
+	Version int `gorm:"not null;default:0" json:"version"`
+
+	Items []OrderItem `json:"items"`
+	// Payments is every payment attempt made against this order. It's kept
+	// separate from Status/PaymentStatus, which summarize the current
+	// state - this is the full history behind that summary.
+	Payments []Payment `gorm:"foreignKey:OrderID" json:"payments,omitempty"`
+}
+
+// OrderStatusDelivered is the Status value set once an order has actually
+// reached the customer, the bar for a review to count as a verified
+// purchase.
+const OrderStatusDelivered = "delivered"
+
+// OrderStatusShipped is the Status value set once an order has left the
+// warehouse, the trigger for the "your order shipped" notification.
+const OrderStatusShipped = "shipped"
+
+// OrderStatusCancelled is the Status value set by
+// service.OrderCancellationService when an unpaid order sits past its
+// payment window, and by an admin cancelling an order by hand.
+const OrderStatusCancelled = "cancelled"
+
+// Order.PaymentStatus values.
+const (
+	PaymentStatusUnpaid            = "unpaid"
+	PaymentStatusPending           = "pending"
+	PaymentStatusPaid              = "paid"
+	PaymentStatusFailed            = "failed"
+	PaymentStatusPartiallyRefunded = "partially_refunded"
+	PaymentStatusRefunded          = "refunded"
+	// PaymentStatusExpired marks a payment whose hosted confirmation
+	// window lapsed before the customer completed it, distinct from a
+	// gateway-declined PaymentStatusFailed.
+	PaymentStatusExpired = "expired"
+	// PaymentStatusAwaitingDelivery marks an order paid cash-on-delivery:
+	// no gateway payment was taken, and it stays in this state until an
+	// admin confirms the cash was collected on delivery.
+	PaymentStatusAwaitingDelivery = "awaiting_delivery"
+)
+
+// PaymentMethodCashOnDelivery is the well-known PaymentMethod.Key that
+// routes Pay through the cash-on-delivery path instead of a gateway.
+const PaymentMethodCashOnDelivery = "cash_on_delivery"
+
+// Payment records one attempt to pay for an order through a gateway. An
+// order can accumulate more than one Payment row if an earlier attempt
+// expires or fails and the customer retries checkout.
+type Payment struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	OrderID          uuid.UUID `gorm:"type:uuid;not null;index" json:"order_id"`
+	Provider         string    `gorm:"not null" json:"provider"`
+	GatewayPaymentID string    `gorm:"not null;index" json:"gateway_payment_id"`
+	Status           string    `gorm:"not null;default:'pending'" json:"status"`
+	Amount           float64   `gorm:"not null" json:"amount"`
+	Currency         string    `gorm:"not null" json:"currency"`
+	ConfirmationURL  string    `json:"confirmation_url,omitempty"`
+	CreatedDate      time.Time `gorm:"autoCreateTime" json:"created_date"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// SavedCard is a card a user tokenized with a gateway during a previous
+// payment, so a later order can be charged without re-entering card
+// details. Only the gateway's own token is stored - never the PAN.
+type SavedCard struct {
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID          uint      `gorm:"not null;index" json:"user_id"`
+	Provider        string    `gorm:"not null" json:"provider"`
+	GatewayMethodID string    `gorm:"not null" json:"-"`
+	Last4           string    `gorm:"not null" json:"last4"`
+	ExpMonth        string    `gorm:"not null" json:"exp_month"`
+	ExpYear         string    `gorm:"not null" json:"exp_year"`
+	CreatedDate     time.Time `gorm:"autoCreateTime" json:"created_date"`
+}
+
+// PaymentMethod is an admin-configured way a customer can pay at checkout
+// (card, SBP, cash on delivery, ...). MaxAmount of 0 means unlimited, and
+// an empty AllowedRegions means available everywhere - both let a plain
+// "card" row exist with no restrictions at all.
+type PaymentMethod struct {
+	Key            string     `gorm:"primaryKey" json:"key"`
+	Label          string     `gorm:"not null" json:"label"`
+	Enabled        bool       `gorm:"not null;default:true" json:"enabled"`
+	MaxAmount      float64    `gorm:"not null;default:0" json:"max_amount"`
+	AllowedRegions StringList `gorm:"type:jsonb" json:"allowed_regions"`
+}
+
+// Refund statuses.
+const (
+	RefundStatusPending   = "pending"
+	RefundStatusSucceeded = "succeeded"
+	RefundStatusFailed    = "failed"
+)
+
+// Refund is a full or partial refund issued against a Payment. A payment
+// can have several partial refunds; RefundRepository.SumSucceededByPayment
+// is what enforces that they never add up to more than was captured.
+type Refund struct {
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	PaymentID       uuid.UUID `gorm:"type:uuid;not null;index" json:"payment_id"`
+	GatewayRefundID string    `gorm:"not null;index" json:"gateway_refund_id"`
+	Amount          float64   `gorm:"not null" json:"amount"`
+	Status          string    `gorm:"not null;default:'pending'" json:"status"`
+	Reason          string    `json:"reason,omitempty"`
+	CreatedDate     time.Time `gorm:"autoCreateTime" json:"created_date"`
+}
+
+// PaymentWebhookEvent is a raw log of every webhook notification a gateway
+// sends, kept regardless of whether it changed anything, so a support
+// ticket about a missed status transition can be answered from the log
+// rather than the gateway's own dashboard.
+type PaymentWebhookEvent struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Provider         string    `gorm:"not null" json:"provider"`
+	GatewayPaymentID string    `gorm:"not null;index" json:"gateway_payment_id"`
+	EventType        string    `gorm:"not null" json:"event_type"`
+	Payload          string    `json:"payload"`
+	ReceivedDate     time.Time `gorm:"autoCreateTime" json:"received_date"`
+}
+
+// OutboxEvent is a domain event recorded in the same database transaction
+// as the change that produced it (the transactional outbox pattern), so
+// OutboxRelayService can publish it reliably afterward instead of the event
+// being lost if a direct publish call had failed or the process crashed
+// between commit and publish.
+type OutboxEvent struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	AggregateType string     `gorm:"not null;index" json:"aggregate_type"`
+	AggregateID   string     `gorm:"not null" json:"aggregate_id"`
+	EventType     string     `gorm:"not null" json:"event_type"`
+	Payload       string     `json:"payload"`
+	Status        string     `gorm:"not null;default:'pending';index" json:"status"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedDate   time.Time  `gorm:"autoCreateTime" json:"created_date"`
+	PublishedDate *time.Time `json:"published_date,omitempty"`
+}
+
+// OutboxEvent.Status values.
+const (
+	OutboxStatusPending   = "pending"
+	OutboxStatusPublished = "published"
+	OutboxStatusFailed    = "failed"
+)
+
+// MaxOutboxAttempts is how many delivery attempts OutboxRelayService makes
+// before giving up on an event and marking it OutboxStatusFailed instead of
+// retrying it forever.
+const MaxOutboxAttempts = 10
+
+// SearchIndexJob queues a product for the search engine to be brought up
+// to date, kept in its own table rather than the generic outbox: both are
+// polled and marked published/failed the same way, but sharing one status
+// column between OutboxRelayService's webhook fanout and
+// SearchIndexService's engine sync would mean whichever relay polls first
+// claims the row and the other never sees it.
+type SearchIndexJob struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	ProductID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"product_id"`
+	Action        string     `gorm:"not null" json:"action"`
+	Status        string     `gorm:"not null;default:'pending';index" json:"status"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedDate   time.Time  `gorm:"autoCreateTime" json:"created_date"`
+	PublishedDate *time.Time `json:"published_date,omitempty"`
+}
+
+// SearchIndexJob.Action values.
+const (
+	SearchJobActionUpsert = "upsert"
+	SearchJobActionDelete = "delete"
+)
+
+// SearchIndexJob.Status values, matching OutboxEvent's.
+const (
+	SearchJobStatusPending   = "pending"
+	SearchJobStatusPublished = "published"
+	SearchJobStatusFailed    = "failed"
+)
+
+// MaxSearchJobAttempts is how many sync attempts SearchIndexService makes
+// before giving up on a job and marking it SearchJobStatusFailed.
+const MaxSearchJobAttempts = 10
+
+// SearchQueryLog records one search request, so the catalog team can see
+// what customers search for and, via ResultCount, what turns up nothing.
+type SearchQueryLog struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Query       string    `gorm:"not null;index" json:"query"`
+	ResultCount int       `gorm:"not null" json:"result_count"`
+	Source      string    `gorm:"not null" json:"source"` // "search_engine", "postgres_fallback" or "postgres_fuzzy"
+	UserID      *uint     `json:"user_id,omitempty"`
+	CreatedDate time.Time `gorm:"autoCreateTime;index" json:"created_date"`
+}
+
+// SearchClickLog records a searcher clicking through to a product from a
+// query's results, so a query with plenty of results but no clicks is as
+// visible to the catalog team as one with none at all. Position is the
+// result's 0-based rank in the response the click came from.
+type SearchClickLog struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Query       string    `gorm:"not null;index" json:"query"`
+	ProductID   uuid.UUID `gorm:"type:uuid;not null" json:"product_id"`
+	Position    int       `gorm:"not null" json:"position"`
+	CreatedDate time.Time `gorm:"autoCreateTime" json:"created_date"`
+}
+
+// EmailLog records the outcome of one EmailService.Send call, so a support
+// agent can answer "did the reset email actually go out" without SSHing
+// into the mail relay.
+type EmailLog struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	To           string     `gorm:"not null;index" json:"to"`
+	TemplateName string     `gorm:"not null" json:"template_name"`
+	Subject      string     `gorm:"not null" json:"subject"`
+	Status       string     `gorm:"not null" json:"status"`
+	Attempts     int        `gorm:"not null;default:0" json:"attempts"`
+	LastError    string     `json:"last_error,omitempty"`
+	CreatedDate  time.Time  `gorm:"autoCreateTime;index" json:"created_date"`
+	SentDate     *time.Time `json:"sent_date,omitempty"`
+}
+
+// EmailLog.Status values.
+const (
+	EmailStatusSent   = "sent"
+	EmailStatusFailed = "failed"
+)
+
+// SMSLog records the outcome of one SMSService.Send call and, once the
+// provider's delivery-status callback arrives, whether the carrier
+// actually delivered it - phone verification codes and COD confirmations
+// are time-sensitive enough that "we submitted it" isn't the same
+// question as "it arrived".
+type SMSLog struct {
+	ID                uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	To                string     `gorm:"not null;index" json:"to"`
+	Country           string     `gorm:"not null" json:"country"`
+	Body              string     `gorm:"not null" json:"body"`
+	ProviderMessageID string     `gorm:"index" json:"provider_message_id,omitempty"`
+	Status            string     `gorm:"not null" json:"status"`
+	Attempts          int        `gorm:"not null;default:0" json:"attempts"`
+	LastError         string     `json:"last_error,omitempty"`
+	CreatedDate       time.Time  `gorm:"autoCreateTime;index" json:"created_date"`
+	DeliveredDate     *time.Time `json:"delivered_date,omitempty"`
+}
+
+// SMSLog.Status values. Submitted is set once the provider has accepted
+// the message; Delivered/Failed are set later, from its delivery-status
+// callback.
+const (
+	SMSStatusSubmitted = "submitted"
+	SMSStatusFailed    = "failed"
+	SMSStatusDelivered = "delivered"
+)
+
+// PushSubscription is one browser's Web Push endpoint for a user, as
+// returned by the Push API's PushManager.subscribe(). Auth and P256DH are
+// the subscription's keys, base64url-encoded exactly as the browser
+// supplies them, and are what webpush.Sender uses to encrypt a payload
+// only that browser can decrypt.
+type PushSubscription struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID      uint      `gorm:"not null;index" json:"user_id"`
+	Endpoint    string    `gorm:"not null;uniqueIndex;size:1024" json:"endpoint"`
+	P256DH      string    `gorm:"not null" json:"p256dh"`
+	Auth        string    `gorm:"not null" json:"auth"`
+	CreatedDate time.Time `gorm:"autoCreateTime" json:"created_date"`
+}
+
+// NotificationLog records one channel's outcome for one notification
+// event dispatched by service.NotificationService, so a user (or support,
+// on their behalf) can see what they were sent regardless of which
+// channel it went out on - the channel-specific logs (EmailLog, SMSLog)
+// exist for delivery debugging, not for a customer-facing history.
+type NotificationLog struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID      uint      `gorm:"not null;index" json:"user_id"`
+	EventKind   string    `gorm:"not null" json:"event_kind"`
+	Channel     string    `gorm:"not null" json:"channel"`
+	Status      string    `gorm:"not null" json:"status"`
+	CreatedDate time.Time `gorm:"autoCreateTime;index" json:"created_date"`
+}
+
+// NotificationLog.Status values.
+const (
+	NotificationStatusSent   = "sent"
+	NotificationStatusFailed = "failed"
+)
+
+// Ledger account names used by LedgerEntry.Account.
+const (
+	LedgerAccountCash      = "cash"
+	LedgerAccountRevenue   = "revenue"
+	LedgerAccountRefunds   = "refunds"
+	LedgerAccountDiscounts = "discounts"
+)
+
+// LedgerEntry.EntryType values.
+const (
+	LedgerEntryDebit  = "debit"
+	LedgerEntryCredit = "credit"
+)
+
+// LedgerEntry is one half of a double-entry bookkeeping record: every event
+// that moves money against an order (a capture, refund, COD settlement, or
+// coupon discount) writes a debit and a matching credit sharing a
+// TransactionID, so finance can audit money flow from an append-only trail
+// instead of trusting Order/Payment rows that get overwritten in place.
+type LedgerEntry struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TransactionID uuid.UUID `gorm:"type:uuid;not null;index" json:"transaction_id"`
+	OrderID       uuid.UUID `gorm:"type:uuid;not null;index" json:"order_id"`
+	Account       string    `gorm:"not null" json:"account"`
+	EntryType     string    `gorm:"not null" json:"entry_type"`
+	Amount        float64   `gorm:"not null" json:"amount"`
+	Currency      string    `gorm:"not null" json:"currency"`
+	// Reference points at the row that caused this entry - a Payment,
+	// Refund, or Coupon ID - so it can be traced back to the event.
+	Reference   string    `json:"reference,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CreatedDate time.Time `gorm:"autoCreateTime" json:"created_date"`
+}
+
+// OrderItem is a single product/size/quantity line within an Order. Product
+// details are snapshotted onto the row at purchase time (below) rather than
+// resolved through the Product FK, so historical orders keep rendering
+// correctly even if the product is later edited or deleted; the FK itself
+// stays RESTRICT to keep referential integrity, not to protect history.
+type OrderItem struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	OrderID     uuid.UUID `gorm:"type:uuid;not null;index" json:"order_id"`
+	ProductID   uuid.UUID `gorm:"type:uuid;not null" json:"product_id"`
+	Product     Product   `gorm:"constraint:OnDelete:RESTRICT" json:"-"`
+	Size        string    `json:"size"`
+	Quantity    int       `gorm:"not null" json:"quantity"`
+	PriceAtTime float64   `gorm:"not null" json:"price_at_time"`
+
+	// Snapshot of the product as it was at purchase time.
+	ProductName  string `json:"product_name"`
+	ProductColor string `json:"product_color"`
+	ProductSKU   string `json:"product_sku"`
+	ProductImage string `json:"product_image"`
+}
+
+// Snapshot copies the fields of product that must survive edits or
+// deletion of the product itself onto the order item.
+func (i *OrderItem) Snapshot(product Product) {
+	i.ProductName = product.Name
+	i.ProductColor = product.Color
+	i.ProductSKU = product.ArticleNumber
+	if len(product.Images) > 0 {
+		i.ProductImage = product.Images[0]
+	}
+}
+
+// Review is a user's rating and comment on a product.
+type Review struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	ProductID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_reviews_product_user" json:"product_id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_reviews_product_user" json:"user_id"`
+	Rating    int       `gorm:"not null" json:"rating"`
+	Content   string    `json:"content"`
+	Flagged   bool      `gorm:"not null;default:false" json:"flagged"`
+	// Status gates whether a review is publicly visible: every review is
+	// created "pending" and only counts toward the storefront or the
+	// aggregate rating once an admin moves it to "approved".
+	Status           string     `gorm:"not null;default:'pending'" json:"status"`
+	RejectionReason  string     `json:"rejection_reason,omitempty"`
+	VerifiedPurchase bool       `gorm:"not null;default:false" json:"verified_purchase"`
+	Images           StringList `gorm:"type:jsonb" json:"images"`
+	HelpfulCount     int        `gorm:"not null;default:0" json:"helpful_count"`
+	UnhelpfulCount   int        `gorm:"not null;default:0" json:"unhelpful_count"`
+	CreatedDate      time.Time  `gorm:"autoCreateTime" json:"created_date"`
+	// Reply is the shop's official response, if any. Loaded via Preload so
+	// it rides along in the review listing without a separate request.
+	Reply *ReviewReply `gorm:"foreignKey:ReviewID" json:"reply,omitempty"`
+	// Author is preloaded only where the listing needs it (see
+	// ReviewRepository.ListByProduct), just to surface UserAvatarURL below
+	// without exposing the rest of the account.
+	Author User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+// MarshalJSON adds the review author's avatar URL, since Author itself is
+// hidden from JSON to avoid exposing the rest of the account. reviewAlias
+// avoids infinite recursion into MarshalJSON.
+func (r Review) MarshalJSON() ([]byte, error) {
+	type reviewAlias Review
+	return json.Marshal(struct {
+		reviewAlias
+		UserAvatarURL string `json:"user_avatar_url,omitempty"`
+	}{
+		reviewAlias:   reviewAlias(r),
+		UserAvatarURL: r.Author.AvatarURL,
+	})
+}
+
+// ReviewVote is one user's helpful/unhelpful vote on a review. The unique
+// index on (review_id, user_id) is what enforces "once" - Cast upserts
+// against it rather than checking-then-inserting.
+type ReviewVote struct {
+	ReviewID uuid.UUID `gorm:"type:uuid;primaryKey" json:"review_id"`
+	UserID   uint      `gorm:"primaryKey" json:"user_id"`
+	Helpful  bool      `json:"helpful"`
+}
+
+// ReviewReply is the shop's official response to a review. There is at
+// most one per review - AdminID is whoever most recently wrote it, and
+// posting again overwrites rather than adding a second reply.
+type ReviewReply struct {
+	ReviewID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"review_id"`
+	AdminID     uint      `gorm:"not null" json:"admin_id"`
+	Content     string    `gorm:"not null" json:"content"`
+	CreatedDate time.Time `gorm:"autoCreateTime" json:"created_date"`
+}
+
+// ShippingZone maps a postal code prefix to delivery lead time and cost
+// range for a single carrier. Longer prefixes are matched first, so a zone
+// can be as specific as a city or as broad as a whole country.
+type ShippingZone struct {
+	ID           uint    `gorm:"primaryKey" json:"id"`
+	PostalPrefix string  `gorm:"not null;index" json:"postal_prefix"`
+	CarrierName  string  `gorm:"not null" json:"carrier_name"`
+	LeadTimeDays int     `gorm:"not null" json:"lead_time_days"`
+	CostMin      float64 `gorm:"not null" json:"cost_min"`
+	CostMax      float64 `gorm:"not null" json:"cost_max"`
+}
+
+// ShippingMethod is an admin-configured way a customer can have an order
+// shipped (e.g. "Standard", "Express"), separate from ShippingZone's
+// postal-code lead-time lookup. BasePrice is adjusted by PriceRules keyed
+// on cart weight or total, and an empty AllowedRegions means available
+// everywhere - the same convention PaymentMethod uses.
+//
+// Zones is a finer-grained alternative to AllowedRegions: when set, a
+// destination must match at least one zone (by country/region/city, with
+// an empty field acting as a wildcard) for the method to be offered at
+// all, and each matching zone's Surcharge is added on top of PriceFor.
+// AllowedRegions is ignored once Zones is set.
+type ShippingMethod struct {
+	ID             uuid.UUID          `gorm:"type:uuid;primaryKey" json:"id"`
+	Name           string             `gorm:"not null" json:"name"`
+	Carrier        string             `gorm:"not null" json:"carrier"`
+	BasePrice      float64            `gorm:"not null;default:0" json:"base_price"`
+	PriceRules     ShippingPriceRules `gorm:"type:jsonb" json:"price_rules"`
+	AllowedRegions StringList         `gorm:"type:jsonb" json:"allowed_regions"`
+	Zones          ShippingZoneRules  `gorm:"type:jsonb" json:"zones"`
+	Enabled        bool               `gorm:"not null;default:true" json:"enabled"`
+	CreatedDate    time.Time          `gorm:"autoCreateTime" json:"created_date"`
+}
+
+// PriceFor computes what this method costs for a cart of the given total
+// weight and price, applying every PriceRules threshold that's met on top
+// of BasePrice.
+func (m ShippingMethod) PriceFor(weightGrams int, total float64) float64 {
+	price := m.BasePrice
+	for _, rule := range m.PriceRules {
+		if rule.MinWeightGrams > 0 && weightGrams >= rule.MinWeightGrams {
+			price += rule.Surcharge
+		}
+		if rule.MinTotal > 0 && total >= rule.MinTotal {
+			price += rule.Surcharge
+		}
+	}
+	return price
+}
+
+// AvailableIn reports whether this method can be offered to a destination.
+// If Zones is set, the destination must match at least one; otherwise it
+// falls back to the coarser AllowedRegions check, where an empty
+// AllowedRegions means every region is allowed.
+func (m ShippingMethod) AvailableIn(country, region, city string) bool {
+	if len(m.Zones) > 0 {
+		for _, z := range m.Zones {
+			if z.Matches(country, region, city) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(m.AllowedRegions) == 0 {
+		return true
+	}
+	for _, r := range m.AllowedRegions {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
+
+// ZoneSurcharge sums the Surcharge of every zone matching the destination,
+// on top of PriceFor.
+func (m ShippingMethod) ZoneSurcharge(country, region, city string) float64 {
+	surcharge := 0.0
+	for _, z := range m.Zones {
+		if z.Matches(country, region, city) {
+			surcharge += z.Surcharge
+		}
+	}
+	return surcharge
+}
+
+// Shipment tracking statuses. A carrier reports its own status codes on
+// each TrackingEvent, but Shipment.Status is normalized to one of these so
+// order status and the storefront UI don't need to know per-carrier codes.
+const (
+	ShipmentStatusCreated   = "created"
+	ShipmentStatusInTransit = "in_transit"
+	ShipmentStatusDelivered = "delivered"
+	ShipmentStatusException = "exception"
+)
+
+// Shipment is a carrier waybill registered against an order. An order has
+// at most one active Shipment; TrackingEvents is its full history, oldest
+// first, appended to as the background tracking poll picks up new carrier
+// statuses.
+type Shipment struct {
+	ID             uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	OrderID        uuid.UUID       `gorm:"type:uuid;not null;index" json:"order_id"`
+	Carrier        string          `gorm:"not null" json:"carrier"`
+	TrackingNumber string          `gorm:"not null;index" json:"tracking_number"`
+	Status         string          `gorm:"not null;default:'created'" json:"status"`
+	CreatedDate    time.Time       `gorm:"autoCreateTime" json:"created_date"`
+	UpdatedAt      time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+	Events         []TrackingEvent `gorm:"foreignKey:ShipmentID" json:"events,omitempty"`
+}
+
+// TrackingEvent is one milestone a carrier has reported against a
+// Shipment, e.g. "handed to courier" or "arrived at sorting facility".
+type TrackingEvent struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	ShipmentID  uuid.UUID `gorm:"type:uuid;not null;index" json:"shipment_id"`
+	Status      string    `gorm:"not null" json:"status"`
+	Description string    `json:"description,omitempty"`
+	OccurredAt  time.Time `gorm:"not null" json:"occurred_at"`
+}
+
+// Address is a delivery address a user has saved to their account. A user
+// can save several; IsDefault marks the one used unless another is
+// explicitly chosen at checkout.
+type Address struct {
+	ID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID uint      `gorm:"not null;index" json:"user_id"`
+	// Label is a name the user picks for themselves, e.g. "Home" or "Office".
+	Label      string `json:"label"`
+	Recipient  string `gorm:"not null" json:"recipient"`
+	Phone      string `gorm:"not null" json:"phone"`
+	Line1      string `gorm:"not null" json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `gorm:"not null" json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `gorm:"not null" json:"postal_code"`
+	Country    string `gorm:"not null" json:"country"`
+	IsDefault  bool   `gorm:"not null;default:false" json:"is_default"`
+	// Latitude, Longitude, and Validated are filled in by an
+	// addressvalidation.Validator when one is configured; Validated stays
+	// false, and the coordinates stay zero, until that succeeds.
+	Latitude    float64   `gorm:"not null;default:0" json:"latitude,omitempty"`
+	Longitude   float64   `gorm:"not null;default:0" json:"longitude,omitempty"`
+	Validated   bool      `gorm:"not null;default:false" json:"validated"`
+	CreatedDate time.Time `gorm:"autoCreateTime" json:"created_date"`
+}
+
+// PickupPoint is a carrier-operated (or admin-entered) location a customer
+// can have an order delivered to instead of a home address. ExternalID is
+// the carrier's own identifier for the point, unique per Carrier, and is
+// what PickupPointRepository.Upsert matches on when syncing a carrier's
+// point list.
+type PickupPoint struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Carrier     string    `gorm:"not null;index:idx_pickup_points_carrier_external,unique" json:"carrier"`
+	ExternalID  string    `gorm:"not null;index:idx_pickup_points_carrier_external,unique" json:"external_id"`
+	Name        string    `gorm:"not null" json:"name"`
+	Address     string    `gorm:"not null" json:"address"`
+	City        string    `gorm:"not null;index" json:"city"`
+	Region      string    `json:"region"`
+	PostalCode  string    `json:"postal_code"`
+	Latitude    float64   `gorm:"not null;default:0" json:"latitude"`
+	Longitude   float64   `gorm:"not null;default:0" json:"longitude"`
+	CreatedDate time.Time `gorm:"autoCreateTime" json:"created_date"`
+}
+
+// DeliverySlot is an admin-configured recurring delivery window, e.g. every
+// Tuesday 10:00-14:00, with a Capacity capping how many orders can book the
+// same calendar date against it.
+type DeliverySlot struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	// Weekday is time.Weekday (0 = Sunday ... 6 = Saturday).
+	Weekday   int    `gorm:"not null" json:"weekday"`
+	StartTime string `gorm:"not null" json:"start_time"`
+	EndTime   string `gorm:"not null" json:"end_time"`
+	Capacity  int    `gorm:"not null;default:1" json:"capacity"`
+	Enabled   bool   `gorm:"not null;default:true" json:"enabled"`
+}
+
+// DeliverySlotBooking is one order's claim on a DeliverySlot for a specific
+// calendar date. DeliverySlotBookingRepository.CountForDate enforces
+// Capacity by counting these rather than decrementing a shared counter, so
+// a canceled order can simply have its booking deleted to free the slot
+// back up.
+type DeliverySlotBooking struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	SlotID      uuid.UUID `gorm:"type:uuid;not null;index" json:"slot_id"`
+	OrderID     uuid.UUID `gorm:"type:uuid;not null;index" json:"order_id"`
+	Date        time.Time `gorm:"type:date;not null;index" json:"date"`
+	CreatedDate time.Time `gorm:"autoCreateTime" json:"created_date"`
+}
+
+// Collection is an admin-curated grouping of products, e.g. a seasonal
+// lookbook or a "New In" landing page, that cuts across Category.
+type Collection struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Name        string    `gorm:"not null" json:"name"`
+	Slug        string    `gorm:"uniqueIndex;not null" json:"slug"`
+	Description string    `json:"description"`
+	IsActive    bool      `gorm:"not null;default:true" json:"is_active"`
+	// LaunchDate and EndDate gate public visibility even once IsActive is
+	// true, so a collection can be staged ahead of a drop and retired
+	// afterwards. CollectionSchedulerService flips IsActive to match these
+	// automatically; IsAvailable re-checks them regardless, the same way
+	// Coupon's ValidFrom/ValidUntil are re-checked live rather than trusted
+	// to a background job alone.
+	LaunchDate  *time.Time `json:"launch_date,omitempty"`
+	EndDate     *time.Time `json:"end_date,omitempty"`
+	CreatedDate time.Time  `gorm:"autoCreateTime" json:"created_date"`
+
+	// MetaTitle, MetaDescription and Keywords back the storefront's
+	// server-rendered landing page for this collection. Empty means the
+	// frontend falls back to Name/Description.
+	MetaTitle       string `json:"meta_title,omitempty"`
+	MetaDescription string `json:"meta_description,omitempty"`
+	Keywords        string `json:"keywords,omitempty"`
+
+	// CoverImage is the stored name of the uploaded original. CoverImageBanner
+	// and CoverImageThumbnail are meant to be resized renditions of it, but
+	// this codebase has no image-processing dependency yet, so both currently
+	// alias CoverImage rather than pointing at genuinely resized files.
+	CoverImage          string `json:"cover_image,omitempty"`
+	CoverImageBanner    string `json:"cover_image_banner,omitempty"`
+	CoverImageThumbnail string `json:"cover_image_thumbnail,omitempty"`
+
+	// DiscountPercent, DiscountValidFrom and DiscountValidUntil apply a
+	// collection-wide discount to every product in it. It's re-checked live
+	// via DiscountActive, the same way Coupon's ValidFrom/ValidUntil are.
+	// A product's own DiscountPercent takes precedence when set; the
+	// collection discount only fills in for products with none of their own.
+	DiscountPercent    int        `gorm:"default:0" json:"discount_percent"`
+	DiscountValidFrom  *time.Time `json:"discount_valid_from,omitempty"`
+	DiscountValidUntil *time.Time `json:"discount_valid_until,omitempty"`
+}
+
+// DiscountActive reports whether the collection's discount currently
+// applies: it must be set and, if a validity window is configured, the
+// current time must fall inside it.
+func (c Collection) DiscountActive() bool {
+	if c.DiscountPercent <= 0 {
+		return false
+	}
+	now := time.Now()
+	if c.DiscountValidFrom != nil && c.DiscountValidFrom.After(now) {
+		return false
+	}
+	if c.DiscountValidUntil != nil && c.DiscountValidUntil.Before(now) {
+		return false
+	}
+	return true
+}
+
+// IsAvailable reports whether the collection should be shown to customers:
+// it must be active, its LaunchDate (if any) must have passed, and its
+// EndDate (if any) must not have passed yet.
+func (c Collection) IsAvailable() bool {
+	if !c.IsActive {
+		return false
+	}
+	now := time.Now()
+	if c.LaunchDate != nil && c.LaunchDate.After(now) {
+		return false
+	}
+	if c.EndDate != nil && c.EndDate.Before(now) {
+		return false
+	}
+	return true
+}
+
+// CollectionProduct links a Product into a Collection, letting the same
+// product appear in more than one collection at once.
+type CollectionProduct struct {
+	CollectionID uuid.UUID `gorm:"type:uuid;primaryKey" json:"collection_id"`
+	ProductID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"product_id"`
+	Product      Product   `json:"product"`
+}
+
+// SavedItem is a product/size a user has moved out of their cart to buy
+// later, without losing it from the cart response.
+type SavedItem struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	ProductID uuid.UUID `gorm:"type:uuid;not null" json:"product_id"`
+	Product   Product   `json:"product"`
+	Size      string    `json:"size"`
+	Quantity  int       `gorm:"not null" json:"quantity"`
+	SavedDate time.Time `gorm:"autoCreateTime" json:"saved_date"`
+}
+
+// WishlistItem is a product a user wants but isn't ready to buy yet.
+type WishlistItem struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	ProductID uuid.UUID `gorm:"type:uuid;not null" json:"product_id"`
+	Product   Product   `json:"product"`
+	AddedDate time.Time `gorm:"autoCreateTime" json:"added_date"`
+}
+
+// WishlistShare records that a user has opted to publish a read-only link to
+// their wishlist, one row per user, keyed by the token embedded in that link.
+type WishlistShare struct {
+	UserID uint   `gorm:"primaryKey" json:"user_id"`
+	Token  string `gorm:"uniqueIndex;not null" json:"token"`
+}
+
+// Coupon is a promo code that can be applied to a cart for a percentage or
+// fixed-amount discount.
+type Coupon struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Code          string    `gorm:"uniqueIndex;not null" json:"code"`
+	Type          string    `gorm:"not null" json:"type"` // "percent" or "fixed"
+	Amount        float64   `gorm:"not null" json:"amount"`
+	MinOrderTotal float64   `gorm:"not null;default:0" json:"min_order_total"`
+	CategoryID    *uint     `json:"category_id"`
+	MaxUses       int       `gorm:"not null;default:0" json:"max_uses"` // 0 = unlimited
+	UsedCount     int       `gorm:"not null;default:0" json:"used_count"`
+	ValidFrom     time.Time `json:"valid_from"`
+	ValidUntil    time.Time `json:"valid_until"`
+	Active        bool      `gorm:"not null;default:true" json:"active"`
+	CreatedDate   time.Time `gorm:"autoCreateTime" json:"created_date"`
+}
+
+// DiscountFor returns the discount amount a coupon grants on subtotal.
+func (c Coupon) DiscountFor(subtotal float64) float64 {
+	if c.Type == "percent" {
+		return subtotal * c.Amount / 100
+	}
+	if c.Amount > subtotal {
+		return subtotal
+	}
+	return c.Amount
+}
+
+// CartCoupon records the single coupon currently applied to a user's cart.
+type CartCoupon struct {
+	UserID   uint      `gorm:"primaryKey" json:"user_id"`
+	CouponID uuid.UUID `gorm:"type:uuid;not null" json:"coupon_id"`
+	Coupon   Coupon    `json:"coupon"`
+}
+
+// CartShare is a point-in-time snapshot of a cart's lines, published under a
+// token so it can be reopened on another device or account - e.g. a
+// corporate buyer sharing a cart with a colleague, or support reproducing a
+// customer's cart. It intentionally does not link back to the owning cart:
+// once shared, the snapshot is frozen even if the original cart changes.
+type CartShare struct {
+	Token       string         `gorm:"primaryKey" json:"token"`
+	UserID      uint           `gorm:"not null;index" json:"user_id"`
+	Items       CartShareLines `gorm:"type:jsonb;not null" json:"items"`
+	CreatedDate time.Time      `gorm:"autoCreateTime" json:"created_date"`
+}
+
+// CartItem is a product/size/quantity a user intends to purchase.
+type CartItem struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	ProductID uuid.UUID `gorm:"type:uuid;not null" json:"product_id"`
+	Product   Product   `json:"product"`
+	Size      string    `json:"size"`
+	Quantity  int       `gorm:"not null" json:"quantity"`
+	// PriceAtAdd is the product's discounted price when the line was first
+	// created. It is never touched on later quantity updates, so List can
+	// diff it against the product's current price to flag drops/increases.
+	PriceAtAdd float64   `gorm:"not null;default:0" json:"price_at_add"`
+	AddedDate  time.Time `gorm:"autoCreateTime" json:"added_date"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// MarshalJSON adds the current price and how it compares to PriceAtAdd, so
+// clients can show a "price dropped/increased" notice without recomputing
+// it themselves. Carts created before PriceAtAdd existed have it as 0 and
+// are treated as unchanged rather than reporting a bogus 100% drop.
+func (i CartItem) MarshalJSON() ([]byte, error) {
+	type cartItemAlias CartItem
+
+	current := i.Product.DiscountedPrice()
+	direction := "none"
+	change := 0.0
+	if i.PriceAtAdd > 0 && current != i.PriceAtAdd {
+		change = current - i.PriceAtAdd
+		if change < 0 {
+			direction = "dropped"
+		} else {
+			direction = "increased"
+		}
+	}
+
+	return json.Marshal(struct {
+		cartItemAlias
+		CurrentPrice   float64 `json:"current_price"`
+		PriceChanged   bool    `json:"price_changed"`
+		PriceChange    float64 `json:"price_change"`
+		PriceDirection string  `json:"price_direction"`
+	}{
+		cartItemAlias:  cartItemAlias(i),
+		CurrentPrice:   current,
+		PriceChanged:   direction != "none",
+		PriceChange:    change,
+		PriceDirection: direction,
+	})
+}
+
+// ShopSettings is a singleton row (ID is always ShopSettingsID) holding
+// shop-wide configuration an admin can edit at runtime, as opposed to the
+// deploy-time config in internal/config.
+type ShopSettings struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+
+	Name         string `gorm:"not null" json:"name"`
+	Currency     string `gorm:"not null" json:"currency"`
+	SupportEmail string `json:"support_email"`
+
+	// MaintenanceMode is admin-only: it's not part of the public subset
+	// returned by GET /api/settings.
+	MaintenanceMode bool `gorm:"not null;default:false" json:"maintenance_mode"`
+}
+
+// ShopSettingsID is the fixed primary key of the single ShopSettings row.
+const ShopSettingsID = 1
+
+// FeatureFlag gates a new behavior behind a toggle and an optional
+// percentage rollout, so features can ship disabled and be dialed in
+// gradually without a redeploy.
+type FeatureFlag struct {
+	Key     string `gorm:"primaryKey" json:"key"`
+	Enabled bool   `gorm:"not null;default:false" json:"enabled"`
+	// RolloutPercent only matters once Enabled is true: 0 means nobody
+	// sees it yet, 100 means everybody does, anything between is a stable
+	// per-user split - see featureflag.Evaluate.
+	RolloutPercent int       `gorm:"not null;default:0" json:"rollout_percent"`
+	CreatedDate    time.Time `gorm:"autoCreateTime" json:"created_date"`
+}
+
+// Warehouse is a physical fulfillment location stock is tracked against.
+type Warehouse struct {
+	ID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Name   string    `gorm:"not null" json:"name"`
+	Region string    `gorm:"not null" json:"region"`
+	Active bool      `gorm:"not null;default:true" json:"active"`
+}
+
+// AuditLog records one admin mutation: who did it, what action, which
+// entity, the raw request payload, and where from. It has no foreign key
+// to the actor - the admin user it names may since have been deleted or
+// anonymized - so a log entry always outlives the account it describes.
+type AuditLog struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	ActorID    uint      `gorm:"index" json:"actor_id"`
+	Action     string    `gorm:"not null;index" json:"action"` // e.g. "PATCH /api/admin/orders/:id/status"
+	EntityType string    `gorm:"not null;index" json:"entity_type"`
+	EntityID   string    `gorm:"index" json:"entity_id,omitempty"`
+	Payload    string    `json:"payload,omitempty"` // the request body, as sent
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+}
+
+// WarehouseStock is the quantity of a product held at a warehouse.
+// Product.StockQuantity remains the single number the storefront reads
+// today; WarehouseStock is the per-location breakdown behind it, summed by
+// WarehouseStockRepository.TotalForProduct for aggregate availability.
+type WarehouseStock struct {
+	WarehouseID uuid.UUID `gorm:"type:uuid;primaryKey" json:"warehouse_id"`
+	ProductID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"product_id"`
+	Quantity    int       `gorm:"not null;default:0" json:"quantity"`
+
+	Warehouse Warehouse `json:"warehouse"`
+}
+
+// ProductTranslation holds a Product's Name/Description in one locale
+// (e.g. "ru"), overlaid onto the base row for a request in that locale.
+// A product with no translation for the requester's locale falls back to
+// its own Name/Description, which are treated as the default locale's copy.
+type ProductTranslation struct {
+	ProductID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"product_id"`
+	Locale      string    `gorm:"primaryKey" json:"locale"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+}
+
+// CategoryTranslation holds a Category's Name in one locale, the same way
+// ProductTranslation does for products.
+type CategoryTranslation struct {
+	CategoryID uint   `gorm:"primaryKey" json:"category_id"`
+	Locale     string `gorm:"primaryKey" json:"locale"`
+	Name       string `json:"name"`
+}
+
+// AbandonedCartReminder tracks one reminder cycle for a user whose cart
+// service.AbandonedCartReminderService found sitting untouched.
+// RemindersSent/LastReminderDate cap how many reminders a user gets and how
+// often, CouponCode is the incentive attached to the cycle (generated once,
+// reused on later reminders in the same cycle), and ConvertedDate closes
+// the cycle out so it stops being reminded. Nothing in this codebase's
+// checkout flow calls MarkConverted yet, since there is no order-creation
+// endpoint here to hook it to - see
+// AbandonedCartReminderRepository.MarkConverted.
+type AbandonedCartReminder struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID           uint       `gorm:"not null;index" json:"user_id"`
+	RemindersSent    int        `gorm:"not null;default:0" json:"reminders_sent"`
+	LastReminderDate time.Time  `json:"last_reminder_date"`
+	CouponCode       string     `json:"coupon_code,omitempty"`
+	ConvertedDate    *time.Time `json:"converted_date,omitempty"`
+	CreatedDate      time.Time  `gorm:"autoCreateTime" json:"created_date"`
+}
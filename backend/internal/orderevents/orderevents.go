@@ -0,0 +1,60 @@
+// Package orderevents broadcasts order status changes over Redis pub/sub,
+// one channel per order, so the "track my order" page can stream updates
+// instead of polling. It follows the same publish/subscribe shape as
+// internal/cache's L1 invalidation channel, just keyed by order instead of
+// cache namespace.
+package orderevents
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Event is broadcast to an order's channel whenever its status changes,
+// whether from an admin action or a payment webhook.
+type Event struct {
+	OrderID       string `json:"order_id"`
+	Status        string `json:"status,omitempty"`
+	PaymentStatus string `json:"payment_status,omitempty"`
+}
+
+// Broker publishes and subscribes to order status events.
+type Broker struct {
+	client *redis.Client
+}
+
+// NewBroker builds a Broker around a Redis client.
+func NewBroker(client *redis.Client) *Broker {
+	return &Broker{client: client}
+}
+
+func channelFor(orderID uuid.UUID) string {
+	return "order-events:" + orderID.String()
+}
+
+// PublishStatus broadcasts an order's new Status.
+func (b *Broker) PublishStatus(ctx context.Context, orderID uuid.UUID, status string) error {
+	return b.publish(ctx, orderID, Event{OrderID: orderID.String(), Status: status})
+}
+
+// PublishPaymentStatus broadcasts an order's new PaymentStatus.
+func (b *Broker) PublishPaymentStatus(ctx context.Context, orderID uuid.UUID, paymentStatus string) error {
+	return b.publish(ctx, orderID, Event{OrderID: orderID.String(), PaymentStatus: paymentStatus})
+}
+
+func (b *Broker) publish(ctx context.Context, orderID uuid.UUID, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return b.client.Publish(ctx, channelFor(orderID), payload).Err()
+}
+
+// Subscribe returns a Redis subscription to orderID's event channel. The
+// caller is responsible for closing it.
+func (b *Broker) Subscribe(ctx context.Context, orderID uuid.UUID) *redis.PubSub {
+	return b.client.Subscribe(ctx, channelFor(orderID))
+}
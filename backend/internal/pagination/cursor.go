@@ -0,0 +1,51 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Cursor identifies a position in a keyset-paginated feed ordered by
+// (createdAt, id) descending - the shape a high-traffic listing uses in
+// place of OFFSET/LIMIT, since OFFSET degrades on deep pages.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// Encode serializes c into an opaque string safe to hand back to a client
+// as next_cursor.
+func (c Cursor) Encode() string {
+	raw := c.CreatedAt.Format(time.RFC3339Nano) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor previously produced by Cursor.Encode. An
+// empty string decodes to the zero Cursor, meaning "start from the top."
+func DecodeCursor(s string) (Cursor, error) {
+	if s == "" {
+		return Cursor{}, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("pagination: invalid cursor: %w", err)
+	}
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return Cursor{}, fmt.Errorf("pagination: invalid cursor")
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("pagination: invalid cursor: %w", err)
+	}
+	return Cursor{CreatedAt: parsed, ID: id}, nil
+}
+
+// CursorPage is the response envelope for a keyset-paginated page of
+// results. NextCursor is omitted once the feed is exhausted.
+type CursorPage[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
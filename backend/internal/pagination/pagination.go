@@ -0,0 +1,58 @@
+// Package pagination provides a page/per_page query param parser and a
+// response envelope shared by every paginated list endpoint (products,
+// orders, reviews, admin listings), so a client's pagination code doesn't
+// have to special-case each one.
+package pagination
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Page is the standard response envelope for a page of results.
+// NextPage/PrevPage are omitted at the ends of the result set, so a client
+// can tell it's on the first or last page without comparing Page to Total
+// itself.
+type Page[T any] struct {
+	Items    []T   `json:"items"`
+	Total    int64 `json:"total"`
+	Page     int   `json:"page"`
+	PerPage  int   `json:"per_page"`
+	NextPage *int  `json:"next_page,omitempty"`
+	PrevPage *int  `json:"prev_page,omitempty"`
+}
+
+// New builds a Page envelope from a page of items, the total row count
+// across every page, and the page/perPage that produced it.
+func New[T any](items []T, total int64, page, perPage int) Page[T] {
+	p := Page[T]{Items: items, Total: total, Page: page, PerPage: perPage}
+	if int64(page*perPage) < total {
+		next := page + 1
+		p.NextPage = &next
+	}
+	if page > 1 {
+		prev := page - 1
+		p.PrevPage = &prev
+	}
+	return p
+}
+
+// ParseParams reads "page" (1-based, default 1) and "page_size" (default
+// defaultPerPage, capped at maxPerPage) from the request's query string,
+// and returns them along with the offset a repository's Limit/Offset call
+// needs.
+func ParseParams(c *gin.Context, defaultPerPage, maxPerPage int) (page, perPage, offset int) {
+	page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ = strconv.Atoi(c.DefaultQuery("page_size", strconv.Itoa(defaultPerPage)))
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+	return page, perPage, (page - 1) * perPage
+}
@@ -0,0 +1,85 @@
+// Package payments defines the gateway-agnostic interface for taking
+// payment on an order, plus one or more concrete gateway implementations.
+package payments
+
+import "context"
+
+// CreatePaymentRequest describes the payment an order needs. SaveMethod
+// asks the gateway to tokenize the card used, so it can be reused later
+// without a hosted redirect. SavedMethodID, when set, charges an
+// already-tokenized card instead of starting a new hosted confirmation,
+// and ReturnURL/SaveMethod are ignored in that case.
+type CreatePaymentRequest struct {
+	OrderID       string
+	Amount        float64
+	Currency      string
+	Description   string
+	ReturnURL     string
+	SaveMethod    bool
+	SavedMethodID string
+}
+
+// CreatePaymentResult is what a Provider hands back after starting a
+// payment. ConfirmationURL is where the shopper is redirected to actually
+// pay, empty when the payment was charged directly against a saved
+// method. GatewayPaymentID identifies the payment for later
+// reconciliation (status polling, webhooks). SavedMethod is populated
+// when CreatePaymentRequest.SaveMethod was set and the gateway tokenized
+// the card used.
+type CreatePaymentResult struct {
+	GatewayPaymentID string
+	ConfirmationURL  string
+	Status           string
+	SavedMethod      *SavedMethod
+}
+
+// SavedMethod is a gateway-tokenized card, safe to persist and reuse -
+// it carries no PAN, only the token and enough detail to show the
+// customer which card it is.
+type SavedMethod struct {
+	GatewayMethodID string
+	Last4           string
+	ExpMonth        string
+	ExpYear         string
+}
+
+// RefundRequest describes a full or partial refund of a captured payment.
+type RefundRequest struct {
+	GatewayPaymentID string
+	Amount           float64
+	Currency         string
+}
+
+// RefundResult is what a Provider hands back after issuing a refund.
+type RefundResult struct {
+	GatewayRefundID string
+	Status          string
+}
+
+// WebhookEvent is a gateway notification reduced to the fields every
+// caller needs, regardless of provider-specific payload shape.
+type WebhookEvent struct {
+	GatewayPaymentID string
+	EventType        string
+	Status           string
+}
+
+// Provider is implemented by a payment gateway integration. A gateway that
+// doesn't support hosted redirect confirmation can leave ConfirmationURL
+// empty on the result.
+type Provider interface {
+	CreatePayment(ctx context.Context, req CreatePaymentRequest) (*CreatePaymentResult, error)
+
+	// CreateRefund issues a full or partial refund against an already
+	// captured payment.
+	CreateRefund(ctx context.Context, req RefundRequest) (*RefundResult, error)
+
+	// VerifySignature reports whether signature (as sent in the webhook
+	// request's signature header) is a valid signature of payload (the raw
+	// request body) for this gateway's shared secret.
+	VerifySignature(payload []byte, signature string) bool
+
+	// ParseWebhookEvent extracts the payment ID, event type and resulting
+	// status from a raw, already-verified webhook body.
+	ParseWebhookEvent(payload []byte) (WebhookEvent, error)
+}
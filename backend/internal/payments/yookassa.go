@@ -0,0 +1,217 @@
+package payments
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const yooKassaAPIBase = "https://api.yookassa.ru/v3"
+
+// YooKassaProvider creates payments through the YooKassa API
+// (https://yookassa.ru/developers/api). Every request carries a fresh
+// Idempotence-Key so retried requests never double-charge.
+type YooKassaProvider struct {
+	shopID    string
+	secretKey string
+	client    *http.Client
+}
+
+// NewYooKassaProvider builds a YooKassaProvider authenticating as shopID
+// with secretKey.
+func NewYooKassaProvider(shopID, secretKey string) *YooKassaProvider {
+	return &YooKassaProvider{
+		shopID:    shopID,
+		secretKey: secretKey,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type yooKassaAmount struct {
+	Value    string `json:"value"`
+	Currency string `json:"currency"`
+}
+
+type yooKassaConfirmation struct {
+	Type            string `json:"type"`
+	ReturnURL       string `json:"return_url,omitempty"`
+	ConfirmationURL string `json:"confirmation_url,omitempty"`
+}
+
+type yooKassaCreatePaymentBody struct {
+	Amount            yooKassaAmount        `json:"amount"`
+	Capture           bool                  `json:"capture"`
+	Confirmation      *yooKassaConfirmation `json:"confirmation,omitempty"`
+	Description       string                `json:"description,omitempty"`
+	Metadata          map[string]string     `json:"metadata,omitempty"`
+	SavePaymentMethod bool                  `json:"save_payment_method,omitempty"`
+	PaymentMethodID   string                `json:"payment_method_id,omitempty"`
+}
+
+type yooKassaPaymentMethod struct {
+	ID    string `json:"id"`
+	Saved bool   `json:"saved"`
+	Card  struct {
+		Last4       string `json:"last4"`
+		ExpiryMonth string `json:"expiry_month"`
+		ExpiryYear  string `json:"expiry_year"`
+	} `json:"card"`
+}
+
+type yooKassaPaymentResponse struct {
+	ID            string                 `json:"id"`
+	Status        string                 `json:"status"`
+	Confirmation  yooKassaConfirmation   `json:"confirmation"`
+	PaymentMethod *yooKassaPaymentMethod `json:"payment_method,omitempty"`
+}
+
+type yooKassaNotification struct {
+	Event  string `json:"event"`
+	Object struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	} `json:"object"`
+}
+
+// CreatePayment starts a YooKassa payment for req and returns its
+// confirmation URL and gateway ID. When req.SavedMethodID is set, it
+// charges that tokenized card directly instead of opening a hosted
+// confirmation; when req.SaveMethod is set instead, the card used for a
+// fresh, hosted payment is tokenized for later reuse.
+func (p *YooKassaProvider) CreatePayment(ctx context.Context, req CreatePaymentRequest) (*CreatePaymentResult, error) {
+	body := yooKassaCreatePaymentBody{
+		Amount:      yooKassaAmount{Value: fmt.Sprintf("%.2f", req.Amount), Currency: req.Currency},
+		Capture:     true,
+		Description: req.Description,
+		Metadata:    map[string]string{"order_id": req.OrderID},
+	}
+	if req.SavedMethodID != "" {
+		body.PaymentMethodID = req.SavedMethodID
+	} else {
+		body.Confirmation = &yooKassaConfirmation{Type: "redirect", ReturnURL: req.ReturnURL}
+		body.SavePaymentMethod = req.SaveMethod
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("yookassa: encode request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, yooKassaAPIBase+"/payments", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("yookassa: build request: %w", err)
+	}
+	httpReq.SetBasicAuth(p.shopID, p.secretKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Idempotence-Key", uuid.New().String())
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("yookassa: request payment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("yookassa: unexpected status %d", resp.StatusCode)
+	}
+
+	var parsed yooKassaPaymentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("yookassa: decode response: %w", err)
+	}
+
+	result := &CreatePaymentResult{
+		GatewayPaymentID: parsed.ID,
+		ConfirmationURL:  parsed.Confirmation.ConfirmationURL,
+		Status:           parsed.Status,
+	}
+	if req.SaveMethod && parsed.PaymentMethod != nil && parsed.PaymentMethod.Saved {
+		result.SavedMethod = &SavedMethod{
+			GatewayMethodID: parsed.PaymentMethod.ID,
+			Last4:           parsed.PaymentMethod.Card.Last4,
+			ExpMonth:        parsed.PaymentMethod.Card.ExpiryMonth,
+			ExpYear:         parsed.PaymentMethod.Card.ExpiryYear,
+		}
+	}
+	return result, nil
+}
+
+type yooKassaCreateRefundBody struct {
+	PaymentID string         `json:"payment_id"`
+	Amount    yooKassaAmount `json:"amount"`
+}
+
+type yooKassaRefundResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// CreateRefund issues a refund against an already captured payment.
+func (p *YooKassaProvider) CreateRefund(ctx context.Context, req RefundRequest) (*RefundResult, error) {
+	body := yooKassaCreateRefundBody{
+		PaymentID: req.GatewayPaymentID,
+		Amount:    yooKassaAmount{Value: fmt.Sprintf("%.2f", req.Amount), Currency: req.Currency},
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("yookassa: encode refund request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, yooKassaAPIBase+"/refunds", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("yookassa: build refund request: %w", err)
+	}
+	httpReq.SetBasicAuth(p.shopID, p.secretKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Idempotence-Key", uuid.New().String())
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("yookassa: request refund: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("yookassa: unexpected refund status %d", resp.StatusCode)
+	}
+
+	var parsed yooKassaRefundResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("yookassa: decode refund response: %w", err)
+	}
+
+	return &RefundResult{GatewayRefundID: parsed.ID, Status: parsed.Status}, nil
+}
+
+// VerifySignature checks an HMAC-SHA256-over-secretKey signature of the raw
+// webhook body, hex-encoded. YooKassa notifications are otherwise only
+// trustworthy by source IP; requiring this header lets the webhook be
+// exposed without an IP allowlist.
+func (p *YooKassaProvider) VerifySignature(payload []byte, signature string) bool {
+	mac := hmac.New(sha256.New, []byte(p.secretKey))
+	mac.Write(payload)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// ParseWebhookEvent decodes a "payment.*" notification body.
+func (p *YooKassaProvider) ParseWebhookEvent(payload []byte) (WebhookEvent, error) {
+	var notification yooKassaNotification
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		return WebhookEvent{}, fmt.Errorf("yookassa: decode webhook event: %w", err)
+	}
+	return WebhookEvent{
+		GatewayPaymentID: notification.Object.ID,
+		EventType:        notification.Event,
+		Status:           notification.Object.Status,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package payments
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func sign(secretKey string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestYooKassaVerifySignature(t *testing.T) {
+	p := NewYooKassaProvider("shop-id", "secret-key")
+	payload := []byte(`{"event":"payment.succeeded","object":{"id":"pay-1","status":"succeeded"}}`)
+
+	if !p.VerifySignature(payload, sign("secret-key", payload)) {
+		t.Error("valid signature was rejected")
+	}
+	if p.VerifySignature(payload, sign("wrong-key", payload)) {
+		t.Error("signature computed with the wrong key was accepted")
+	}
+	if p.VerifySignature([]byte(`{"event":"tampered"}`), sign("secret-key", payload)) {
+		t.Error("signature for a different payload was accepted")
+	}
+	if p.VerifySignature(payload, "not-hex-at-all") {
+		t.Error("malformed signature was accepted")
+	}
+}
+
+func TestYooKassaParseWebhookEvent(t *testing.T) {
+	p := NewYooKassaProvider("shop-id", "secret-key")
+	payload := []byte(`{"event":"payment.succeeded","object":{"id":"pay-1","status":"succeeded"}}`)
+
+	event, err := p.ParseWebhookEvent(payload)
+	if err != nil {
+		t.Fatalf("ParseWebhookEvent: %v", err)
+	}
+	want := WebhookEvent{GatewayPaymentID: "pay-1", EventType: "payment.succeeded", Status: "succeeded"}
+	if event != want {
+		t.Errorf("event = %+v, want %+v", event, want)
+	}
+}
+
+func TestYooKassaParseWebhookEventRejectsMalformedPayload(t *testing.T) {
+	p := NewYooKassaProvider("shop-id", "secret-key")
+	if _, err := p.ParseWebhookEvent([]byte("not json")); err == nil {
+		t.Fatal("expected an error for malformed JSON, got nil")
+	}
+}
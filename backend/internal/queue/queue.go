@@ -0,0 +1,76 @@
+// Package queue provides a minimal in-process async job runner used for
+// work that should not block the HTTP request that triggered it.
+package queue
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Status is the lifecycle state of a queued job.
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+// Job tracks the state and result of a single asynchronous unit of work.
+type Job struct {
+	ID     string      `json:"id"`
+	Status Status      `json:"status"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// Queue runs enqueued functions on background goroutines and keeps their
+// results available for later polling by ID.
+type Queue struct {
+	mu   sync.RWMutex
+	jobs map[string]*Job
+}
+
+// New builds an empty Queue.
+func New() *Queue {
+	return &Queue{jobs: make(map[string]*Job)}
+}
+
+// Enqueue registers a new job and runs fn in the background, recording
+// whatever it returns (or the error it fails with) against the job ID.
+func (q *Queue) Enqueue(fn func() (interface{}, error)) string {
+	id := uuid.New().String()
+
+	q.mu.Lock()
+	q.jobs[id] = &Job{ID: id, Status: StatusPending}
+	q.mu.Unlock()
+
+	go func() {
+		result, err := fn()
+
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		job := q.jobs[id]
+		if err != nil {
+			job.Status = StatusFailed
+			job.Error = err.Error()
+			return
+		}
+		job.Status = StatusDone
+		job.Result = result
+	}()
+
+	return id
+}
+
+// Get returns the current state of a job, or false if the ID is unknown.
+func (q *Queue) Get(id string) (Job, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	job, ok := q.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *job, true
+}
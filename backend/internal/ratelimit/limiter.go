@@ -0,0 +1,102 @@
+// Package ratelimit implements a Redis-backed token bucket, so rate limits
+// are shared across every instance of this service rather than tracked
+// per-process.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// bucketScript refills a bucket continuously (rather than resetting it at a
+// fixed window boundary) and atomically takes one token if available. It
+// reads the current time from Redis itself (TIME) instead of trusting the
+// caller's clock, so buckets stay consistent even if app servers drift.
+var bucketScript = redis.NewScript(`
+local key = KEYS[1]
+local capacity = tonumber(ARGV[1])
+local refill_per_sec = tonumber(ARGV[2])
+local requested = tonumber(ARGV[3])
+
+local bucket = redis.call('HMGET', key, 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+
+local time_parts = redis.call('TIME')
+local now_ms = tonumber(time_parts[1]) * 1000 + math.floor(tonumber(time_parts[2]) / 1000)
+
+if tokens == nil then
+	tokens = capacity
+	ts = now_ms
+end
+
+local elapsed_ms = math.max(0, now_ms - ts)
+tokens = math.min(capacity, tokens + (elapsed_ms / 1000) * refill_per_sec)
+
+local allowed = 0
+if tokens >= requested then
+	tokens = tokens - requested
+	allowed = 1
+end
+
+local ttl = math.ceil(capacity / refill_per_sec) + 1
+redis.call('HSET', key, 'tokens', tostring(tokens), 'ts', tostring(now_ms))
+redis.call('EXPIRE', key, ttl)
+
+return {allowed, tostring(tokens), tostring(now_ms)}
+`)
+
+// Limiter checks and consumes tokens from Redis-backed buckets.
+type Limiter struct {
+	client *redis.Client
+}
+
+// NewLimiter builds a Limiter around an open Redis client.
+func NewLimiter(client *redis.Client) *Limiter {
+	return &Limiter{client: client}
+}
+
+// Result is the outcome of one Allow call.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// Allow takes one token from the bucket identified by key, which refills at
+// refillPerSecond up to capacity tokens. It reports whether the request
+// should proceed, along with the values needed for the standard
+// X-RateLimit-* / Retry-After response headers.
+func (l *Limiter) Allow(ctx context.Context, key string, capacity int, refillPerSecond float64) (Result, error) {
+	reply, err := bucketScript.Run(ctx, l.client, []string{key}, capacity, refillPerSecond, 1).Slice()
+	if err != nil {
+		return Result{}, err
+	}
+
+	allowed := reply[0].(int64) == 1
+	tokensRemaining, err := strconv.ParseFloat(reply[1].(string), 64)
+	if err != nil {
+		return Result{}, err
+	}
+
+	remaining := int(math.Floor(tokensRemaining))
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	result := Result{
+		Allowed:   allowed,
+		Limit:     capacity,
+		Remaining: remaining,
+	}
+	if !allowed {
+		deficit := 1 - tokensRemaining
+		result.RetryAfter = time.Duration(deficit/refillPerSecond*1000) * time.Millisecond
+	}
+	return result, nil
+}
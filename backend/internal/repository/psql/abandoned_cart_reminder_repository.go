@@ -0,0 +1,70 @@
+package psql
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AbandonedCartReminderRepository tracks the reminder cycles
+// service.AbandonedCartReminderService runs against abandoned carts.
+type AbandonedCartReminderRepository struct {
+	db *gorm.DB
+}
+
+// NewAbandonedCartReminderRepository builds an
+// AbandonedCartReminderRepository around an open GORM connection.
+func NewAbandonedCartReminderRepository(db *gorm.DB) *AbandonedCartReminderRepository {
+	return &AbandonedCartReminderRepository{db: db}
+}
+
+// GetOpen returns userID's in-progress reminder cycle, or
+// gorm.ErrRecordNotFound if userID has none in progress.
+func (r *AbandonedCartReminderRepository) GetOpen(ctx context.Context, userID uint) (*models.AbandonedCartReminder, error) {
+	var reminder models.AbandonedCartReminder
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND converted_date IS NULL", userID).
+		Order("created_date DESC").
+		First(&reminder).Error
+	if err != nil {
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+// Create inserts a new reminder cycle, generating its ID if unset.
+func (r *AbandonedCartReminderRepository) Create(ctx context.Context, reminder *models.AbandonedCartReminder) error {
+	if reminder.ID == uuid.Nil {
+		reminder.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(reminder).Error
+}
+
+// RecordSent bumps RemindersSent and LastReminderDate for one reminder
+// email having gone out. couponCode is only written when this send is the
+// one that generated the cycle's coupon; pass "" on later sends that reuse
+// the coupon already on the row.
+func (r *AbandonedCartReminderRepository) RecordSent(ctx context.Context, id uuid.UUID, couponCode string) error {
+	updates := map[string]interface{}{
+		"reminders_sent":     gorm.Expr("reminders_sent + 1"),
+		"last_reminder_date": time.Now(),
+	}
+	if couponCode != "" {
+		updates["coupon_code"] = couponCode
+	}
+	return r.db.WithContext(ctx).Model(&models.AbandonedCartReminder{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// MarkConverted closes userID's open reminder cycle once they complete an
+// order, so ListAbandoned won't pick their cart back up mid-cycle and
+// AbandonedCartReminderService stops emailing them. Nothing in this
+// codebase's checkout flow calls this yet - there is no order-creation
+// endpoint here to hook it to.
+func (r *AbandonedCartReminderRepository) MarkConverted(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Model(&models.AbandonedCartReminder{}).
+		Where("user_id = ? AND converted_date IS NULL", userID).
+		Update("converted_date", time.Now()).Error
+}
@@ -0,0 +1,62 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AddressRepository provides access to a user's saved delivery addresses.
+type AddressRepository struct {
+	db *gorm.DB
+}
+
+// NewAddressRepository builds an AddressRepository around an open GORM connection.
+func NewAddressRepository(db *gorm.DB) *AddressRepository {
+	return &AddressRepository{db: db}
+}
+
+// Create inserts a new address, generating its ID if unset.
+func (r *AddressRepository) Create(ctx context.Context, address *models.Address) error {
+	if address.ID == uuid.Nil {
+		address.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(address).Error
+}
+
+// ListByUser returns every address a user has saved, newest first.
+func (r *AddressRepository) ListByUser(ctx context.Context, userID uint) ([]models.Address, error) {
+	var addresses []models.Address
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_date DESC").Find(&addresses).Error
+	return addresses, err
+}
+
+// GetByID fetches a single address, scoped to its owner so one user can
+// never read or act on another's address.
+func (r *AddressRepository) GetByID(ctx context.Context, userID uint, id string) (*models.Address, error) {
+	var address models.Address
+	if err := r.db.WithContext(ctx).First(&address, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		return nil, err
+	}
+	return &address, nil
+}
+
+// Update persists changes to an existing address.
+func (r *AddressRepository) Update(ctx context.Context, address *models.Address) error {
+	return r.db.WithContext(ctx).Save(address).Error
+}
+
+// Delete removes an address, scoped to its owner.
+func (r *AddressRepository) Delete(ctx context.Context, userID uint, id string) error {
+	return r.db.WithContext(ctx).Delete(&models.Address{}, "id = ? AND user_id = ?", id, userID).Error
+}
+
+// ClearDefault unsets IsDefault on every address a user has, so SetDefault
+// can then set it on exactly one.
+func (r *AddressRepository) ClearDefault(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Model(&models.Address{}).
+		Where("user_id = ?", userID).
+		Update("is_default", false).Error
+}
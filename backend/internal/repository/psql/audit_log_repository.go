@@ -0,0 +1,63 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository provides access to the admin audit log.
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository builds an AuditLogRepository around an open GORM
+// connection.
+func NewAuditLogRepository(db *gorm.DB) *AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+// Create records a new audit log entry, generating its ID if unset.
+func (r *AuditLogRepository) Create(ctx context.Context, log *models.AuditLog) error {
+	if log.ID == uuid.Nil {
+		log.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(log).Error
+}
+
+// AuditLogFilter narrows List to entries matching every non-zero field.
+type AuditLogFilter struct {
+	ActorID    uint
+	Action     string
+	EntityType string
+	EntityID   string
+}
+
+// List returns audit log entries matching filter, newest first, along with
+// the total count matching it (ignoring limit/offset) for pagination.
+func (r *AuditLogRepository) List(ctx context.Context, filter AuditLogFilter, limit, offset int) ([]models.AuditLog, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.AuditLog{})
+	if filter.ActorID != 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []models.AuditLog
+	err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&logs).Error
+	return logs, total, err
+}
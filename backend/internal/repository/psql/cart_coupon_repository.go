@@ -0,0 +1,39 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CartCouponRepository tracks the single coupon applied to a user's cart.
+type CartCouponRepository struct {
+	db *gorm.DB
+}
+
+// NewCartCouponRepository builds a CartCouponRepository around an open GORM connection.
+func NewCartCouponRepository(db *gorm.DB) *CartCouponRepository {
+	return &CartCouponRepository{db: db}
+}
+
+// Get returns the coupon currently applied to userID's cart, if any.
+func (r *CartCouponRepository) Get(ctx context.Context, userID uint) (*models.CartCoupon, error) {
+	var cc models.CartCoupon
+	if err := r.db.WithContext(ctx).Preload("Coupon").First(&cc, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	return &cc, nil
+}
+
+// Set applies couponID to userID's cart, replacing any previously applied coupon.
+func (r *CartCouponRepository) Set(ctx context.Context, userID uint, couponID uuid.UUID) error {
+	cc := models.CartCoupon{UserID: userID, CouponID: couponID}
+	return r.db.WithContext(ctx).Save(&cc).Error
+}
+
+// Clear removes any coupon applied to userID's cart.
+func (r *CartCouponRepository) Clear(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Delete(&models.CartCoupon{}, "user_id = ?", userID).Error
+}
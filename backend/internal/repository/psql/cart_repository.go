@@ -0,0 +1,96 @@
+package psql
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CartRepository provides access to a user's cart items.
+type CartRepository struct {
+	db *gorm.DB
+}
+
+// NewCartRepository builds a CartRepository around an open GORM connection.
+func NewCartRepository(db *gorm.DB) *CartRepository {
+	return &CartRepository{db: db}
+}
+
+// ListByUser returns all cart items for a user, with products preloaded.
+func (r *CartRepository) ListByUser(ctx context.Context, userID uint) ([]models.CartItem, error) {
+	var items []models.CartItem
+	err := r.db.WithContext(ctx).Preload("Product").Where("user_id = ?", userID).Find(&items).Error
+	return items, err
+}
+
+// GetByID fetches a single cart item owned by userID.
+func (r *CartRepository) GetByID(ctx context.Context, userID uint, id string) (*models.CartItem, error) {
+	var item models.CartItem
+	err := r.db.WithContext(ctx).Preload("Product").
+		First(&item, "id = ? AND user_id = ?", id, userID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// UpdateQuantity sets the quantity of a cart item owned by userID.
+func (r *CartRepository) UpdateQuantity(ctx context.Context, userID uint, id string, quantity int) error {
+	return r.db.WithContext(ctx).Model(&models.CartItem{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("quantity", quantity).Error
+}
+
+// Delete removes a single cart item owned by userID.
+func (r *CartRepository) Delete(ctx context.Context, userID uint, id string) error {
+	return r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.CartItem{}).Error
+}
+
+// Clear removes all cart items belonging to userID.
+func (r *CartRepository) Clear(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.CartItem{}).Error
+}
+
+// ListAbandoned returns cart items that have not been touched since before
+// cutoff, with products and owning users preloaded so the caller (e.g. an
+// admin report or a reminder job) doesn't need a second round trip.
+func (r *CartRepository) ListAbandoned(ctx context.Context, cutoff time.Time) ([]models.CartItem, error) {
+	var items []models.CartItem
+	err := r.db.WithContext(ctx).Preload("Product").
+		Where("updated_at < ?", cutoff).
+		Order("updated_at ASC").
+		Find(&items).Error
+	return items, err
+}
+
+// AddOrIncrement adds a product/size to the cart, or increases the quantity
+// of the matching line if it is already present. priceAtAdd is only
+// recorded when a new line is created; an existing line keeps the price it
+// was first added at so later price-drop detection has a stable baseline.
+func (r *CartRepository) AddOrIncrement(ctx context.Context, userID uint, productID uuid.UUID, size string, quantity int, priceAtAdd float64) error {
+	var existing models.CartItem
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND product_id = ? AND size = ?", userID, productID, size).
+		First(&existing).Error
+
+	switch {
+	case err == nil:
+		return r.db.WithContext(ctx).Model(&existing).
+			Update("quantity", existing.Quantity+quantity).Error
+	case err == gorm.ErrRecordNotFound:
+		item := &models.CartItem{
+			ID:         uuid.New(),
+			UserID:     userID,
+			ProductID:  productID,
+			Size:       size,
+			Quantity:   quantity,
+			PriceAtAdd: priceAtAdd,
+		}
+		return r.db.WithContext(ctx).Create(item).Error
+	default:
+		return err
+	}
+}
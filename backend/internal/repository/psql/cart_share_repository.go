@@ -0,0 +1,32 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"gorm.io/gorm"
+)
+
+// CartShareRepository stores shared cart snapshots.
+type CartShareRepository struct {
+	db *gorm.DB
+}
+
+// NewCartShareRepository builds a CartShareRepository around an open GORM connection.
+func NewCartShareRepository(db *gorm.DB) *CartShareRepository {
+	return &CartShareRepository{db: db}
+}
+
+// Create persists a new cart snapshot under token.
+func (r *CartShareRepository) Create(ctx context.Context, share *models.CartShare) error {
+	return r.db.WithContext(ctx).Create(share).Error
+}
+
+// GetByToken fetches a cart snapshot by its share token.
+func (r *CartShareRepository) GetByToken(ctx context.Context, token string) (*models.CartShare, error) {
+	var share models.CartShare
+	if err := r.db.WithContext(ctx).First(&share, "token = ?", token).Error; err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
@@ -0,0 +1,67 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+)
+
+// CategoryRepository provides access to product categories. Every method
+// takes the caller's context and threads it through WithContext, so a
+// client disconnecting or a handler-level deadline cancels the underlying
+// query. Reads run against ReplicatedDB.Reader(); writes stay on the
+// primary.
+type CategoryRepository struct {
+	db *ReplicatedDB
+}
+
+// NewCategoryRepository builds a CategoryRepository around a ReplicatedDB.
+func NewCategoryRepository(db *ReplicatedDB) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+// List returns all categories.
+func (r *CategoryRepository) List(ctx context.Context) ([]models.Category, error) {
+	var categories []models.Category
+	err := r.db.Reader().WithContext(ctx).Find(&categories).Error
+	return categories, err
+}
+
+// GetByIDs returns the categories matching ids, in no particular order.
+// IDs with no matching row are simply omitted rather than erroring.
+func (r *CategoryRepository) GetByIDs(ctx context.Context, ids []uint) ([]models.Category, error) {
+	var categories []models.Category
+	err := r.db.Reader().WithContext(ctx).Where("id IN ?", ids).Find(&categories).Error
+	return categories, err
+}
+
+// Create inserts a new category.
+func (r *CategoryRepository) Create(ctx context.Context, category *models.Category) error {
+	return r.db.Primary.WithContext(ctx).Create(category).Error
+}
+
+// ListIndexable returns categories flagged as indexable, for the sitemap.
+func (r *CategoryRepository) ListIndexable(ctx context.Context) ([]models.Category, error) {
+	var categories []models.Category
+	err := r.db.Reader().WithContext(ctx).Where("indexable = ?", true).Find(&categories).Error
+	return categories, err
+}
+
+// SetIndexable flips whether a category appears in the sitemap.
+func (r *CategoryRepository) SetIndexable(ctx context.Context, id string, indexable bool) error {
+	return r.db.Primary.WithContext(ctx).Model(&models.Category{}).
+		Where("id = ?", id).
+		Update("indexable", indexable).Error
+}
+
+// SuggestNames returns up to limit category names starting with prefix
+// (case-insensitive), for search autocomplete.
+func (r *CategoryRepository) SuggestNames(ctx context.Context, prefix string, limit int) ([]string, error) {
+	var names []string
+	err := r.db.Reader().WithContext(ctx).Model(&models.Category{}).
+		Where("name ILIKE ?", prefix+"%").
+		Order("name ASC").
+		Limit(limit).
+		Pluck("name", &names).Error
+	return names, err
+}
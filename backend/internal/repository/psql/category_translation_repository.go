@@ -0,0 +1,54 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"gorm.io/gorm"
+)
+
+// CategoryTranslationRepository provides access to per-locale category
+// name overrides.
+type CategoryTranslationRepository struct {
+	db *gorm.DB
+}
+
+// NewCategoryTranslationRepository builds a CategoryTranslationRepository
+// around an open GORM connection.
+func NewCategoryTranslationRepository(db *gorm.DB) *CategoryTranslationRepository {
+	return &CategoryTranslationRepository{db: db}
+}
+
+// Upsert writes a category's translation for a locale, creating it if it
+// doesn't exist yet.
+func (r *CategoryTranslationRepository) Upsert(ctx context.Context, categoryID uint, locale, name string) error {
+	var existing models.CategoryTranslation
+	err := r.db.WithContext(ctx).
+		Where("category_id = ? AND locale = ?", categoryID, locale).
+		First(&existing).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		return r.db.WithContext(ctx).Create(&models.CategoryTranslation{
+			CategoryID: categoryID,
+			Locale:     locale,
+			Name:       name,
+		}).Error
+	case err != nil:
+		return err
+	}
+
+	existing.Name = name
+	return r.db.WithContext(ctx).Save(&existing).Error
+}
+
+// GetByCategoryAndLocale returns a category's translation for locale, or
+// gorm.ErrRecordNotFound if it hasn't been translated into it.
+func (r *CategoryTranslationRepository) GetByCategoryAndLocale(ctx context.Context, categoryID uint, locale string) (*models.CategoryTranslation, error) {
+	var translation models.CategoryTranslation
+	if err := r.db.WithContext(ctx).
+		Where("category_id = ? AND locale = ?", categoryID, locale).
+		First(&translation).Error; err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
@@ -0,0 +1,146 @@
+package psql
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CollectionRepository provides access to collections and the products
+// linked into them.
+type CollectionRepository struct {
+	db *gorm.DB
+}
+
+// NewCollectionRepository builds a CollectionRepository around an open GORM connection.
+func NewCollectionRepository(db *gorm.DB) *CollectionRepository {
+	return &CollectionRepository{db: db}
+}
+
+// Create adds a new collection, generating its ID if unset.
+func (r *CollectionRepository) Create(ctx context.Context, collection *models.Collection) error {
+	if collection.ID == uuid.Nil {
+		collection.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(collection).Error
+}
+
+// GetByID fetches a single collection by ID.
+func (r *CollectionRepository) GetByID(ctx context.Context, id string) (*models.Collection, error) {
+	var collection models.Collection
+	if err := r.db.WithContext(ctx).First(&collection, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// GetBySlug fetches a single collection by its public slug.
+func (r *CollectionRepository) GetBySlug(ctx context.Context, slug string) (*models.Collection, error) {
+	var collection models.Collection
+	if err := r.db.WithContext(ctx).First(&collection, "slug = ?", slug).Error; err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// List returns every collection, active or not, for admin management.
+func (r *CollectionRepository) List(ctx context.Context) ([]models.Collection, error) {
+	var collections []models.Collection
+	err := r.db.WithContext(ctx).Find(&collections).Error
+	return collections, err
+}
+
+// Update saves changes to an existing collection.
+func (r *CollectionRepository) Update(ctx context.Context, collection *models.Collection) error {
+	return r.db.WithContext(ctx).Save(collection).Error
+}
+
+// Delete removes a collection by ID, along with its product links.
+func (r *CollectionRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("collection_id = ?", id).Delete(&models.CollectionProduct{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Collection{}, "id = ?", id).Error
+	})
+}
+
+// ListDueToLaunch returns inactive collections whose LaunchDate has passed
+// and whose EndDate (if any) hasn't, for the scheduler to activate.
+func (r *CollectionRepository) ListDueToLaunch(ctx context.Context, asOf time.Time) ([]models.Collection, error) {
+	var collections []models.Collection
+	err := r.db.WithContext(ctx).
+		Where("is_active = ? AND launch_date IS NOT NULL AND launch_date <= ? AND (end_date IS NULL OR end_date > ?)", false, asOf, asOf).
+		Find(&collections).Error
+	return collections, err
+}
+
+// ListDueToRetire returns active collections whose EndDate has passed, for
+// the scheduler to deactivate.
+func (r *CollectionRepository) ListDueToRetire(ctx context.Context, asOf time.Time) ([]models.Collection, error) {
+	var collections []models.Collection
+	err := r.db.WithContext(ctx).
+		Where("is_active = ? AND end_date IS NOT NULL AND end_date <= ?", true, asOf).
+		Find(&collections).Error
+	return collections, err
+}
+
+// SetActive flips a collection's IsActive flag.
+func (r *CollectionRepository) SetActive(ctx context.Context, id uuid.UUID, active bool) error {
+	return r.db.WithContext(ctx).Model(&models.Collection{}).
+		Where("id = ?", id).
+		Update("is_active", active).Error
+}
+
+// AddProduct links a product into a collection. It is a no-op if the link
+// already exists.
+func (r *CollectionRepository) AddProduct(ctx context.Context, collectionID, productID uuid.UUID) error {
+	var existing models.CollectionProduct
+	err := r.db.WithContext(ctx).
+		Where("collection_id = ? AND product_id = ?", collectionID, productID).
+		First(&existing).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		return r.db.WithContext(ctx).Create(&models.CollectionProduct{CollectionID: collectionID, ProductID: productID}).Error
+	case err != nil:
+		return err
+	}
+	return nil
+}
+
+// RemoveProduct unlinks a product from a collection.
+func (r *CollectionRepository) RemoveProduct(ctx context.Context, collectionID, productID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("collection_id = ? AND product_id = ?", collectionID, productID).
+		Delete(&models.CollectionProduct{}).Error
+}
+
+// ListProducts returns a page of products linked into a collection, plus
+// the total number of linked products so callers can render a page count.
+func (r *CollectionRepository) ListProducts(ctx context.Context, collectionID string, limit, offset int) ([]models.Product, int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&models.CollectionProduct{}).
+		Where("collection_id = ?", collectionID).
+		Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var links []models.CollectionProduct
+	err := r.db.WithContext(ctx).
+		Preload("Product").Preload("Product.Category").
+		Where("collection_id = ?", collectionID).
+		Limit(limit).Offset(offset).
+		Find(&links).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	products := make([]models.Product, 0, len(links))
+	for _, link := range links {
+		products = append(products, link.Product)
+	}
+	return products, total, nil
+}
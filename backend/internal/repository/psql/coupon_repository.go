@@ -0,0 +1,69 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CouponRepository provides CRUD access to promo codes.
+type CouponRepository struct {
+	db *gorm.DB
+}
+
+// NewCouponRepository builds a CouponRepository around an open GORM connection.
+func NewCouponRepository(db *gorm.DB) *CouponRepository {
+	return &CouponRepository{db: db}
+}
+
+// Create inserts a new coupon, generating its ID if unset.
+func (r *CouponRepository) Create(ctx context.Context, coupon *models.Coupon) error {
+	if coupon.ID == uuid.Nil {
+		coupon.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(coupon).Error
+}
+
+// GetByCode fetches a coupon by its promo code.
+func (r *CouponRepository) GetByCode(ctx context.Context, code string) (*models.Coupon, error) {
+	var coupon models.Coupon
+	if err := r.db.WithContext(ctx).First(&coupon, "code = ?", code).Error; err != nil {
+		return nil, err
+	}
+	return &coupon, nil
+}
+
+// List returns all coupons, newest first.
+func (r *CouponRepository) List(ctx context.Context) ([]models.Coupon, error) {
+	var coupons []models.Coupon
+	err := r.db.WithContext(ctx).Order("created_date DESC").Find(&coupons).Error
+	return coupons, err
+}
+
+// Update persists changes to an existing coupon.
+func (r *CouponRepository) Update(ctx context.Context, coupon *models.Coupon) error {
+	return r.db.WithContext(ctx).Save(coupon).Error
+}
+
+// Delete removes a coupon by ID.
+func (r *CouponRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Delete(&models.Coupon{}, "id = ?", id).Error
+}
+
+// IncrementUsageIfUnderLimit atomically bumps a coupon's used_count, once
+// an order redeeming it is placed, but only if it hasn't already hit
+// max_uses (0 meaning unlimited). The check and the increment happen in
+// the same UPDATE, so two concurrent orders racing to redeem the last use
+// of a capped coupon can't both succeed - reports false, not an error, if
+// the coupon was already at its limit by the time this runs.
+func (r *CouponRepository) IncrementUsageIfUnderLimit(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.Coupon{}).
+		Where("id = ? AND (max_uses = 0 OR used_count < max_uses)", id).
+		UpdateColumn("used_count", gorm.Expr("used_count + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
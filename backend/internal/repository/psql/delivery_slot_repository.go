@@ -0,0 +1,74 @@
+package psql
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DeliverySlotRepository provides admin-managed CRUD for recurring delivery
+// slots, plus the booking rows that consume their per-date capacity.
+type DeliverySlotRepository struct {
+	db *gorm.DB
+}
+
+// NewDeliverySlotRepository builds a DeliverySlotRepository around an open GORM connection.
+func NewDeliverySlotRepository(db *gorm.DB) *DeliverySlotRepository {
+	return &DeliverySlotRepository{db: db}
+}
+
+// Create adds a new delivery slot.
+func (r *DeliverySlotRepository) Create(ctx context.Context, slot *models.DeliverySlot) error {
+	slot.ID = uuid.New()
+	return r.db.WithContext(ctx).Create(slot).Error
+}
+
+// GetByID fetches a single delivery slot.
+func (r *DeliverySlotRepository) GetByID(ctx context.Context, id string) (*models.DeliverySlot, error) {
+	var slot models.DeliverySlot
+	if err := r.db.WithContext(ctx).First(&slot, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &slot, nil
+}
+
+// List returns every configured delivery slot, enabled or not, for admin management.
+func (r *DeliverySlotRepository) List(ctx context.Context) ([]models.DeliverySlot, error) {
+	var slots []models.DeliverySlot
+	err := r.db.WithContext(ctx).Find(&slots).Error
+	return slots, err
+}
+
+// ListEnabledForWeekday returns every enabled slot that recurs on weekday.
+func (r *DeliverySlotRepository) ListEnabledForWeekday(ctx context.Context, weekday int) ([]models.DeliverySlot, error) {
+	var slots []models.DeliverySlot
+	err := r.db.WithContext(ctx).Where("weekday = ? AND enabled = ?", weekday, true).Find(&slots).Error
+	return slots, err
+}
+
+// Update saves changes to an existing delivery slot.
+func (r *DeliverySlotRepository) Update(ctx context.Context, slot *models.DeliverySlot) error {
+	return r.db.WithContext(ctx).Save(slot).Error
+}
+
+// Delete removes a delivery slot by ID.
+func (r *DeliverySlotRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Delete(&models.DeliverySlot{}, "id = ?", id).Error
+}
+
+// CountForDate returns how many bookings already claim a slot on a date.
+func (r *DeliverySlotRepository) CountForDate(ctx context.Context, slotID uuid.UUID, date time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.DeliverySlotBooking{}).
+		Where("slot_id = ? AND date = ?", slotID, date).Count(&count).Error
+	return count, err
+}
+
+// Book records an order's claim on a slot for a date, generating an ID.
+func (r *DeliverySlotRepository) Book(ctx context.Context, booking *models.DeliverySlotBooking) error {
+	booking.ID = uuid.New()
+	return r.db.WithContext(ctx).Create(booking).Error
+}
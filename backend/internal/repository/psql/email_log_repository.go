@@ -0,0 +1,29 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EmailLogRepository records the outcome of every email EmailService
+// attempts to send.
+type EmailLogRepository struct {
+	db *gorm.DB
+}
+
+// NewEmailLogRepository builds an EmailLogRepository around an open GORM
+// connection.
+func NewEmailLogRepository(db *gorm.DB) *EmailLogRepository {
+	return &EmailLogRepository{db: db}
+}
+
+// Create records a new email log entry, generating its ID if unset.
+func (r *EmailLogRepository) Create(ctx context.Context, log *models.EmailLog) error {
+	if log.ID == uuid.Nil {
+		log.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(log).Error
+}
@@ -0,0 +1,44 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"gorm.io/gorm"
+)
+
+// FeatureFlagRepository provides admin-managed CRUD for feature flags.
+type FeatureFlagRepository struct {
+	db *gorm.DB
+}
+
+// NewFeatureFlagRepository builds a FeatureFlagRepository around an open GORM connection.
+func NewFeatureFlagRepository(db *gorm.DB) *FeatureFlagRepository {
+	return &FeatureFlagRepository{db: db}
+}
+
+// List returns every configured feature flag.
+func (r *FeatureFlagRepository) List(ctx context.Context) ([]models.FeatureFlag, error) {
+	var flags []models.FeatureFlag
+	err := r.db.WithContext(ctx).Find(&flags).Error
+	return flags, err
+}
+
+// GetByKey fetches a single feature flag by its key.
+func (r *FeatureFlagRepository) GetByKey(ctx context.Context, key string) (*models.FeatureFlag, error) {
+	var flag models.FeatureFlag
+	if err := r.db.WithContext(ctx).First(&flag, "key = ?", key).Error; err != nil {
+		return nil, err
+	}
+	return &flag, nil
+}
+
+// Set creates or overwrites the feature flag identified by flag.Key.
+func (r *FeatureFlagRepository) Set(ctx context.Context, flag *models.FeatureFlag) error {
+	return r.db.WithContext(ctx).Save(flag).Error
+}
+
+// Delete removes a feature flag by key.
+func (r *FeatureFlagRepository) Delete(ctx context.Context, key string) error {
+	return r.db.WithContext(ctx).Delete(&models.FeatureFlag{}, "key = ?", key).Error
+}
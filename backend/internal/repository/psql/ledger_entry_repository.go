@@ -0,0 +1,60 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// LedgerEntryRepository provides access to double-entry bookkeeping rows.
+type LedgerEntryRepository struct {
+	db *gorm.DB
+}
+
+// NewLedgerEntryRepository builds a LedgerEntryRepository around an open GORM connection.
+func NewLedgerEntryRepository(db *gorm.DB) *LedgerEntryRepository {
+	return &LedgerEntryRepository{db: db}
+}
+
+// Record inserts a set of ledger entries - normally a debit and its
+// matching credit - generating IDs where unset.
+func (r *LedgerEntryRepository) Record(ctx context.Context, entries ...*models.LedgerEntry) error {
+	for _, entry := range entries {
+		if entry.ID == uuid.Nil {
+			entry.ID = uuid.New()
+		}
+	}
+	return r.db.WithContext(ctx).Create(&entries).Error
+}
+
+// ListByOrder fetches every ledger entry recorded against an order, oldest first.
+func (r *LedgerEntryRepository) ListByOrder(ctx context.Context, orderID uuid.UUID) ([]models.LedgerEntry, error) {
+	var entries []models.LedgerEntry
+	err := r.db.WithContext(ctx).Where("order_id = ?", orderID).Order("created_date asc").Find(&entries).Error
+	return entries, err
+}
+
+// AccountBalance summarizes one ledger account's activity for the
+// reconciliation report.
+type AccountBalance struct {
+	Account string  `json:"account"`
+	Debits  float64 `json:"debits"`
+	Credits float64 `json:"credits"`
+}
+
+// Reconciliation sums debits and credits per account across every ledger
+// entry, so finance can confirm the books balance without touching mutable
+// order or payment rows.
+func (r *LedgerEntryRepository) Reconciliation(ctx context.Context) ([]AccountBalance, error) {
+	var balances []AccountBalance
+	err := r.db.WithContext(ctx).Model(&models.LedgerEntry{}).
+		Select("account, "+
+			"COALESCE(SUM(CASE WHEN entry_type = ? THEN amount ELSE 0 END), 0) AS debits, "+
+			"COALESCE(SUM(CASE WHEN entry_type = ? THEN amount ELSE 0 END), 0) AS credits",
+			models.LedgerEntryDebit, models.LedgerEntryCredit).
+		Group("account").
+		Scan(&balances).Error
+	return balances, err
+}
@@ -0,0 +1,41 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NotificationLogRepository records the outcome of every channel
+// NotificationService dispatches an event to.
+type NotificationLogRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationLogRepository builds a NotificationLogRepository around
+// an open GORM connection.
+func NewNotificationLogRepository(db *gorm.DB) *NotificationLogRepository {
+	return &NotificationLogRepository{db: db}
+}
+
+// Create records a new notification log entry, generating its ID if unset.
+func (r *NotificationLogRepository) Create(ctx context.Context, log *models.NotificationLog) error {
+	if log.ID == uuid.Nil {
+		log.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(log).Error
+}
+
+// ListByUser returns a page of userID's notification history, newest first.
+func (r *NotificationLogRepository) ListByUser(ctx context.Context, userID uint, limit, offset int) ([]models.NotificationLog, int64, error) {
+	var logs []models.NotificationLog
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&models.NotificationLog{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).
+		Order("created_date DESC").Limit(limit).Offset(offset).Find(&logs).Error
+	return logs, total, err
+}
@@ -0,0 +1,421 @@
+package psql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/apperr"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrderRepository provides access to orders and their line items.
+type OrderRepository struct {
+	db *gorm.DB
+}
+
+// NewOrderRepository builds an OrderRepository around an open GORM connection.
+func NewOrderRepository(db *gorm.DB) *OrderRepository {
+	return &OrderRepository{db: db}
+}
+
+// Create inserts a new order, generating its ID if unset.
+func (r *OrderRepository) Create(ctx context.Context, order *models.Order) error {
+	if order.ID == uuid.Nil {
+		order.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(order).Error
+}
+
+// GetByID fetches an order with its items and their products preloaded.
+func (r *OrderRepository) GetByID(ctx context.Context, id string) (*models.Order, error) {
+	var order models.Order
+	err := r.db.WithContext(ctx).
+		Preload("Items").Preload("Items.Product").Preload("Payments").
+		First(&order, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// ListByUser returns every order a user has placed, with items and their
+// products preloaded, newest first.
+func (r *OrderRepository) ListByUser(ctx context.Context, userID uint) ([]models.Order, error) {
+	var orders []models.Order
+	err := r.db.WithContext(ctx).
+		Preload("Items").Preload("Items.Product").
+		Where("user_id = ?", userID).
+		Order("created_date desc").
+		Find(&orders).Error
+	return orders, err
+}
+
+// OrderStats summarizes a customer's order history for their account page.
+type OrderStats struct {
+	OrderCount int     `json:"order_count"`
+	TotalSpent float64 `json:"total_spent"`
+}
+
+// Stats aggregates a customer's order count and total spend in one query.
+func (r *OrderRepository) Stats(ctx context.Context, userID uint) (*OrderStats, error) {
+	var stats OrderStats
+	err := r.db.WithContext(ctx).Model(&models.Order{}).
+		Select("COUNT(*) AS order_count, COALESCE(SUM(total_amount), 0) AS total_spent").
+		Where("user_id = ?", userID).
+		Scan(&stats).Error
+	return &stats, err
+}
+
+// FavoriteCategory is one entry in a customer's favorite-categories ranking.
+type FavoriteCategory struct {
+	CategoryID uint   `json:"category_id"`
+	Name       string `json:"name"`
+	ItemCount  int    `json:"item_count"`
+}
+
+// FavoriteCategories ranks the categories a customer has bought from most,
+// by total item quantity purchased, capped at limit entries.
+func (r *OrderRepository) FavoriteCategories(ctx context.Context, userID uint, limit int) ([]FavoriteCategory, error) {
+	var favorites []FavoriteCategory
+	err := r.db.WithContext(ctx).
+		Table("order_items").
+		Select("categories.id AS category_id, categories.name AS name, SUM(order_items.quantity) AS item_count").
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Joins("JOIN products ON products.id = order_items.product_id").
+		Joins("JOIN categories ON categories.id = products.category_id").
+		Where("orders.user_id = ?", userID).
+		Group("categories.id, categories.name").
+		Order("item_count DESC").
+		Limit(limit).
+		Scan(&favorites).Error
+	return favorites, err
+}
+
+// HasDeliveredPurchase reports whether userID has a delivered order
+// containing productID, the bar for a review to be marked verified.
+func (r *OrderRepository) HasDeliveredPurchase(ctx context.Context, userID uint, productID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.OrderItem{}).
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Where("orders.user_id = ? AND orders.status = ? AND order_items.product_id = ?", userID, models.OrderStatusDelivered, productID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// PeriodStats summarizes order volume and revenue over a time window, for
+// the admin dashboard's "today"/"this week" tiles.
+type PeriodStats struct {
+	OrderCount   int64   `json:"order_count"`
+	Revenue      float64 `json:"revenue"`
+	AverageOrder float64 `json:"average_order_value"`
+}
+
+// StatsSince aggregates order count, revenue and average order value for
+// paid orders created at or after since, in one query.
+func (r *OrderRepository) StatsSince(ctx context.Context, since time.Time) (*PeriodStats, error) {
+	var stats PeriodStats
+	err := r.db.WithContext(ctx).Model(&models.Order{}).
+		Select("COUNT(*) AS order_count, COALESCE(SUM(total_amount), 0) AS revenue, COALESCE(AVG(total_amount), 0) AS average_order").
+		Where("created_date >= ? AND payment_status = ?", since, models.PaymentStatusPaid).
+		Scan(&stats).Error
+	return &stats, err
+}
+
+// validSalesReportGroupBy whitelists the DATE_TRUNC field SalesReport
+// interpolates into its query, since it isn't a bind parameter.
+var validSalesReportGroupBy = map[string]bool{"day": true, "week": true, "month": true}
+
+// SalesReportRow is one bucket of a sales report.
+type SalesReportRow struct {
+	Period     time.Time `json:"period"`
+	OrderCount int64     `json:"order_count"`
+	Revenue    float64   `json:"revenue"`
+	UnitsSold  int64     `json:"units_sold"`
+}
+
+// SalesReport aggregates revenue, order counts and units sold for paid
+// orders in [from, to], bucketed by groupBy ("day", "week" or "month"),
+// entirely in SQL so a wide date range doesn't mean loading every order
+// into memory. Revenue/order count and units sold come from two separate
+// grouped aggregates - joining order_items in for units sold and summing
+// orders.total_amount over the joined rows would double-count revenue for
+// any order with more than one line item - merged here by period.
+func (r *OrderRepository) SalesReport(ctx context.Context, from, to time.Time, groupBy string) ([]SalesReportRow, error) {
+	if !validSalesReportGroupBy[groupBy] {
+		return nil, fmt.Errorf("psql: invalid group_by %q", groupBy)
+	}
+
+	type orderBucket struct {
+		Period     time.Time
+		OrderCount int64
+		Revenue    float64
+	}
+	var orderBuckets []orderBucket
+	err := r.db.WithContext(ctx).Model(&models.Order{}).
+		Select(fmt.Sprintf("DATE_TRUNC('%s', created_date) AS period, COUNT(*) AS order_count, COALESCE(SUM(total_amount), 0) AS revenue", groupBy)).
+		Where("created_date BETWEEN ? AND ? AND payment_status = ?", from, to, models.PaymentStatusPaid).
+		Group("period").
+		Order("period ASC").
+		Scan(&orderBuckets).Error
+	if err != nil {
+		return nil, err
+	}
+
+	type unitsBucket struct {
+		Period    time.Time
+		UnitsSold int64
+	}
+	var unitsBuckets []unitsBucket
+	err = r.db.WithContext(ctx).
+		Table("order_items").
+		Select(fmt.Sprintf("DATE_TRUNC('%s', orders.created_date) AS period, COALESCE(SUM(order_items.quantity), 0) AS units_sold", groupBy)).
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Where("orders.created_date BETWEEN ? AND ? AND orders.payment_status = ?", from, to, models.PaymentStatusPaid).
+		Group("period").
+		Scan(&unitsBuckets).Error
+	if err != nil {
+		return nil, err
+	}
+	unitsByPeriod := make(map[time.Time]int64, len(unitsBuckets))
+	for _, b := range unitsBuckets {
+		unitsByPeriod[b.Period] = b.UnitsSold
+	}
+
+	rows := make([]SalesReportRow, len(orderBuckets))
+	for i, b := range orderBuckets {
+		rows[i] = SalesReportRow{
+			Period:     b.Period,
+			OrderCount: b.OrderCount,
+			Revenue:    b.Revenue,
+			UnitsSold:  unitsByPeriod[b.Period],
+		}
+	}
+	return rows, nil
+}
+
+// TopProductRow is one line of a best-sellers report.
+type TopProductRow struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	UnitsSold   int64     `json:"units_sold"`
+	Revenue     float64   `json:"revenue"`
+}
+
+// TopProducts returns the best-selling products by units sold for paid
+// orders in [from, to], for merchandising decisions like what to feature or
+// restock. Revenue is order_items.price_at_time at the point of sale, not
+// the product's current price, so a later price change doesn't reshuffle a
+// historical report.
+func (r *OrderRepository) TopProducts(ctx context.Context, from, to time.Time, limit int) ([]TopProductRow, error) {
+	var rows []TopProductRow
+	err := r.db.WithContext(ctx).
+		Table("order_items").
+		Select("order_items.product_id AS product_id, order_items.product_name AS product_name, SUM(order_items.quantity) AS units_sold, COALESCE(SUM(order_items.quantity * order_items.price_at_time), 0) AS revenue").
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Where("orders.created_date BETWEEN ? AND ? AND orders.payment_status = ?", from, to, models.PaymentStatusPaid).
+		Group("order_items.product_id, order_items.product_name").
+		Order("units_sold DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// CoPurchasedProduct is one product frequently bought in the same order as
+// another, with how many paid orders contained both.
+type CoPurchasedProduct struct {
+	ProductID      uuid.UUID `json:"product_id"`
+	CoProductID    uuid.UUID `json:"co_product_id"`
+	OrdersTogether int64     `json:"orders_together"`
+}
+
+// CoPurchasedProducts returns, for every pair of distinct products that
+// appeared together in at least one paid order placed since since, how
+// many orders contained both - the raw material for "customers also
+// bought," computed by RecommendationService and cached per product. Self-
+// joining order_items by order_id keeps this to one query rather than one
+// per product.
+func (r *OrderRepository) CoPurchasedProducts(ctx context.Context, since time.Time) ([]CoPurchasedProduct, error) {
+	var rows []CoPurchasedProduct
+	err := r.db.WithContext(ctx).
+		Table("order_items AS a").
+		Select("a.product_id AS product_id, b.product_id AS co_product_id, COUNT(DISTINCT a.order_id) AS orders_together").
+		Joins("JOIN order_items AS b ON b.order_id = a.order_id AND b.product_id != a.product_id").
+		Joins("JOIN orders ON orders.id = a.order_id").
+		Where("orders.created_date >= ? AND orders.payment_status = ?", since, models.PaymentStatusPaid).
+		Group("a.product_id, b.product_id").
+		Order("a.product_id, orders_together DESC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// CategoryRevenueRow is one line of a revenue-by-category report.
+type CategoryRevenueRow struct {
+	CategoryID   uint    `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	UnitsSold    int64   `json:"units_sold"`
+	Revenue      float64 `json:"revenue"`
+}
+
+// CategoryRevenue aggregates units sold and revenue by category for paid
+// orders in [from, to]. Categories are resolved through products.category_id
+// rather than order_items' own snapshot, since order items don't snapshot a
+// category, so an item bought before a product was recategorized reports
+// under its current category, not the one at sale time.
+func (r *OrderRepository) CategoryRevenue(ctx context.Context, from, to time.Time) ([]CategoryRevenueRow, error) {
+	var rows []CategoryRevenueRow
+	err := r.db.WithContext(ctx).
+		Table("order_items").
+		Select("categories.id AS category_id, categories.name AS category_name, SUM(order_items.quantity) AS units_sold, COALESCE(SUM(order_items.quantity * order_items.price_at_time), 0) AS revenue").
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Joins("JOIN products ON products.id = order_items.product_id").
+		Joins("JOIN categories ON categories.id = products.category_id").
+		Where("orders.created_date BETWEEN ? AND ? AND orders.payment_status = ?", from, to, models.PaymentStatusPaid).
+		Group("categories.id, categories.name").
+		Order("revenue DESC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// CustomerStatsRow is one customer's lifetime purchase summary, for
+// segmentation and lifetime-value reporting.
+type CustomerStatsRow struct {
+	UserID        uint      `json:"user_id"`
+	Email         string    `json:"email"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	OrderCount    int64     `json:"order_count"`
+	TotalSpend    float64   `json:"total_spend"`
+	LastOrderDate time.Time `json:"last_order_date"`
+}
+
+// CustomerStats aggregates order count, lifetime spend and last order date
+// per customer, across every paid order, ordered by total spend descending.
+// It only covers customers with at least one paid order - someone who has
+// never bought anything has nothing to segment.
+func (r *OrderRepository) CustomerStats(ctx context.Context, limit, offset int) ([]CustomerStatsRow, int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&models.Order{}).
+		Where("payment_status = ?", models.PaymentStatusPaid).
+		Distinct("user_id").
+		Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []CustomerStatsRow
+	err := r.db.WithContext(ctx).
+		Table("orders").
+		Select("users.id AS user_id, users.email AS email, users.first_name AS first_name, users.last_name AS last_name, "+
+			"COUNT(orders.id) AS order_count, COALESCE(SUM(orders.total_amount), 0) AS total_spend, MAX(orders.created_date) AS last_order_date").
+		Joins("JOIN users ON users.id = orders.user_id").
+		Where("orders.payment_status = ?", models.PaymentStatusPaid).
+		Group("users.id, users.email, users.first_name, users.last_name").
+		Order("total_spend DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error
+	return rows, total, err
+}
+
+// ListBetween returns orders created within [from, to], oldest first, with
+// their line items and products preloaded for reporting purposes.
+func (r *OrderRepository) ListBetween(ctx context.Context, from, to time.Time) ([]models.Order, error) {
+	var orders []models.Order
+	err := r.db.WithContext(ctx).
+		Preload("Items").Preload("Items.Product").
+		Where("created_date BETWEEN ? AND ?", from, to).
+		Order("created_date ASC").
+		Find(&orders).Error
+	return orders, err
+}
+
+// ListUnpaidOlderThan returns every order created before cutoff whose
+// payment never went through, for OrderCancellationService to cancel.
+// "Never went through" means still unpaid/pending/expired - a
+// cash-on-delivery order awaiting the driver to collect payment is
+// deliberately excluded, since it has no gateway payment to time out.
+func (r *OrderRepository) ListUnpaidOlderThan(ctx context.Context, cutoff time.Time) ([]models.Order, error) {
+	var orders []models.Order
+	err := r.db.WithContext(ctx).
+		Where("created_date < ? AND status != ? AND payment_status IN ?", cutoff, models.OrderStatusCancelled,
+			[]string{models.PaymentStatusUnpaid, models.PaymentStatusPending, models.PaymentStatusExpired}).
+		Order("created_date ASC").
+		Find(&orders).Error
+	return orders, err
+}
+
+// ListByTag returns orders carrying the given internal tag, newest first,
+// for the admin order list and warehouse pick list.
+func (r *OrderRepository) ListByTag(ctx context.Context, tag string) ([]models.Order, error) {
+	needle, err := json.Marshal([]string{tag})
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []models.Order
+	err = r.db.WithContext(ctx).
+		Preload("Items").Preload("Items.Product").
+		Where("tags::jsonb @> ?::jsonb", string(needle)).
+		Order("created_date DESC").
+		Find(&orders).Error
+	return orders, err
+}
+
+// UpdatePaymentStatus sets an order's payment status, e.g. once a gateway
+// confirms or rejects a payment.
+func (r *OrderRepository) UpdatePaymentStatus(ctx context.Context, id uuid.UUID, status string) error {
+	return r.db.WithContext(ctx).Model(&models.Order{}).
+		Where("id = ?", id).
+		Update("payment_status", status).Error
+}
+
+// UpdatePaymentMethod records which payment method an order was paid (or is
+// being paid) with, once the customer has chosen one at checkout.
+func (r *OrderRepository) UpdatePaymentMethod(ctx context.Context, id uuid.UUID, method string) error {
+	return r.db.WithContext(ctx).Model(&models.Order{}).
+		Where("id = ?", id).
+		Update("payment_method", method).Error
+}
+
+// SetPickupPoint records which carrier pickup point an order should be
+// delivered to instead of a home address.
+func (r *OrderRepository) SetPickupPoint(ctx context.Context, id uuid.UUID, pickupPointID uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&models.Order{}).
+		Where("id = ?", id).
+		Update("pickup_point_id", pickupPointID).Error
+}
+
+// SetDeliverySlot records which delivery slot and date an order booked.
+func (r *OrderRepository) SetDeliverySlot(ctx context.Context, id uuid.UUID, slotID uuid.UUID, date time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.Order{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"delivery_slot_id": slotID, "delivery_date": date}).Error
+}
+
+// UpdateStatus transitions an order's Status, using currentVersion as an
+// optimistic lock so two concurrent status changes (e.g. an admin marking
+// an order shipped while a cancellation lands) can't silently overwrite
+// each other. The loser gets back apperr.ErrConflict and should reload the
+// order before retrying.
+func (r *OrderRepository) UpdateStatus(ctx context.Context, id uuid.UUID, currentVersion int, status string) error {
+	result := r.db.WithContext(ctx).Model(&models.Order{}).
+		Where("id = ? AND version = ?", id, currentVersion).
+		Updates(map[string]interface{}{"status": status, "version": currentVersion + 1})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: order was modified by someone else, reload and retry", apperr.ErrConflict)
+	}
+	return nil
+}
+
+// UpdateAdminMeta sets the internal admin note and tags on an order.
+func (r *OrderRepository) UpdateAdminMeta(ctx context.Context, id string, adminNote string, tags []string) error {
+	return r.db.WithContext(ctx).Model(&models.Order{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"admin_note": adminNote, "tags": models.StringList(tags)}).Error
+}
@@ -0,0 +1,81 @@
+package psql
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OutboxEventRepository provides access to the transactional outbox.
+type OutboxEventRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxEventRepository builds an OutboxEventRepository around an open
+// GORM connection.
+func NewOutboxEventRepository(db *gorm.DB) *OutboxEventRepository {
+	return &OutboxEventRepository{db: db}
+}
+
+// Create records a new event, generating its ID if unset. Call this through
+// psql.WithTx alongside the write that produced the event, so the event is
+// only ever recorded if that write actually commits.
+func (r *OutboxEventRepository) Create(ctx context.Context, event *models.OutboxEvent) error {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	if event.Status == "" {
+		event.Status = models.OutboxStatusPending
+	}
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+// ListPending returns up to limit pending events, oldest first, locking the
+// rows it returns (SKIP LOCKED) so two relay instances polling concurrently
+// never grab the same event twice.
+func (r *OutboxEventRepository) ListPending(ctx context.Context, limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ?", models.OutboxStatusPending).
+		Order("created_date ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// MarkPublished records that an event was delivered.
+func (r *OutboxEventRepository) MarkPublished(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"status": models.OutboxStatusPublished, "published_date": &now}).Error
+}
+
+// MarkAttemptFailed records a failed delivery attempt, flipping the event to
+// OutboxStatusFailed once it's used up models.MaxOutboxAttempts so the relay
+// stops retrying it forever; otherwise it stays pending for the next poll.
+func (r *OutboxEventRepository) MarkAttemptFailed(ctx context.Context, id uuid.UUID, attempts int, lastErr string) error {
+	status := models.OutboxStatusPending
+	if attempts >= models.MaxOutboxAttempts {
+		status = models.OutboxStatusFailed
+	}
+	return r.db.WithContext(ctx).Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"status": status, "attempts": attempts, "last_error": lastErr}).Error
+}
+
+// DeleteSettledOlderThan removes published or permanently-failed events
+// created before cutoff, keeping the outbox table from growing without
+// bound once entries have nothing left for OutboxRelayService to do with
+// them. Pending events are never touched regardless of age.
+func (r *OutboxEventRepository) DeleteSettledOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("created_date < ? AND status IN ?", cutoff, []string{models.OutboxStatusPublished, models.OutboxStatusFailed}).
+		Delete(&models.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,51 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"gorm.io/gorm"
+)
+
+// PaymentMethodRepository provides admin-managed CRUD for checkout payment methods.
+type PaymentMethodRepository struct {
+	db *gorm.DB
+}
+
+// NewPaymentMethodRepository builds a PaymentMethodRepository around an open GORM connection.
+func NewPaymentMethodRepository(db *gorm.DB) *PaymentMethodRepository {
+	return &PaymentMethodRepository{db: db}
+}
+
+// ListEnabled returns every enabled payment method, for checkout.
+func (r *PaymentMethodRepository) ListEnabled(ctx context.Context) ([]models.PaymentMethod, error) {
+	var methods []models.PaymentMethod
+	err := r.db.WithContext(ctx).Where("enabled = ?", true).Find(&methods).Error
+	return methods, err
+}
+
+// List returns every configured payment method, enabled or not, for admin management.
+func (r *PaymentMethodRepository) List(ctx context.Context) ([]models.PaymentMethod, error) {
+	var methods []models.PaymentMethod
+	err := r.db.WithContext(ctx).Find(&methods).Error
+	return methods, err
+}
+
+// GetByKey fetches a single payment method by its key.
+func (r *PaymentMethodRepository) GetByKey(ctx context.Context, key string) (*models.PaymentMethod, error) {
+	var method models.PaymentMethod
+	if err := r.db.WithContext(ctx).First(&method, "key = ?", key).Error; err != nil {
+		return nil, err
+	}
+	return &method, nil
+}
+
+// Set creates or overwrites the payment method identified by method.Key.
+func (r *PaymentMethodRepository) Set(ctx context.Context, method *models.PaymentMethod) error {
+	return r.db.WithContext(ctx).Save(method).Error
+}
+
+// Delete removes a payment method by key.
+func (r *PaymentMethodRepository) Delete(ctx context.Context, key string) error {
+	return r.db.WithContext(ctx).Delete(&models.PaymentMethod{}, "key = ?", key).Error
+}
@@ -0,0 +1,60 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PaymentRepository provides access to payment attempts against orders.
+type PaymentRepository struct {
+	db *gorm.DB
+}
+
+// NewPaymentRepository builds a PaymentRepository around an open GORM connection.
+func NewPaymentRepository(db *gorm.DB) *PaymentRepository {
+	return &PaymentRepository{db: db}
+}
+
+// Create inserts a new payment attempt, generating its ID if unset.
+func (r *PaymentRepository) Create(ctx context.Context, payment *models.Payment) error {
+	if payment.ID == uuid.Nil {
+		payment.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(payment).Error
+}
+
+// GetByID fetches a single payment attempt by ID.
+func (r *PaymentRepository) GetByID(ctx context.Context, id string) (*models.Payment, error) {
+	var payment models.Payment
+	if err := r.db.WithContext(ctx).First(&payment, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// GetByGatewayID fetches the payment attempt with the given gateway payment ID.
+func (r *PaymentRepository) GetByGatewayID(ctx context.Context, gatewayPaymentID string) (*models.Payment, error) {
+	var payment models.Payment
+	if err := r.db.WithContext(ctx).First(&payment, "gateway_payment_id = ?", gatewayPaymentID).Error; err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// CountByOrder returns how many payment attempts have been made against
+// orderID, including failed and expired ones, to enforce a retry cap.
+func (r *PaymentRepository) CountByOrder(ctx context.Context, orderID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Payment{}).Where("order_id = ?", orderID).Count(&count).Error
+	return count, err
+}
+
+// UpdateStatus sets the status of a payment attempt.
+func (r *PaymentRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	return r.db.WithContext(ctx).Model(&models.Payment{}).
+		Where("id = ?", id).
+		Update("status", status).Error
+}
@@ -0,0 +1,36 @@
+package psql
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PaymentWebhookEventRepository logs every payment webhook notification received.
+type PaymentWebhookEventRepository struct {
+	db *gorm.DB
+}
+
+// NewPaymentWebhookEventRepository builds a PaymentWebhookEventRepository around an open GORM connection.
+func NewPaymentWebhookEventRepository(db *gorm.DB) *PaymentWebhookEventRepository {
+	return &PaymentWebhookEventRepository{db: db}
+}
+
+// Create logs a received webhook event, generating its ID if unset.
+func (r *PaymentWebhookEventRepository) Create(ctx context.Context, event *models.PaymentWebhookEvent) error {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+// DeleteOlderThan removes logged webhook events received before cutoff, so
+// this table keeps only what reconciliation and support are likely to
+// still need, not the gateway's entire delivery history.
+func (r *PaymentWebhookEventRepository) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("received_date < ?", cutoff).Delete(&models.PaymentWebhookEvent{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,53 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PickupPointRepository provides access to carrier pickup points.
+type PickupPointRepository struct {
+	db *gorm.DB
+}
+
+// NewPickupPointRepository builds a PickupPointRepository around an open GORM connection.
+func NewPickupPointRepository(db *gorm.DB) *PickupPointRepository {
+	return &PickupPointRepository{db: db}
+}
+
+// Upsert saves a pickup point, matching on (carrier, external_id) so a
+// resync from the carrier's directory updates an existing point in place
+// instead of duplicating it.
+func (r *PickupPointRepository) Upsert(ctx context.Context, point *models.PickupPoint) error {
+	var existing models.PickupPoint
+	err := r.db.WithContext(ctx).Where("carrier = ? AND external_id = ?", point.Carrier, point.ExternalID).First(&existing).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		point.ID = uuid.New()
+		return r.db.WithContext(ctx).Create(point).Error
+	case err != nil:
+		return err
+	}
+
+	point.ID = existing.ID
+	return r.db.WithContext(ctx).Save(point).Error
+}
+
+// Search returns every pickup point in a city.
+func (r *PickupPointRepository) Search(ctx context.Context, city string) ([]models.PickupPoint, error) {
+	var points []models.PickupPoint
+	err := r.db.WithContext(ctx).Where("city = ?", city).Order("name asc").Find(&points).Error
+	return points, err
+}
+
+// GetByID fetches a single pickup point.
+func (r *PickupPointRepository) GetByID(ctx context.Context, id string) (*models.PickupPoint, error) {
+	var point models.PickupPoint
+	if err := r.db.WithContext(ctx).First(&point, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &point, nil
+}
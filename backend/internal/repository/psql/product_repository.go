@@ -0,0 +1,442 @@
+// Package psql contains PostgreSQL-backed repository implementations.
+package psql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/apperr"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProductRepository provides CRUD access to products. Reads that can
+// tolerate replication lag run against ReplicatedDB.Reader(); writes and
+// the single-row lookups that follow them stay on the primary.
+type ProductRepository struct {
+	db *ReplicatedDB
+}
+
+// NewProductRepository builds a ProductRepository around a ReplicatedDB.
+func NewProductRepository(db *ReplicatedDB) *ProductRepository {
+	return &ProductRepository{db: db}
+}
+
+// Create inserts a new product, generating its ID if unset.
+func (r *ProductRepository) Create(ctx context.Context, product *models.Product) error {
+	if product.ID == uuid.Nil {
+		product.ID = uuid.New()
+	}
+	return r.db.Primary.WithContext(ctx).Create(product).Error
+}
+
+// GetByID fetches a product with its category preloaded.
+func (r *ProductRepository) GetByID(ctx context.Context, id string) (*models.Product, error) {
+	var product models.Product
+	if err := r.db.Reader().WithContext(ctx).Preload("Category").First(&product, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// GetByArticleNumber fetches a product by its unique SKU/article number.
+func (r *ProductRepository) GetByArticleNumber(ctx context.Context, articleNumber string) (*models.Product, error) {
+	var product models.Product
+	if err := r.db.Reader().WithContext(ctx).First(&product, "article_number = ?", articleNumber).Error; err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// List returns products ordered by creation date, newest first.
+func (r *ProductRepository) List(ctx context.Context, limit, offset int) ([]models.Product, error) {
+	var products []models.Product
+	err := r.db.Reader().WithContext(ctx).Preload("Category").
+		Order("created_date DESC").
+		Limit(limit).Offset(offset).
+		Find(&products).Error
+	return products, err
+}
+
+// Count returns the total number of products, for the offset pagination
+// envelope's Total field.
+func (r *ProductRepository) Count(ctx context.Context) (int64, error) {
+	var total int64
+	err := r.db.Reader().WithContext(ctx).Model(&models.Product{}).Count(&total).Error
+	return total, err
+}
+
+// ListByCursor returns products ordered by created_date/id descending,
+// starting strictly after the position (afterCreatedDate, afterID) - a
+// zero afterCreatedDate means "start from the top." Unlike List's
+// OFFSET/LIMIT, this keyset approach doesn't slow down on deep pages,
+// which matters for infinite-scroll feeds that can page arbitrarily far.
+func (r *ProductRepository) ListByCursor(ctx context.Context, afterCreatedDate time.Time, afterID string, limit int) ([]models.Product, error) {
+	var products []models.Product
+	q := r.db.Reader().WithContext(ctx).Preload("Category").
+		Order("created_date DESC, id DESC").
+		Limit(limit)
+	if !afterCreatedDate.IsZero() {
+		q = q.Where("(created_date, id) < (?, ?)", afterCreatedDate, afterID)
+	}
+	err := q.Find(&products).Error
+	return products, err
+}
+
+// GetByIDs returns the products matching ids, in no particular order. IDs
+// with no matching row (deleted, unknown) are simply omitted rather than
+// erroring, since a batch fetch is expected to tolerate a stale ID or two.
+func (r *ProductRepository) GetByIDs(ctx context.Context, ids []string) ([]models.Product, error) {
+	var products []models.Product
+	err := r.db.Reader().WithContext(ctx).Preload("Category").Where("id IN ?", ids).Find(&products).Error
+	return products, err
+}
+
+// ListByCategory returns other published products in the same category,
+// excluding excludeID, for "related products" style listings.
+func (r *ProductRepository) ListByCategory(ctx context.Context, categoryID uint, excludeID string, limit int) ([]models.Product, error) {
+	var products []models.Product
+	err := r.db.Reader().WithContext(ctx).Preload("Category").
+		Where("category_id = ? AND id != ?", categoryID, excludeID).
+		Order("created_date DESC").
+		Limit(limit).
+		Find(&products).Error
+	return products, err
+}
+
+// Update persists changes to an existing product, using product.Version as
+// an optimistic lock: the write only takes effect if the row's version
+// still matches what the caller last read. On success product.Version is
+// bumped to match the new row. A caller that lost the race gets back
+// apperr.ErrConflict and should reload the product before retrying.
+func (r *ProductRepository) Update(ctx context.Context, product *models.Product) error {
+	currentVersion := product.Version
+	product.Version++
+	result := r.db.Primary.WithContext(ctx).Model(product).
+		Where("version = ?", currentVersion).
+		Select("*").
+		Updates(product)
+	if result.Error != nil {
+		product.Version = currentVersion
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		product.Version = currentVersion
+		return fmt.Errorf("%w: product was modified by someone else, reload and retry", apperr.ErrConflict)
+	}
+	return nil
+}
+
+// DecrementStock atomically reduces a product's StockQuantity by quantity,
+// but only if enough stock remains - the check and the deduction happen in
+// the same UPDATE, so two concurrent checkouts racing for the last few
+// units can't both succeed. It returns apperr.ErrInsufficientStock if
+// quantity exceeds what's currently available, meaning the caller lost the
+// race (or StockValidationService's earlier check is now stale) and should
+// reload and re-validate rather than retry blindly.
+func (r *ProductRepository) DecrementStock(ctx context.Context, id uuid.UUID, quantity int) error {
+	result := r.db.Primary.WithContext(ctx).Model(&models.Product{}).
+		Where("id = ? AND stock_quantity >= ?", id, quantity).
+		UpdateColumn("stock_quantity", gorm.Expr("stock_quantity - ?", quantity))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: insufficient stock for product", apperr.ErrInsufficientStock)
+	}
+	return nil
+}
+
+// Delete soft-deletes a product by ID: GORM sets its DeletedAt column
+// instead of removing the row, so every normal read keeps excluding it
+// while order history referencing the product stays intact.
+func (r *ProductRepository) Delete(ctx context.Context, id string) error {
+	return r.db.Primary.WithContext(ctx).Delete(&models.Product{}, "id = ?", id).Error
+}
+
+// ListDeleted returns soft-deleted products, newest deletion first, for the
+// admin trash view.
+func (r *ProductRepository) ListDeleted(ctx context.Context, limit, offset int) ([]models.Product, error) {
+	var products []models.Product
+	err := r.db.Reader().WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&products).Error
+	return products, err
+}
+
+// Restore clears DeletedAt on a soft-deleted product, making it visible to
+// normal reads again.
+func (r *ProductRepository) Restore(ctx context.Context, id string) error {
+	return r.db.Primary.WithContext(ctx).Unscoped().Model(&models.Product{}).
+		Where("id = ?", id).
+		Update("deleted_at", nil).Error
+}
+
+// ListIndexable returns published products flagged as indexable, for the sitemap.
+func (r *ProductRepository) ListIndexable(ctx context.Context) ([]models.Product, error) {
+	var products []models.Product
+	err := r.db.Reader().WithContext(ctx).Where("indexable = ? AND published = ?", true, true).Find(&products).Error
+	return products, err
+}
+
+// SetIndexable flips whether a product appears in the sitemap.
+func (r *ProductRepository) SetIndexable(ctx context.Context, id string, indexable bool) error {
+	return r.db.Primary.WithContext(ctx).Model(&models.Product{}).
+		Where("id = ?", id).
+		Update("indexable", indexable).Error
+}
+
+// InventoryReportRow is one line of the inventory report - a product's
+// current stock position and how fast it moved over the reported period.
+type InventoryReportRow struct {
+	ProductID     uuid.UUID `json:"product_id"`
+	ProductName   string    `json:"product_name"`
+	CategoryID    uint      `json:"category_id"`
+	StockQuantity int       `json:"stock_quantity"`
+	StockValue    float64   `json:"stock_value"`
+	OutOfStock    bool      `json:"out_of_stock"`
+	UnitsSold     int64     `json:"units_sold"`
+	// Turnover is units sold in the period divided by current stock -
+	// a coarse approximation (a proper turnover ratio divides by average
+	// stock held over the period, which this schema doesn't track), but
+	// enough to flag what's moving versus what's dead stock.
+	Turnover float64 `json:"turnover"`
+}
+
+// InventoryReport lists stock level, stock value at current price, and
+// sales turnover over [from, to] for every non-discontinued product,
+// optionally restricted to one category. Units sold is computed with a
+// grouped subquery over order_items rather than pulling orders into
+// memory, same as OrderRepository.SalesReport.
+func (r *ProductRepository) InventoryReport(ctx context.Context, categoryID *uint, from, to time.Time) ([]InventoryReportRow, error) {
+	query := r.db.Reader().WithContext(ctx).
+		Table("products").
+		Select(`products.id AS product_id,
+			products.name AS product_name,
+			products.category_id AS category_id,
+			products.stock_quantity AS stock_quantity,
+			products.stock_quantity * products.price AS stock_value,
+			products.stock_quantity <= 0 AS out_of_stock,
+			COALESCE(sold.units_sold, 0) AS units_sold,
+			CASE WHEN products.stock_quantity > 0 THEN COALESCE(sold.units_sold, 0)::float / products.stock_quantity ELSE 0 END AS turnover`).
+		Joins(`LEFT JOIN (
+			SELECT order_items.product_id, SUM(order_items.quantity) AS units_sold
+			FROM order_items
+			JOIN orders ON orders.id = order_items.order_id
+			WHERE orders.created_date BETWEEN ? AND ? AND orders.payment_status = ?
+			GROUP BY order_items.product_id
+		) sold ON sold.product_id = products.id`, from, to, models.PaymentStatusPaid).
+		Where("products.discontinued = ?", false)
+	if categoryID != nil {
+		query = query.Where("products.category_id = ?", *categoryID)
+	}
+
+	var rows []InventoryReportRow
+	err := query.Order("products.name ASC").Scan(&rows).Error
+	return rows, err
+}
+
+// SearchFTS matches published, non-discontinued products against query
+// using PostgreSQL full-text search over name and description, ranked by
+// relevance. It backs SearchHandler.Search when the external search
+// engine is unavailable - lower ranking quality than the engine, but
+// enough to keep search working through an outage.
+func (r *ProductRepository) SearchFTS(ctx context.Context, query string, limit int) ([]models.Product, error) {
+	var products []models.Product
+	err := r.db.Reader().WithContext(ctx).
+		Select("*, ts_rank(to_tsvector('english', name || ' ' || description), plainto_tsquery('english', ?)) AS rank", query).
+		Where("published = ? AND discontinued = ? AND to_tsvector('english', name || ' ' || description) @@ plainto_tsquery('english', ?)", true, false, query).
+		Order("rank DESC").
+		Limit(limit).
+		Find(&products).Error
+	return products, err
+}
+
+// fuzzySearchMinSimilarity is the minimum pg_trgm similarity score a
+// product name must reach to be considered a fuzzy match - low enough to
+// catch a misspelling or transliteration variant, high enough to not
+// return results unrelated to the query.
+const fuzzySearchMinSimilarity = 0.2
+
+// SearchFuzzy matches published, non-discontinued products against query
+// using pg_trgm trigram similarity over the product name, ranked by how
+// similar the match is. It backs SearchHandler.Search when SearchFTS finds
+// nothing - a misspelling or transliteration variant won't share a whole
+// word with the real name, but it shares enough trigrams to still match.
+func (r *ProductRepository) SearchFuzzy(ctx context.Context, query string, limit int) ([]models.Product, error) {
+	var products []models.Product
+	err := r.db.Reader().WithContext(ctx).
+		Select("*, similarity(name, ?) AS rank", query).
+		Where("published = ? AND discontinued = ? AND similarity(name, ?) > ?", true, false, query, fuzzySearchMinSimilarity).
+		Order("rank DESC").
+		Limit(limit).
+		Find(&products).Error
+	return products, err
+}
+
+// ValueFacet is a count of matched products carrying one distinct value of
+// a filterable text attribute (e.g. one size, one color).
+type ValueFacet struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// CategoryFacet is a count of matched products in one category.
+type CategoryFacet struct {
+	CategoryID   uint   `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	Count        int64  `json:"count"`
+}
+
+// PriceRangeFacet is a count of matched products whose price falls in
+// [Min, Max). Max of 0 means unbounded.
+type PriceRangeFacet struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max,omitempty"`
+	Count int64   `json:"count"`
+}
+
+// SearchFacets is the faceted breakdown of a search's matched products,
+// for rendering a storefront filter sidebar from the same response as the
+// results themselves. Product has no brand field today, so brand faceting
+// isn't included.
+type SearchFacets struct {
+	Categories  []CategoryFacet   `json:"categories"`
+	Sizes       []ValueFacet      `json:"sizes"`
+	Colors      []ValueFacet      `json:"colors"`
+	PriceRanges []PriceRangeFacet `json:"price_ranges"`
+}
+
+// searchFacetPriceRanges are the fixed buckets SearchFacets counts prices
+// into. They aren't configurable - tuning them to catalog price
+// distribution is a follow-up, not something worth a config knob yet.
+var searchFacetPriceRanges = []struct{ Min, Max float64 }{
+	{0, 2000},
+	{2000, 5000},
+	{5000, 10000},
+	{10000, 0},
+}
+
+// matchedProducts is the base query shared by SearchFTS and SearchFacets:
+// published, non-discontinued products, additionally matched against query
+// by full-text search when query is non-empty.
+func (r *ProductRepository) matchedProducts(ctx context.Context, query string) *gorm.DB {
+	q := r.db.Reader().WithContext(ctx).Model(&models.Product{}).
+		Where("published = ? AND discontinued = ?", true, false)
+	if query != "" {
+		q = q.Where("to_tsvector('english', name || ' ' || description) @@ plainto_tsquery('english', ?)", query)
+	}
+	return q
+}
+
+// SearchFacets counts, among products matching query the same way SearchFTS
+// does, how many fall into each category, size, color, and price range -
+// everything a storefront filter sidebar needs, computed in one call
+// alongside the results themselves.
+func (r *ProductRepository) SearchFacets(ctx context.Context, query string) (SearchFacets, error) {
+	var facets SearchFacets
+
+	if err := r.matchedProducts(ctx, query).
+		Session(&gorm.Session{}).
+		Joins("JOIN categories ON categories.id = products.category_id").
+		Group("categories.id, categories.name").
+		Select("categories.id AS category_id, categories.name AS category_name, COUNT(*) AS count").
+		Scan(&facets.Categories).Error; err != nil {
+		return facets, err
+	}
+
+	if err := r.matchedProducts(ctx, query).
+		Session(&gorm.Session{}).
+		Where("size != ?", "").
+		Group("size").
+		Select("size AS value, COUNT(*) AS count").
+		Scan(&facets.Sizes).Error; err != nil {
+		return facets, err
+	}
+
+	if err := r.matchedProducts(ctx, query).
+		Session(&gorm.Session{}).
+		Where("color != ?", "").
+		Group("color").
+		Select("color AS value, COUNT(*) AS count").
+		Scan(&facets.Colors).Error; err != nil {
+		return facets, err
+	}
+
+	for _, bucket := range searchFacetPriceRanges {
+		q := r.matchedProducts(ctx, query).Session(&gorm.Session{}).Where("price >= ?", bucket.Min)
+		if bucket.Max > 0 {
+			q = q.Where("price < ?", bucket.Max)
+		}
+		var count int64
+		if err := q.Count(&count).Error; err != nil {
+			return facets, err
+		}
+		facets.PriceRanges = append(facets.PriceRanges, PriceRangeFacet{Min: bucket.Min, Max: bucket.Max, Count: count})
+	}
+
+	return facets, nil
+}
+
+// SuggestNames returns up to limit distinct published, non-discontinued
+// product names starting with prefix (case-insensitive), for search
+// autocomplete.
+func (r *ProductRepository) SuggestNames(ctx context.Context, prefix string, limit int) ([]string, error) {
+	var names []string
+	err := r.db.Reader().WithContext(ctx).Model(&models.Product{}).
+		Distinct("name").
+		Where("published = ? AND discontinued = ? AND name ILIKE ?", true, false, prefix+"%").
+		Order("name ASC").
+		Limit(limit).
+		Pluck("name", &names).Error
+	return names, err
+}
+
+// ReorderSuggestionRow is one low-stock product's recent sales velocity and
+// a suggested reorder quantity.
+type ReorderSuggestionRow struct {
+	ProductID       uuid.UUID `json:"product_id"`
+	ProductName     string    `json:"product_name"`
+	StockQuantity   int       `json:"stock_quantity"`
+	UnitsSoldRecent int64     `json:"units_sold_recent"`
+	// DailyVelocity is UnitsSoldRecent averaged over the lookback window.
+	DailyVelocity float64 `json:"daily_velocity"`
+	// SuggestedReorderQty covers leadTimeDays of expected demand at the
+	// current velocity, minus stock already on hand, floored at 0 - a
+	// product that isn't selling gets no suggestion even if it's low.
+	SuggestedReorderQty int `json:"suggested_reorder_qty"`
+}
+
+// LowStockReorderSuggestions lists non-discontinued products at or below
+// threshold units in stock, together with how fast they've been selling
+// over the last lookbackDays and how much to reorder to cover
+// leadTimeDays of demand at that pace. Sales velocity comes from the same
+// grouped order_items subquery as InventoryReport.
+func (r *ProductRepository) LowStockReorderSuggestions(ctx context.Context, threshold int, lookbackDays, leadTimeDays int) ([]ReorderSuggestionRow, error) {
+	since := time.Now().AddDate(0, 0, -lookbackDays)
+
+	var rows []ReorderSuggestionRow
+	err := r.db.Reader().WithContext(ctx).
+		Table("products").
+		Select(`products.id AS product_id,
+			products.name AS product_name,
+			products.stock_quantity AS stock_quantity,
+			COALESCE(sold.units_sold, 0) AS units_sold_recent,
+			COALESCE(sold.units_sold, 0)::float / ? AS daily_velocity,
+			GREATEST(0, CEIL(COALESCE(sold.units_sold, 0)::float / ? * ? - products.stock_quantity)) AS suggested_reorder_qty`, lookbackDays, lookbackDays, leadTimeDays).
+		Joins(`LEFT JOIN (
+			SELECT order_items.product_id, SUM(order_items.quantity) AS units_sold
+			FROM order_items
+			JOIN orders ON orders.id = order_items.order_id
+			WHERE orders.created_date >= ? AND orders.payment_status = ?
+			GROUP BY order_items.product_id
+		) sold ON sold.product_id = products.id`, since, models.PaymentStatusPaid).
+		Where("products.discontinued = ? AND products.stock_quantity <= ?", false, threshold).
+		Order("daily_velocity DESC").
+		Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,57 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProductTranslationRepository provides access to per-locale product
+// name/description overrides.
+type ProductTranslationRepository struct {
+	db *gorm.DB
+}
+
+// NewProductTranslationRepository builds a ProductTranslationRepository
+// around an open GORM connection.
+func NewProductTranslationRepository(db *gorm.DB) *ProductTranslationRepository {
+	return &ProductTranslationRepository{db: db}
+}
+
+// Upsert writes a product's translation for a locale, creating it if it
+// doesn't exist yet.
+func (r *ProductTranslationRepository) Upsert(ctx context.Context, productID uuid.UUID, locale, name, description string) error {
+	var existing models.ProductTranslation
+	err := r.db.WithContext(ctx).
+		Where("product_id = ? AND locale = ?", productID, locale).
+		First(&existing).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		return r.db.WithContext(ctx).Create(&models.ProductTranslation{
+			ProductID:   productID,
+			Locale:      locale,
+			Name:        name,
+			Description: description,
+		}).Error
+	case err != nil:
+		return err
+	}
+
+	existing.Name = name
+	existing.Description = description
+	return r.db.WithContext(ctx).Save(&existing).Error
+}
+
+// GetByProductAndLocale returns a product's translation for locale, or
+// gorm.ErrRecordNotFound if it hasn't been translated into it.
+func (r *ProductTranslationRepository) GetByProductAndLocale(ctx context.Context, productID uuid.UUID, locale string) (*models.ProductTranslation, error) {
+	var translation models.ProductTranslation
+	if err := r.db.WithContext(ctx).
+		Where("product_id = ? AND locale = ?", productID, locale).
+		First(&translation).Error; err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
@@ -0,0 +1,68 @@
+package psql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PushSubscriptionRepository provides access to users' Web Push
+// subscriptions.
+type PushSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewPushSubscriptionRepository builds a PushSubscriptionRepository around
+// an open GORM connection.
+func NewPushSubscriptionRepository(db *gorm.DB) *PushSubscriptionRepository {
+	return &PushSubscriptionRepository{db: db}
+}
+
+// Upsert records userID's subscription, replacing any existing row for the
+// same endpoint - a browser re-subscribing with the same endpoint but a
+// rotated key should overwrite, not duplicate.
+func (r *PushSubscriptionRepository) Upsert(ctx context.Context, sub *models.PushSubscription) error {
+	var existing models.PushSubscription
+	err := r.db.WithContext(ctx).Where("endpoint = ?", sub.Endpoint).First(&existing).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		if sub.ID == uuid.Nil {
+			sub.ID = uuid.New()
+		}
+		return r.db.WithContext(ctx).Create(sub).Error
+	case err != nil:
+		return err
+	}
+
+	existing.UserID = sub.UserID
+	existing.P256DH = sub.P256DH
+	existing.Auth = sub.Auth
+	if err := r.db.WithContext(ctx).Save(&existing).Error; err != nil {
+		return err
+	}
+	*sub = existing
+	return nil
+}
+
+// DeleteByEndpoint removes userID's subscription for a given endpoint, so a
+// browser can unsubscribe.
+func (r *PushSubscriptionRepository) DeleteByEndpoint(ctx context.Context, userID uint, endpoint string) error {
+	return r.db.WithContext(ctx).Where("user_id = ? AND endpoint = ?", userID, endpoint).Delete(&models.PushSubscription{}).Error
+}
+
+// ListByUser returns every subscription registered for a user - a user can
+// have more than one, one per browser/device.
+func (r *PushSubscriptionRepository) ListByUser(ctx context.Context, userID uint) ([]models.PushSubscription, error) {
+	var subs []models.PushSubscription
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&subs).Error
+	return subs, err
+}
+
+// Delete removes a subscription by ID, for the sender to drop one the push
+// service reports gone.
+func (r *PushSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.PushSubscription{}).Error
+}
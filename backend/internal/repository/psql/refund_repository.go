@@ -0,0 +1,63 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RefundRepository provides access to refunds issued against payments.
+type RefundRepository struct {
+	db *gorm.DB
+}
+
+// NewRefundRepository builds a RefundRepository around an open GORM connection.
+func NewRefundRepository(db *gorm.DB) *RefundRepository {
+	return &RefundRepository{db: db}
+}
+
+// Create inserts a new refund, generating its ID if unset.
+func (r *RefundRepository) Create(ctx context.Context, refund *models.Refund) error {
+	if refund.ID == uuid.Nil {
+		refund.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(refund).Error
+}
+
+// CountPendingByUser counts a customer's refunds still awaiting resolution,
+// joining through payments to orders since a refund only ever references
+// its payment. There's no separate "return request" concept in this
+// codebase - a pending refund is the closest stand-in for one.
+func (r *RefundRepository) CountPendingByUser(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Refund{}).
+		Joins("JOIN payments ON payments.id = refunds.payment_id").
+		Joins("JOIN orders ON orders.id = payments.order_id").
+		Where("orders.user_id = ? AND refunds.status = ?", userID, models.RefundStatusPending).
+		Count(&count).Error
+	return count, err
+}
+
+// CountPending counts every refund still awaiting resolution, across all
+// customers, for the admin dashboard's "pending returns" tile.
+func (r *RefundRepository) CountPending(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Refund{}).
+		Where("status = ?", models.RefundStatusPending).
+		Count(&count).Error
+	return count, err
+}
+
+// SumSucceededByPayment totals the amount already successfully refunded
+// against a payment, used to reject a refund that would exceed what was
+// captured.
+func (r *RefundRepository) SumSucceededByPayment(ctx context.Context, paymentID uuid.UUID) (float64, error) {
+	var total float64
+	err := r.db.WithContext(ctx).Model(&models.Refund{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("payment_id = ? AND status = ?", paymentID, models.RefundStatusSucceeded).
+		Scan(&total).Error
+	return total, err
+}
@@ -0,0 +1,36 @@
+package psql
+
+import (
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+// ReplicatedDB dispatches writes to Primary and, when replicas are
+// configured, round-robins reads across Replicas instead. It's meant for
+// repositories that serve read-heavy, replication-lag-tolerant traffic
+// (product/category listings) - a repository built on it should send every
+// SELECT-only query through Reader() and everything else through Primary.
+type ReplicatedDB struct {
+	Primary  *gorm.DB
+	Replicas []*gorm.DB
+
+	next uint64
+}
+
+// NewReplicatedDB builds a ReplicatedDB around a primary connection and zero
+// or more read replicas. With no replicas, Reader returns Primary, so a
+// repository built on ReplicatedDB works unchanged against a single database.
+func NewReplicatedDB(primary *gorm.DB, replicas ...*gorm.DB) *ReplicatedDB {
+	return &ReplicatedDB{Primary: primary, Replicas: replicas}
+}
+
+// Reader returns the connection a read-only query should run against: the
+// next replica in round-robin order, or Primary if none are configured.
+func (r *ReplicatedDB) Reader() *gorm.DB {
+	if len(r.Replicas) == 0 {
+		return r.Primary
+	}
+	i := atomic.AddUint64(&r.next, 1)
+	return r.Replicas[i%uint64(len(r.Replicas))]
+}
@@ -0,0 +1,30 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReviewReplyRepository stores the shop's official replies to reviews.
+type ReviewReplyRepository struct {
+	db *gorm.DB
+}
+
+// NewReviewReplyRepository builds a ReviewReplyRepository around an open GORM connection.
+func NewReviewReplyRepository(db *gorm.DB) *ReviewReplyRepository {
+	return &ReviewReplyRepository{db: db}
+}
+
+// Set writes the reply for a review, replacing any earlier one.
+func (r *ReviewReplyRepository) Set(ctx context.Context, reviewID uuid.UUID, adminID uint, content string) error {
+	reply := models.ReviewReply{ReviewID: reviewID, AdminID: adminID, Content: content}
+	return r.db.WithContext(ctx).Save(&reply).Error
+}
+
+// Delete removes the reply on a review, if any.
+func (r *ReviewReplyRepository) Delete(ctx context.Context, reviewID uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.ReviewReply{}, "review_id = ?", reviewID).Error
+}
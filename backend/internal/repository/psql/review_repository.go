@@ -0,0 +1,230 @@
+package psql
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReviewRepository provides access to product reviews.
+type ReviewRepository struct {
+	db *gorm.DB
+}
+
+// NewReviewRepository builds a ReviewRepository around an open GORM connection.
+func NewReviewRepository(db *gorm.DB) *ReviewRepository {
+	return &ReviewRepository{db: db}
+}
+
+// Create inserts a new review, generating its ID if unset, and refreshes
+// the product's denormalized rating.
+func (r *ReviewRepository) Create(ctx context.Context, review *models.Review) error {
+	if review.ID == uuid.Nil {
+		review.ID = uuid.New()
+	}
+	if err := r.db.WithContext(ctx).Create(review).Error; err != nil {
+		return err
+	}
+	return r.refreshProductRating(ctx, review.ProductID)
+}
+
+// refreshProductRating recomputes AverageRating and ReviewCount for a
+// product from its approved reviews. Called after any review is created,
+// edited, deleted, or moderated so listings never drift from what's
+// actually visible to shoppers.
+func (r *ReviewRepository) refreshProductRating(ctx context.Context, productID uuid.UUID) error {
+	var agg struct {
+		Average float64
+		Count   int
+	}
+	if err := r.db.WithContext(ctx).Model(&models.Review{}).
+		Select("COALESCE(AVG(rating), 0) AS average, COUNT(*) AS count").
+		Where("product_id = ? AND status = ?", productID, models.ReviewStatusApproved).
+		Scan(&agg).Error; err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Model(&models.Product{}).
+		Where("id = ?", productID).
+		Updates(map[string]interface{}{"average_rating": agg.Average, "review_count": agg.Count}).Error
+}
+
+// CountByUserSince counts how many reviews userID has submitted since since,
+// used to enforce the submission-rate quota.
+func (r *ReviewRepository) CountByUserSince(ctx context.Context, userID uint, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Review{}).
+		Where("user_id = ? AND created_date >= ?", userID, since).
+		Count(&count).Error
+	return count, err
+}
+
+// ListByUser returns every review a user has submitted, across all
+// products, newest first.
+func (r *ReviewRepository) ListByUser(ctx context.Context, userID uint) ([]models.Review, error) {
+	var reviews []models.Review
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_date desc").
+		Find(&reviews).Error
+	return reviews, err
+}
+
+// GetByID fetches a single review by ID.
+func (r *ReviewRepository) GetByID(ctx context.Context, id string) (*models.Review, error) {
+	var review models.Review
+	if err := r.db.WithContext(ctx).Preload("Reply").First(&review, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// GetByUserAndProduct fetches the review userID left on productID, if any.
+// Backed by the unique index on (product_id, user_id) that enforces one
+// review per user per product.
+func (r *ReviewRepository) GetByUserAndProduct(ctx context.Context, userID uint, productID uuid.UUID) (*models.Review, error) {
+	var review models.Review
+	if err := r.db.WithContext(ctx).First(&review, "user_id = ? AND product_id = ?", userID, productID).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// AddImages appends filenames to a review owned by userID.
+func (r *ReviewRepository) AddImages(ctx context.Context, userID uint, id string, filenames []string) error {
+	review, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if review.UserID != userID {
+		return gorm.ErrRecordNotFound
+	}
+	review.Images = append(review.Images, filenames...)
+	return r.db.WithContext(ctx).Model(&models.Review{}).
+		Where("id = ?", id).
+		Update("images", review.Images).Error
+}
+
+// ReviewListOptions narrows and orders a product's review listing. Zero
+// values mean "no filter": Rating 0 matches any rating, Limit 0 disables
+// pagination entirely (used by callers that want every matching review).
+type ReviewListOptions struct {
+	Sort         string // "newest" (default), "highest", "lowest", "helpful"
+	Rating       int
+	WithPhotos   bool
+	VerifiedOnly bool
+	Limit        int
+	Offset       int
+}
+
+// ListByProduct returns approved reviews for a product matching opts, plus
+// the total number of matching reviews (ignoring pagination) so callers can
+// render a page count. Pending and rejected reviews are never shown to
+// shoppers.
+func (r *ReviewRepository) ListByProduct(ctx context.Context, productID uuid.UUID, opts ReviewListOptions) ([]models.Review, int64, error) {
+	filtered := func() *gorm.DB {
+		q := r.db.WithContext(ctx).Model(&models.Review{}).
+			Where("product_id = ? AND status = ?", productID, models.ReviewStatusApproved)
+		if opts.Rating > 0 {
+			q = q.Where("rating = ?", opts.Rating)
+		}
+		if opts.WithPhotos {
+			q = q.Where("images != '[]'::jsonb")
+		}
+		if opts.VerifiedOnly {
+			q = q.Where("verified_purchase = ?", true)
+		}
+		return q
+	}
+
+	var total int64
+	if err := filtered().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := "created_date DESC"
+	switch opts.Sort {
+	case "highest":
+		order = "rating DESC"
+	case "lowest":
+		order = "rating ASC"
+	case "helpful":
+		order = "helpful_count DESC"
+	}
+
+	listQuery := filtered().Preload("Reply").Preload("Author").Order(order)
+	if opts.Limit > 0 {
+		listQuery = listQuery.Limit(opts.Limit).Offset(opts.Offset)
+	}
+
+	var reviews []models.Review
+	err := listQuery.Find(&reviews).Error
+	return reviews, total, err
+}
+
+// ListPending returns every review awaiting moderation, oldest first so the
+// queue is worked in submission order.
+func (r *ReviewRepository) ListPending(ctx context.Context) ([]models.Review, error) {
+	var reviews []models.Review
+	err := r.db.WithContext(ctx).
+		Preload("Reply").
+		Where("status = ?", models.ReviewStatusPending).
+		Order("created_date ASC").
+		Find(&reviews).Error
+	return reviews, err
+}
+
+// CountPending counts reviews awaiting moderation, for the admin dashboard.
+func (r *ReviewRepository) CountPending(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Review{}).
+		Where("status = ?", models.ReviewStatusPending).
+		Count(&count).Error
+	return count, err
+}
+
+// Moderate approves or rejects a pending review. Rejecting without a
+// reason is allowed but discouraged at the handler layer.
+func (r *ReviewRepository) Moderate(ctx context.Context, id, status, rejectionReason string) error {
+	review, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Model(&models.Review{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"status": status, "rejection_reason": rejectionReason}).Error; err != nil {
+		return err
+	}
+	return r.refreshProductRating(ctx, review.ProductID)
+}
+
+// Update overwrites the rating and content of a review owned by userID,
+// sends it back to pending for re-moderation, and refreshes the product's
+// denormalized rating.
+func (r *ReviewRepository) Update(ctx context.Context, userID uint, id string, rating int, content string) error {
+	review, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Model(&models.Review{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Updates(map[string]interface{}{"rating": rating, "content": content, "status": models.ReviewStatusPending, "rejection_reason": ""}).Error; err != nil {
+		return err
+	}
+	return r.refreshProductRating(ctx, review.ProductID)
+}
+
+// Delete removes a review owned by userID and refreshes the product's
+// denormalized rating.
+func (r *ReviewRepository) Delete(ctx context.Context, userID uint, id string) error {
+	review, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.Review{}).Error; err != nil {
+		return err
+	}
+	return r.refreshProductRating(ctx, review.ProductID)
+}
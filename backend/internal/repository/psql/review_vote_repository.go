@@ -0,0 +1,45 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReviewVoteRepository records users' helpful/unhelpful votes on reviews.
+type ReviewVoteRepository struct {
+	db *gorm.DB
+}
+
+// NewReviewVoteRepository builds a ReviewVoteRepository around an open GORM connection.
+func NewReviewVoteRepository(db *gorm.DB) *ReviewVoteRepository {
+	return &ReviewVoteRepository{db: db}
+}
+
+// Cast records userID's vote on a review, replacing any earlier vote from
+// the same user, then refreshes the review's denormalized vote counts.
+func (r *ReviewVoteRepository) Cast(ctx context.Context, reviewID uuid.UUID, userID uint, helpful bool) error {
+	vote := models.ReviewVote{ReviewID: reviewID, UserID: userID, Helpful: helpful}
+	if err := r.db.WithContext(ctx).Save(&vote).Error; err != nil {
+		return err
+	}
+	return r.refreshCounts(ctx, reviewID)
+}
+
+// refreshCounts recomputes a review's helpful/unhelpful counts from its votes.
+func (r *ReviewVoteRepository) refreshCounts(ctx context.Context, reviewID uuid.UUID) error {
+	var helpful, unhelpful int64
+	if err := r.db.WithContext(ctx).Model(&models.ReviewVote{}).
+		Where("review_id = ? AND helpful = ?", reviewID, true).Count(&helpful).Error; err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Model(&models.ReviewVote{}).
+		Where("review_id = ? AND helpful = ?", reviewID, false).Count(&unhelpful).Error; err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Model(&models.Review{}).
+		Where("id = ?", reviewID).
+		Updates(map[string]interface{}{"helpful_count": helpful, "unhelpful_count": unhelpful}).Error
+}
@@ -0,0 +1,49 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SavedCardRepository provides access to users' tokenized cards.
+type SavedCardRepository struct {
+	db *gorm.DB
+}
+
+// NewSavedCardRepository builds a SavedCardRepository around an open GORM connection.
+func NewSavedCardRepository(db *gorm.DB) *SavedCardRepository {
+	return &SavedCardRepository{db: db}
+}
+
+// Create stores a newly tokenized card, generating its ID if unset.
+func (r *SavedCardRepository) Create(ctx context.Context, card *models.SavedCard) error {
+	if card.ID == uuid.Nil {
+		card.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(card).Error
+}
+
+// ListByUser returns every card userID has saved.
+func (r *SavedCardRepository) ListByUser(ctx context.Context, userID uint) ([]models.SavedCard, error) {
+	var cards []models.SavedCard
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&cards).Error
+	return cards, err
+}
+
+// GetByID fetches a single saved card owned by userID.
+func (r *SavedCardRepository) GetByID(ctx context.Context, userID uint, id string) (*models.SavedCard, error) {
+	var card models.SavedCard
+	err := r.db.WithContext(ctx).First(&card, "id = ? AND user_id = ?", id, userID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// Delete removes a saved card owned by userID.
+func (r *SavedCardRepository) Delete(ctx context.Context, userID uint, id string) error {
+	return r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.SavedCard{}).Error
+}
@@ -0,0 +1,49 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SavedItemRepository provides access to a user's "saved for later" list.
+type SavedItemRepository struct {
+	db *gorm.DB
+}
+
+// NewSavedItemRepository builds a SavedItemRepository around an open GORM connection.
+func NewSavedItemRepository(db *gorm.DB) *SavedItemRepository {
+	return &SavedItemRepository{db: db}
+}
+
+// ListByUser returns everything a user has saved for later, with products preloaded.
+func (r *SavedItemRepository) ListByUser(ctx context.Context, userID uint) ([]models.SavedItem, error) {
+	var items []models.SavedItem
+	err := r.db.WithContext(ctx).Preload("Product").Where("user_id = ?", userID).Find(&items).Error
+	return items, err
+}
+
+// Create moves a cart item's product/size into the saved-for-later list.
+func (r *SavedItemRepository) Create(ctx context.Context, item *models.SavedItem) error {
+	if item.ID == uuid.Nil {
+		item.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(item).Error
+}
+
+// Delete removes a saved item owned by userID.
+func (r *SavedItemRepository) Delete(ctx context.Context, userID uint, id string) error {
+	return r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.SavedItem{}).Error
+}
+
+// GetByID fetches a single saved item owned by userID.
+func (r *SavedItemRepository) GetByID(ctx context.Context, userID uint, id string) (*models.SavedItem, error) {
+	var item models.SavedItem
+	err := r.db.WithContext(ctx).First(&item, "id = ? AND user_id = ?", id, userID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
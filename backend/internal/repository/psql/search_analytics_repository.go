@@ -0,0 +1,75 @@
+package psql
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SearchAnalyticsRepository records search queries and result clicks, and
+// answers the aggregate "what are people searching for" questions the
+// catalog team's admin report needs.
+type SearchAnalyticsRepository struct {
+	db *gorm.DB
+}
+
+// NewSearchAnalyticsRepository builds a SearchAnalyticsRepository around an
+// open GORM connection.
+func NewSearchAnalyticsRepository(db *gorm.DB) *SearchAnalyticsRepository {
+	return &SearchAnalyticsRepository{db: db}
+}
+
+// RecordQuery logs one search request, generating its ID if unset.
+func (r *SearchAnalyticsRepository) RecordQuery(ctx context.Context, log *models.SearchQueryLog) error {
+	if log.ID == uuid.Nil {
+		log.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(log).Error
+}
+
+// RecordClick logs a searcher clicking through to a product from a query's
+// results, generating its ID if unset.
+func (r *SearchAnalyticsRepository) RecordClick(ctx context.Context, log *models.SearchClickLog) error {
+	if log.ID == uuid.Nil {
+		log.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(log).Error
+}
+
+// QueryCount is one query string and how many times it was searched.
+type QueryCount struct {
+	Query string `json:"query"`
+	Count int64  `json:"count"`
+}
+
+// TopQueries returns the most-searched queries since since, most frequent
+// first, capped at limit.
+func (r *SearchAnalyticsRepository) TopQueries(ctx context.Context, since time.Time, limit int) ([]QueryCount, error) {
+	var rows []QueryCount
+	err := r.db.WithContext(ctx).Model(&models.SearchQueryLog{}).
+		Select("query, COUNT(*) AS count").
+		Where("created_date >= ?", since).
+		Group("query").
+		Order("count DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// ZeroResultQueries returns queries that returned no results since since,
+// most frequent first, capped at limit - the list the catalog team reads
+// to see what customers can't find.
+func (r *SearchAnalyticsRepository) ZeroResultQueries(ctx context.Context, since time.Time, limit int) ([]QueryCount, error) {
+	var rows []QueryCount
+	err := r.db.WithContext(ctx).Model(&models.SearchQueryLog{}).
+		Select("query, COUNT(*) AS count").
+		Where("created_date >= ? AND result_count = 0", since).
+		Group("query").
+		Order("count DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
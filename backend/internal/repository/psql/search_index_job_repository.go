@@ -0,0 +1,69 @@
+package psql
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SearchIndexJobRepository provides access to the search index job queue.
+type SearchIndexJobRepository struct {
+	db *gorm.DB
+}
+
+// NewSearchIndexJobRepository builds a SearchIndexJobRepository around an
+// open GORM connection.
+func NewSearchIndexJobRepository(db *gorm.DB) *SearchIndexJobRepository {
+	return &SearchIndexJobRepository{db: db}
+}
+
+// Create records a new job, generating its ID if unset.
+func (r *SearchIndexJobRepository) Create(ctx context.Context, job *models.SearchIndexJob) error {
+	if job.ID == uuid.Nil {
+		job.ID = uuid.New()
+	}
+	if job.Status == "" {
+		job.Status = models.SearchJobStatusPending
+	}
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+// ListPending returns up to limit pending jobs, oldest first, locking the
+// rows it returns (SKIP LOCKED) so two SearchIndexService instances polling
+// concurrently never grab the same job twice.
+func (r *SearchIndexJobRepository) ListPending(ctx context.Context, limit int) ([]models.SearchIndexJob, error) {
+	var jobs []models.SearchIndexJob
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ?", models.SearchJobStatusPending).
+		Order("created_date ASC").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// MarkPublished records that a job was applied to the search engine.
+func (r *SearchIndexJobRepository) MarkPublished(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).Model(&models.SearchIndexJob{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"status": models.SearchJobStatusPublished, "published_date": &now}).Error
+}
+
+// MarkAttemptFailed records a failed sync attempt, flipping the job to
+// SearchJobStatusFailed once it's used up models.MaxSearchJobAttempts so
+// the relay stops retrying it forever; otherwise it stays pending for the
+// next poll.
+func (r *SearchIndexJobRepository) MarkAttemptFailed(ctx context.Context, id uuid.UUID, attempts int, lastErr string) error {
+	status := models.SearchJobStatusPending
+	if attempts >= models.MaxSearchJobAttempts {
+		status = models.SearchJobStatusFailed
+	}
+	return r.db.WithContext(ctx).Model(&models.SearchIndexJob{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"status": status, "attempts": attempts, "last_error": lastErr}).Error
+}
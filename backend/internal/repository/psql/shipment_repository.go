@@ -0,0 +1,86 @@
+package psql
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ShipmentRepository provides access to carrier shipments and their
+// tracking history.
+type ShipmentRepository struct {
+	db *gorm.DB
+}
+
+// NewShipmentRepository builds a ShipmentRepository around an open GORM connection.
+func NewShipmentRepository(db *gorm.DB) *ShipmentRepository {
+	return &ShipmentRepository{db: db}
+}
+
+// Create saves a newly registered shipment, generating an ID if unset.
+func (r *ShipmentRepository) Create(ctx context.Context, shipment *models.Shipment) error {
+	if shipment.ID == uuid.Nil {
+		shipment.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(shipment).Error
+}
+
+// GetByOrderID fetches the shipment registered against an order, with its
+// tracking history, oldest event first.
+func (r *ShipmentRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) (*models.Shipment, error) {
+	var shipment models.Shipment
+	err := r.db.WithContext(ctx).
+		Preload("Events", func(db *gorm.DB) *gorm.DB { return db.Order("occurred_at asc") }).
+		Where("order_id = ?", orderID).First(&shipment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+// ListActive returns every shipment that hasn't reached a terminal status
+// yet, the set the background tracking poll needs to check.
+func (r *ShipmentRepository) ListActive(ctx context.Context) ([]models.Shipment, error) {
+	var shipments []models.Shipment
+	err := r.db.WithContext(ctx).
+		Where("status NOT IN ?", []string{models.ShipmentStatusDelivered, models.ShipmentStatusException}).
+		Find(&shipments).Error
+	return shipments, err
+}
+
+// UpdateStatus updates a shipment's normalized status.
+func (r *ShipmentRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	return r.db.WithContext(ctx).Model(&models.Shipment{}).Where("id = ?", id).Update("status", status).Error
+}
+
+// AppendEvents inserts newly observed tracking events for a shipment,
+// generating IDs where unset.
+func (r *ShipmentRepository) AppendEvents(ctx context.Context, events ...*models.TrackingEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	for _, event := range events {
+		if event.ID == uuid.Nil {
+			event.ID = uuid.New()
+		}
+	}
+	return r.db.WithContext(ctx).Create(&events).Error
+}
+
+// LatestEventTime returns the OccurredAt of the most recently recorded
+// event for a shipment, or the zero time if none have been recorded yet -
+// used to figure out which carrier events are new since the last poll.
+func (r *ShipmentRepository) LatestEventTime(ctx context.Context, shipmentID uuid.UUID) (time.Time, error) {
+	var event models.TrackingEvent
+	err := r.db.WithContext(ctx).Where("shipment_id = ?", shipmentID).Order("occurred_at desc").First(&event).Error
+	if err == gorm.ErrRecordNotFound {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return event.OccurredAt, nil
+}
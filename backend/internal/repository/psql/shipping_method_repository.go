@@ -0,0 +1,60 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ShippingMethodRepository provides admin-managed CRUD for checkout shipping methods.
+type ShippingMethodRepository struct {
+	db *gorm.DB
+}
+
+// NewShippingMethodRepository builds a ShippingMethodRepository around an open GORM connection.
+func NewShippingMethodRepository(db *gorm.DB) *ShippingMethodRepository {
+	return &ShippingMethodRepository{db: db}
+}
+
+// Create inserts a new shipping method, generating its ID if unset.
+func (r *ShippingMethodRepository) Create(ctx context.Context, method *models.ShippingMethod) error {
+	if method.ID == uuid.Nil {
+		method.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(method).Error
+}
+
+// GetByID fetches a single shipping method by ID.
+func (r *ShippingMethodRepository) GetByID(ctx context.Context, id string) (*models.ShippingMethod, error) {
+	var method models.ShippingMethod
+	if err := r.db.WithContext(ctx).First(&method, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &method, nil
+}
+
+// List returns every configured shipping method, enabled or not, for admin management.
+func (r *ShippingMethodRepository) List(ctx context.Context) ([]models.ShippingMethod, error) {
+	var methods []models.ShippingMethod
+	err := r.db.WithContext(ctx).Order("created_date DESC").Find(&methods).Error
+	return methods, err
+}
+
+// ListEnabled returns every enabled shipping method, for checkout.
+func (r *ShippingMethodRepository) ListEnabled(ctx context.Context) ([]models.ShippingMethod, error) {
+	var methods []models.ShippingMethod
+	err := r.db.WithContext(ctx).Where("enabled = ?", true).Find(&methods).Error
+	return methods, err
+}
+
+// Update persists changes to an existing shipping method.
+func (r *ShippingMethodRepository) Update(ctx context.Context, method *models.ShippingMethod) error {
+	return r.db.WithContext(ctx).Save(method).Error
+}
+
+// Delete removes a shipping method by ID.
+func (r *ShippingMethodRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Delete(&models.ShippingMethod{}, "id = ?", id).Error
+}
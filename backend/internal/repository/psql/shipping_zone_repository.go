@@ -0,0 +1,33 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"gorm.io/gorm"
+)
+
+// ShippingZoneRepository provides access to postal-code-based delivery zones.
+type ShippingZoneRepository struct {
+	db *gorm.DB
+}
+
+// NewShippingZoneRepository builds a ShippingZoneRepository around an open GORM connection.
+func NewShippingZoneRepository(db *gorm.DB) *ShippingZoneRepository {
+	return &ShippingZoneRepository{db: db}
+}
+
+// MatchPostalCode returns the zone whose postal prefix matches postalCode
+// most specifically (longest prefix wins), or gorm.ErrRecordNotFound if no
+// zone covers it.
+func (r *ShippingZoneRepository) MatchPostalCode(ctx context.Context, postalCode string) (*models.ShippingZone, error) {
+	var zone models.ShippingZone
+	err := r.db.WithContext(ctx).
+		Where("? LIKE postal_prefix || '%'", postalCode).
+		Order("length(postal_prefix) DESC").
+		First(&zone).Error
+	if err != nil {
+		return nil, err
+	}
+	return &zone, nil
+}
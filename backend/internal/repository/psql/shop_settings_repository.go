@@ -0,0 +1,42 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"gorm.io/gorm"
+)
+
+// ShopSettingsRepository provides access to the single ShopSettings row.
+type ShopSettingsRepository struct {
+	db *gorm.DB
+}
+
+// NewShopSettingsRepository builds a ShopSettingsRepository around an open GORM connection.
+func NewShopSettingsRepository(db *gorm.DB) *ShopSettingsRepository {
+	return &ShopSettingsRepository{db: db}
+}
+
+// Get returns the shop settings, creating the row with zero-value defaults
+// on first use if it doesn't exist yet.
+func (r *ShopSettingsRepository) Get(ctx context.Context) (*models.ShopSettings, error) {
+	var settings models.ShopSettings
+	err := r.db.WithContext(ctx).First(&settings, "id = ?", models.ShopSettingsID).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		settings = models.ShopSettings{ID: models.ShopSettingsID}
+		if err := r.db.WithContext(ctx).Create(&settings).Error; err != nil {
+			return nil, err
+		}
+		return &settings, nil
+	case err != nil:
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// Update saves the shop settings, pinning ID to the singleton row.
+func (r *ShopSettingsRepository) Update(ctx context.Context, settings *models.ShopSettings) error {
+	settings.ID = models.ShopSettingsID
+	return r.db.WithContext(ctx).Save(settings).Error
+}
@@ -0,0 +1,52 @@
+package psql
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SMSLogRepository records the outcome of every SMS SMSService attempts to
+// send, and is later updated by a provider's delivery-status callback.
+type SMSLogRepository struct {
+	db *gorm.DB
+}
+
+// NewSMSLogRepository builds an SMSLogRepository around an open GORM
+// connection.
+func NewSMSLogRepository(db *gorm.DB) *SMSLogRepository {
+	return &SMSLogRepository{db: db}
+}
+
+// Create records a new SMS log entry, generating its ID if unset.
+func (r *SMSLogRepository) Create(ctx context.Context, log *models.SMSLog) error {
+	if log.ID == uuid.Nil {
+		log.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(log).Error
+}
+
+// GetByProviderMessageID looks up the log entry for a provider's message
+// ID, for a delivery-status callback to update.
+func (r *SMSLogRepository) GetByProviderMessageID(ctx context.Context, providerMessageID string) (*models.SMSLog, error) {
+	var log models.SMSLog
+	err := r.db.WithContext(ctx).First(&log, "provider_message_id = ?", providerMessageID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
+
+// UpdateStatus applies a delivery-status callback to the log entry for
+// providerMessageID. deliveredDate is only set when status is
+// models.SMSStatusDelivered.
+func (r *SMSLogRepository) UpdateStatus(ctx context.Context, providerMessageID, status string, deliveredDate *time.Time) error {
+	updates := map[string]interface{}{"status": status}
+	if deliveredDate != nil {
+		updates["delivered_date"] = deliveredDate
+	}
+	return r.db.WithContext(ctx).Model(&models.SMSLog{}).Where("provider_message_id = ?", providerMessageID).Updates(updates).Error
+}
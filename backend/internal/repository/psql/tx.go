@@ -0,0 +1,44 @@
+package psql
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// TxRepos bundles the repositories a cross-repository operation needs, each
+// bound to the same in-flight transaction so their writes commit or roll
+// back together. Add a field here as a call site needs one more repository
+// inside the same transaction - it isn't meant to grow into every
+// repository in the package.
+type TxRepos struct {
+	Orders         *OrderRepository
+	Payments       *PaymentRepository
+	Refunds        *RefundRepository
+	WarehouseStock *WarehouseStockRepository
+	Outbox         *OutboxEventRepository
+	Products       *ProductRepository
+	Coupons        *CouponRepository
+	Cart           *CartRepository
+	CartCoupon     *CartCouponRepository
+}
+
+// WithTx runs fn inside a single GORM transaction, passing it repositories
+// bound to that transaction's connection. A non-nil return from fn rolls
+// the transaction back; a panic inside fn also rolls back and re-panics,
+// same as gorm.DB.Transaction.
+func WithTx(ctx context.Context, db *gorm.DB, fn func(*TxRepos) error) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&TxRepos{
+			Orders:         NewOrderRepository(tx),
+			Payments:       NewPaymentRepository(tx),
+			Refunds:        NewRefundRepository(tx),
+			WarehouseStock: NewWarehouseStockRepository(tx),
+			Outbox:         NewOutboxEventRepository(tx),
+			Products:       NewProductRepository(NewReplicatedDB(tx)),
+			Coupons:        NewCouponRepository(tx),
+			Cart:           NewCartRepository(tx),
+			CartCoupon:     NewCartCouponRepository(tx),
+		})
+	})
+}
@@ -0,0 +1,130 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"gorm.io/gorm"
+)
+
+// UserRepository provides access to user accounts. Every method takes the
+// caller's context and threads it through WithContext, so a client
+// disconnecting or a handler-level deadline cancels the underlying query.
+type UserRepository struct {
+	db *gorm.DB
+}
+
+// NewUserRepository builds a UserRepository around an open GORM connection.
+func NewUserRepository(db *gorm.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// Create inserts a new user.
+func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Create(user).Error
+}
+
+// GetByEmail looks up a user by their login email.
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).First(&user, "email = ?", email).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetByID looks up a user by their primary key.
+func (r *UserRepository) GetByID(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// CountCreatedSince counts accounts created at or after since, for the
+// admin dashboard's "new users" tile.
+func (r *UserRepository) CountCreatedSince(ctx context.Context, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("created_date >= ?", since).Count(&count).Error
+	return count, err
+}
+
+// UpdateRole changes a user's role.
+func (r *UserRepository) UpdateRole(ctx context.Context, id uint, role string) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("role", role).Error
+}
+
+// SetBlocked flips whether a user can log in.
+func (r *UserRepository) SetBlocked(ctx context.Context, id uint, blocked bool) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("blocked", blocked).Error
+}
+
+// List returns customers matching a case-insensitive search over email and
+// name, newest first, for the admin user list. An empty search returns
+// every customer.
+func (r *UserRepository) List(ctx context.Context, search string, limit, offset int) ([]models.User, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.User{})
+	if search != "" {
+		needle := "%" + search + "%"
+		query = query.Where("email ILIKE ? OR first_name ILIKE ? OR last_name ILIKE ?", needle, needle, needle)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []models.User
+	err := query.Order("created_date DESC").Limit(limit).Offset(offset).Find(&users).Error
+	return users, total, err
+}
+
+// UpdateProfile saves a user's editable profile fields.
+func (r *UserRepository) UpdateProfile(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", user.ID).
+		Select("FirstName", "LastName", "Country", "Phone", "City", "Street", "Building", "Apartment", "PostalCode").
+		Updates(user).Error
+}
+
+// UpdateAvatar sets or clears a user's avatar URL.
+func (r *UserRepository) UpdateAvatar(ctx context.Context, id uint, avatarURL string) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("avatar_url", avatarURL).Error
+}
+
+// UpdatePreferences saves a user's communication preferences.
+func (r *UserRepository) UpdatePreferences(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", user.ID).
+		Select("EmailOrderUpdates", "EmailMarketing", "SMSNotifications", "PushNotifications").
+		Updates(user).Error
+}
+
+// SetPreferenceColumn flips a single communication preference column, by its
+// database column name, to value. It's used by the unsubscribe endpoint,
+// which only ever knows the one column named in the link it was given.
+func (r *UserRepository) SetPreferenceColumn(ctx context.Context, id uint, column string, value bool) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update(column, value).Error
+}
+
+// Anonymize scrubs a user's PII in place, replacing their email with an
+// unaddressable placeholder and blanking the rest of their profile. The
+// row is kept, not deleted, so that orders and reviews referencing it stay
+// intact for accounting and moderation history.
+func (r *UserRepository) Anonymize(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"email":         fmt.Sprintf("deleted-user-%d@anonymized.invalid", id),
+		"password_hash": "",
+		"first_name":    "",
+		"last_name":     "",
+		"country":       "",
+		"phone":         "",
+		"city":          "",
+		"street":        "",
+		"building":      "",
+		"apartment":     "",
+		"postal_code":   "",
+		"anonymized":    true,
+	}).Error
+}
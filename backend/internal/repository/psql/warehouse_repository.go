@@ -0,0 +1,58 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WarehouseRepository provides admin access to fulfillment warehouses.
+type WarehouseRepository struct {
+	db *gorm.DB
+}
+
+// NewWarehouseRepository builds a WarehouseRepository around an open GORM connection.
+func NewWarehouseRepository(db *gorm.DB) *WarehouseRepository {
+	return &WarehouseRepository{db: db}
+}
+
+// Create adds a new warehouse, generating its ID if unset.
+func (r *WarehouseRepository) Create(ctx context.Context, warehouse *models.Warehouse) error {
+	if warehouse.ID == uuid.Nil {
+		warehouse.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(warehouse).Error
+}
+
+// GetByID fetches a single warehouse by ID.
+func (r *WarehouseRepository) GetByID(ctx context.Context, id string) (*models.Warehouse, error) {
+	var warehouse models.Warehouse
+	if err := r.db.WithContext(ctx).First(&warehouse, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &warehouse, nil
+}
+
+// List returns every warehouse, active or not, for admin management.
+func (r *WarehouseRepository) List(ctx context.Context) ([]models.Warehouse, error) {
+	var warehouses []models.Warehouse
+	err := r.db.WithContext(ctx).Order("name asc").Find(&warehouses).Error
+	return warehouses, err
+}
+
+// Update saves changes to an existing warehouse.
+func (r *WarehouseRepository) Update(ctx context.Context, warehouse *models.Warehouse) error {
+	return r.db.WithContext(ctx).Save(warehouse).Error
+}
+
+// Delete removes a warehouse by ID, along with its stock records.
+func (r *WarehouseRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("warehouse_id = ?", id).Delete(&models.WarehouseStock{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Warehouse{}, "id = ?", id).Error
+	})
+}
@@ -0,0 +1,148 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/YoungGoofy/shopping/internal/apperr"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WarehouseStockRepository provides access to per-warehouse stock levels.
+type WarehouseStockRepository struct {
+	db *gorm.DB
+}
+
+// NewWarehouseStockRepository builds a WarehouseStockRepository around an open GORM connection.
+func NewWarehouseStockRepository(db *gorm.DB) *WarehouseStockRepository {
+	return &WarehouseStockRepository{db: db}
+}
+
+// SetQuantity overwrites the stock level for a product at a warehouse,
+// creating the record if it doesn't exist yet.
+func (r *WarehouseStockRepository) SetQuantity(ctx context.Context, warehouseID, productID uuid.UUID, quantity int) error {
+	var existing models.WarehouseStock
+	err := r.db.WithContext(ctx).
+		Where("warehouse_id = ? AND product_id = ?", warehouseID, productID).
+		First(&existing).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		return r.db.WithContext(ctx).Create(&models.WarehouseStock{
+			WarehouseID: warehouseID,
+			ProductID:   productID,
+			Quantity:    quantity,
+		}).Error
+	case err != nil:
+		return err
+	}
+
+	existing.Quantity = quantity
+	return r.db.WithContext(ctx).Save(&existing).Error
+}
+
+// ListForProduct returns the per-warehouse breakdown for a product.
+func (r *WarehouseStockRepository) ListForProduct(ctx context.Context, productID uuid.UUID) ([]models.WarehouseStock, error) {
+	var stock []models.WarehouseStock
+	err := r.db.WithContext(ctx).Preload("Warehouse").
+		Where("product_id = ?", productID).
+		Find(&stock).Error
+	return stock, err
+}
+
+// TotalForProduct sums stock across every warehouse, for aggregate
+// availability in the catalog.
+func (r *WarehouseStockRepository) TotalForProduct(ctx context.Context, productID uuid.UUID) (int, error) {
+	var total int
+	err := r.db.WithContext(ctx).Model(&models.WarehouseStock{}).
+		Where("product_id = ?", productID).
+		Select("COALESCE(SUM(quantity), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+// WarehouseAllocation is the quantity of an order line drawn from a single
+// warehouse, as decided by Allocate.
+type WarehouseAllocation struct {
+	WarehouseID uuid.UUID `json:"warehouse_id"`
+	Quantity    int       `json:"quantity"`
+}
+
+// Allocate reserves quantity units of a product across one or more
+// warehouses, preferring warehouses in preferredRegion before falling back
+// to any warehouse with stock, and deducts the reserved amounts in the same
+// transaction. The returned breakdown lets a caller split the order line
+// into partial shipments. It fails without deducting anything if the total
+// available stock is short.
+func (r *WarehouseStockRepository) Allocate(ctx context.Context, productID uuid.UUID, quantity int, preferredRegion string) ([]WarehouseAllocation, error) {
+	var allocations []WarehouseAllocation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var stock []models.WarehouseStock
+		if err := tx.Preload("Warehouse").
+			Joins("JOIN warehouses ON warehouses.id = warehouse_stocks.warehouse_id").
+			Where("warehouse_stocks.product_id = ? AND warehouse_stocks.quantity > 0 AND warehouses.active = ?", productID, true).
+			Order(gorm.Expr("CASE WHEN warehouses.region = ? THEN 0 ELSE 1 END, warehouse_stocks.quantity DESC", preferredRegion)).
+			Find(&stock).Error; err != nil {
+			return err
+		}
+
+		remaining := quantity
+		for i := range stock {
+			if remaining == 0 {
+				break
+			}
+			take := stock[i].Quantity
+			if take > remaining {
+				take = remaining
+			}
+			stock[i].Quantity -= take
+			if err := tx.Save(&stock[i]).Error; err != nil {
+				return err
+			}
+			allocations = append(allocations, WarehouseAllocation{WarehouseID: stock[i].WarehouseID, Quantity: take})
+			remaining -= take
+		}
+
+		if remaining > 0 {
+			return fmt.Errorf("%w: product %s short by %d", apperr.ErrInsufficientStock, productID, remaining)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return allocations, nil
+}
+
+// Transfer moves quantity units of a product from one warehouse to another.
+func (r *WarehouseStockRepository) Transfer(ctx context.Context, fromWarehouseID, toWarehouseID, productID uuid.UUID, quantity int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var from models.WarehouseStock
+		if err := tx.Where("warehouse_id = ? AND product_id = ?", fromWarehouseID, productID).First(&from).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: no stock record for source warehouse", apperr.ErrNotFound)
+			}
+			return err
+		}
+		if from.Quantity < quantity {
+			return fmt.Errorf("%w: source warehouse has %d, need %d", apperr.ErrInsufficientStock, from.Quantity, quantity)
+		}
+		from.Quantity -= quantity
+		if err := tx.Save(&from).Error; err != nil {
+			return err
+		}
+
+		var to models.WarehouseStock
+		err := tx.Where("warehouse_id = ? AND product_id = ?", toWarehouseID, productID).First(&to).Error
+		switch {
+		case err == gorm.ErrRecordNotFound:
+			return tx.Create(&models.WarehouseStock{WarehouseID: toWarehouseID, ProductID: productID, Quantity: quantity}).Error
+		case err != nil:
+			return err
+		}
+		to.Quantity += quantity
+		return tx.Save(&to).Error
+	})
+}
@@ -0,0 +1,58 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WishlistRepository provides access to users' wishlists.
+type WishlistRepository struct {
+	db *gorm.DB
+}
+
+// NewWishlistRepository builds a WishlistRepository around an open GORM connection.
+func NewWishlistRepository(db *gorm.DB) *WishlistRepository {
+	return &WishlistRepository{db: db}
+}
+
+// ListByUser returns everything on a user's wishlist, with products preloaded.
+func (r *WishlistRepository) ListByUser(ctx context.Context, userID uint) ([]models.WishlistItem, error) {
+	var items []models.WishlistItem
+	err := r.db.WithContext(ctx).Preload("Product").Where("user_id = ?", userID).Find(&items).Error
+	return items, err
+}
+
+// Add inserts a product onto a user's wishlist.
+func (r *WishlistRepository) Add(ctx context.Context, userID uint, productID uuid.UUID) (*models.WishlistItem, error) {
+	item := &models.WishlistItem{ID: uuid.New(), UserID: userID, ProductID: productID}
+	if err := r.db.WithContext(ctx).Create(item).Error; err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Remove deletes a wishlist item owned by userID.
+func (r *WishlistRepository) Remove(ctx context.Context, userID uint, id string) error {
+	return r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.WishlistItem{}).Error
+}
+
+// GetByID fetches a single wishlist item owned by userID, product preloaded.
+func (r *WishlistRepository) GetByID(ctx context.Context, userID uint, id string) (*models.WishlistItem, error) {
+	var item models.WishlistItem
+	err := r.db.WithContext(ctx).Preload("Product").First(&item, "id = ? AND user_id = ?", id, userID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// ListContainingProduct returns every wishlist item for a product, across
+// all users, so a back-in-stock job can notify them.
+func (r *WishlistRepository) ListContainingProduct(ctx context.Context, productID uuid.UUID) ([]models.WishlistItem, error) {
+	var items []models.WishlistItem
+	err := r.db.WithContext(ctx).Where("product_id = ?", productID).Find(&items).Error
+	return items, err
+}
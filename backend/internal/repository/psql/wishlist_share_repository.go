@@ -0,0 +1,39 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"gorm.io/gorm"
+)
+
+// WishlistShareRepository tracks which users have published a read-only link
+// to their wishlist.
+type WishlistShareRepository struct {
+	db *gorm.DB
+}
+
+// NewWishlistShareRepository builds a WishlistShareRepository around an open GORM connection.
+func NewWishlistShareRepository(db *gorm.DB) *WishlistShareRepository {
+	return &WishlistShareRepository{db: db}
+}
+
+// Enable creates or replaces the share token for userID's wishlist.
+func (r *WishlistShareRepository) Enable(ctx context.Context, userID uint, token string) error {
+	share := models.WishlistShare{UserID: userID, Token: token}
+	return r.db.WithContext(ctx).Save(&share).Error
+}
+
+// Disable removes userID's share token, if any.
+func (r *WishlistShareRepository) Disable(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Delete(&models.WishlistShare{}, "user_id = ?", userID).Error
+}
+
+// GetByToken resolves a share token back to the owning user ID.
+func (r *WishlistShareRepository) GetByToken(ctx context.Context, token string) (*models.WishlistShare, error) {
+	var share models.WishlistShare
+	if err := r.db.WithContext(ctx).First(&share, "token = ?", token).Error; err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
@@ -0,0 +1,53 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// browsingHistoryMaxLength caps how many recently viewed products are kept
+// per user - enough to inform "recommended for you" without the list
+// growing unbounded for a heavy browser.
+const browsingHistoryMaxLength = 50
+
+// browsingHistoryTTL is how long an untouched history survives, so a user
+// who never comes back doesn't linger in Redis forever.
+const browsingHistoryTTL = 90 * 24 * time.Hour
+
+// BrowsingHistoryRepository records which products a user has viewed
+// recently, most recent first, backing personalized recommendations.
+type BrowsingHistoryRepository struct {
+	client *redis.Client
+}
+
+// NewBrowsingHistoryRepository builds a BrowsingHistoryRepository around an
+// open Redis client.
+func NewBrowsingHistoryRepository(client *redis.Client) *BrowsingHistoryRepository {
+	return &BrowsingHistoryRepository{client: client}
+}
+
+func browsingHistoryKey(userID uint) string {
+	return fmt.Sprintf("browsing_history:%d", userID)
+}
+
+// Record moves productID to the front of userID's history, trimming the
+// list back to browsingHistoryMaxLength and refreshing its TTL.
+func (r *BrowsingHistoryRepository) Record(ctx context.Context, userID uint, productID string) error {
+	key := browsingHistoryKey(userID)
+	pipe := r.client.Pipeline()
+	pipe.LRem(ctx, key, 0, productID)
+	pipe.LPush(ctx, key, productID)
+	pipe.LTrim(ctx, key, 0, browsingHistoryMaxLength-1)
+	pipe.Expire(ctx, key, browsingHistoryTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// List returns up to limit recently viewed product IDs for userID, most
+// recent first.
+func (r *BrowsingHistoryRepository) List(ctx context.Context, userID uint, limit int) ([]string, error) {
+	return r.client.LRange(ctx, browsingHistoryKey(userID), 0, int64(limit)-1).Result()
+}
@@ -0,0 +1,64 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// deliveryEstimateTTL controls how long a resolved (zone, carrier) delivery
+// estimate is cached before the shipping-zone tables are re-consulted.
+const deliveryEstimateTTL = 6 * time.Hour
+
+// DeliveryEstimate is the cached outcome of a shipping-zone lookup.
+type DeliveryEstimate struct {
+	Available    bool    `json:"available"`
+	CarrierName  string  `json:"carrier_name"`
+	LeadTimeDays int     `json:"lead_time_days"`
+	CostMin      float64 `json:"cost_min"`
+	CostMax      float64 `json:"cost_max"`
+}
+
+// DeliveryEstimateCache caches delivery estimates per (postal prefix,
+// carrier), since zone/carrier lead times change far less often than
+// products are browsed.
+type DeliveryEstimateCache struct {
+	client *redis.Client
+}
+
+func deliveryEstimateKey(postalPrefix, carrierName string) string {
+	return fmt.Sprintf("delivery_estimate:%s:%s", postalPrefix, carrierName)
+}
+
+// NewDeliveryEstimateCache builds a DeliveryEstimateCache around an open Redis client.
+func NewDeliveryEstimateCache(client *redis.Client) *DeliveryEstimateCache {
+	return &DeliveryEstimateCache{client: client}
+}
+
+// Get returns the cached estimate for (postalPrefix, carrierName), if present.
+func (r *DeliveryEstimateCache) Get(ctx context.Context, postalPrefix, carrierName string) (*DeliveryEstimate, error) {
+	raw, err := r.client.Get(ctx, deliveryEstimateKey(postalPrefix, carrierName)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var est DeliveryEstimate
+	if err := json.Unmarshal([]byte(raw), &est); err != nil {
+		return nil, err
+	}
+	return &est, nil
+}
+
+// Set caches an estimate for (postalPrefix, carrierName).
+func (r *DeliveryEstimateCache) Set(ctx context.Context, postalPrefix, carrierName string, est DeliveryEstimate) error {
+	data, err := json.Marshal(est)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, deliveryEstimateKey(postalPrefix, carrierName), data, deliveryEstimateTTL).Err()
+}
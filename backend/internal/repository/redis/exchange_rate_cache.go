@@ -0,0 +1,52 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ExchangeRateCache caches the latest fetched FX rates per base currency.
+// Entries carry no TTL: a refresh overwrites them on success, and a
+// failed refresh leaves the previous rates in place so conversions keep
+// working off the last known values.
+type ExchangeRateCache struct {
+	client *redis.Client
+}
+
+func exchangeRateKey(base string) string {
+	return fmt.Sprintf("fx_rates:%s", base)
+}
+
+// NewExchangeRateCache builds an ExchangeRateCache around an open Redis client.
+func NewExchangeRateCache(client *redis.Client) *ExchangeRateCache {
+	return &ExchangeRateCache{client: client}
+}
+
+// Get returns the last cached rates for base, or nil if none have ever
+// been cached.
+func (r *ExchangeRateCache) Get(ctx context.Context, base string) (map[string]float64, error) {
+	raw, err := r.client.Get(ctx, exchangeRateKey(base)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rates map[string]float64
+	if err := json.Unmarshal([]byte(raw), &rates); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// Set overwrites the cached rates for base.
+func (r *ExchangeRateCache) Set(ctx context.Context, base string, rates map[string]float64) error {
+	data, err := json.Marshal(rates)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, exchangeRateKey(base), data, 0).Err()
+}
@@ -0,0 +1,82 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// GuestCartLine is a single product/size/quantity in a guest's cart.
+type GuestCartLine struct {
+	ProductID string `json:"product_id"`
+	Size      string `json:"size"`
+	Quantity  int    `json:"quantity"`
+}
+
+// guestCartTTL is how long an untouched guest cart survives in Redis.
+const guestCartTTL = 30 * 24 * time.Hour
+
+// GuestCartRepository stores carts for unauthenticated shoppers, identified
+// by a cookie value rather than a user ID.
+type GuestCartRepository struct {
+	client *redis.Client
+}
+
+// NewGuestCartRepository builds a GuestCartRepository around an open Redis client.
+func NewGuestCartRepository(client *redis.Client) *GuestCartRepository {
+	return &GuestCartRepository{client: client}
+}
+
+func guestCartKey(cookie string) string {
+	return fmt.Sprintf("guest_cart:%s", cookie)
+}
+
+// List returns the lines currently in the guest cart for cookie.
+func (r *GuestCartRepository) List(ctx context.Context, cookie string) ([]GuestCartLine, error) {
+	raw, err := r.client.Get(ctx, guestCartKey(cookie)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var lines []GuestCartLine
+	if err := json.Unmarshal([]byte(raw), &lines); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// AddOrIncrement adds a product/size to the guest cart, or increases the
+// quantity of the matching line if it is already present.
+func (r *GuestCartRepository) AddOrIncrement(ctx context.Context, cookie, productID, size string, quantity int) error {
+	lines, err := r.List(ctx, cookie)
+	if err != nil {
+		return err
+	}
+
+	for i, line := range lines {
+		if line.ProductID == productID && line.Size == size {
+			lines[i].Quantity += quantity
+			return r.save(ctx, cookie, lines)
+		}
+	}
+	lines = append(lines, GuestCartLine{ProductID: productID, Size: size, Quantity: quantity})
+	return r.save(ctx, cookie, lines)
+}
+
+// Clear deletes the guest cart for cookie.
+func (r *GuestCartRepository) Clear(ctx context.Context, cookie string) error {
+	return r.client.Del(ctx, guestCartKey(cookie)).Err()
+}
+
+func (r *GuestCartRepository) save(ctx context.Context, cookie string, lines []GuestCartLine) error {
+	data, err := json.Marshal(lines)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, guestCartKey(cookie), data, guestCartTTL).Err()
+}
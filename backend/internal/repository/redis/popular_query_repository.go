@@ -0,0 +1,33 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const popularQueriesKey = "search:popular_queries"
+
+// PopularQueryRepository tracks how often each search query has been run,
+// backing the "popular queries" part of search autocomplete. It's a single
+// sorted set rather than one key per query, so ranking the top N is a
+// single ZREVRANGE instead of a scan.
+type PopularQueryRepository struct {
+	client *redis.Client
+}
+
+// NewPopularQueryRepository builds a PopularQueryRepository around an open
+// Redis client.
+func NewPopularQueryRepository(client *redis.Client) *PopularQueryRepository {
+	return &PopularQueryRepository{client: client}
+}
+
+// Record increments query's popularity score by one.
+func (r *PopularQueryRepository) Record(ctx context.Context, query string) error {
+	return r.client.ZIncrBy(ctx, popularQueriesKey, 1, query).Err()
+}
+
+// Top returns up to limit queries, most popular first.
+func (r *PopularQueryRepository) Top(ctx context.Context, limit int) ([]string, error) {
+	return r.client.ZRevRange(ctx, popularQueriesKey, 0, int64(limit)-1).Result()
+}
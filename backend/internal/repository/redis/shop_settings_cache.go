@@ -0,0 +1,54 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const shopSettingsKey = "shop_settings"
+
+// ShopSettingsCache caches the singleton ShopSettings row. Entries carry no
+// TTL, since ShopSettingsService.Update invalidates the key directly on
+// every write rather than waiting for it to expire.
+type ShopSettingsCache struct {
+	client *redis.Client
+}
+
+// NewShopSettingsCache builds a ShopSettingsCache around an open Redis client.
+func NewShopSettingsCache(client *redis.Client) *ShopSettingsCache {
+	return &ShopSettingsCache{client: client}
+}
+
+// Get returns the cached settings, or nil if nothing is cached.
+func (r *ShopSettingsCache) Get(ctx context.Context) (*models.ShopSettings, error) {
+	raw, err := r.client.Get(ctx, shopSettingsKey).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var settings models.ShopSettings
+	if err := json.Unmarshal([]byte(raw), &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// Set caches the settings.
+func (r *ShopSettingsCache) Set(ctx context.Context, settings *models.ShopSettings) error {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, shopSettingsKey, data, 0).Err()
+}
+
+// Invalidate drops the cached settings, forcing the next Get to re-read
+// from the repository.
+func (r *ShopSettingsCache) Invalidate(ctx context.Context) error {
+	return r.client.Del(ctx, shopSettingsKey).Err()
+}
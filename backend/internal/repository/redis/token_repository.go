@@ -0,0 +1,88 @@
+// Package redis contains Redis-backed repository implementations, mainly
+// used for session and token storage.
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/breaker"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrUnavailable is returned instead of hitting Redis once the circuit
+// breaker has tripped, so a caller like AuthMiddleware can tell "Redis is
+// down" apart from "no token found" and decide whether to degrade rather
+// than fail the request.
+var ErrUnavailable = errors.New("redis: circuit open, not attempting call")
+
+// breakerFailureThreshold and breakerCooldown match the values used
+// elsewhere in the codebase for guarding an unreliable dependency; there's
+// no traffic-derived tuning behind them yet.
+const (
+	breakerFailureThreshold = 5
+	breakerCooldown         = 30 * time.Second
+)
+
+// TokenRepository tracks issued JWT refresh tokens so they can be revoked.
+// Calls are guarded by a circuit breaker so a downed Redis fails fast
+// instead of every request blocking on its connection timeout.
+type TokenRepository struct {
+	client  *redis.Client
+	breaker *breaker.Breaker
+}
+
+// NewTokenRepository builds a TokenRepository around an open Redis client.
+func NewTokenRepository(client *redis.Client) *TokenRepository {
+	return &TokenRepository{
+		client:  client,
+		breaker: breaker.New(breakerFailureThreshold, breakerCooldown),
+	}
+}
+
+func tokenKey(userID uint) string {
+	return fmt.Sprintf("token:%d", userID)
+}
+
+// Save stores the current valid token for a user with the given TTL.
+func (r *TokenRepository) Save(ctx context.Context, userID uint, token string, ttl time.Duration) error {
+	if !r.breaker.Allow() {
+		return ErrUnavailable
+	}
+	err := r.client.Set(ctx, tokenKey(userID), token, ttl).Err()
+	r.record(err)
+	return err
+}
+
+// Get returns the currently valid token for a user, if any.
+func (r *TokenRepository) Get(ctx context.Context, userID uint) (string, error) {
+	if !r.breaker.Allow() {
+		return "", ErrUnavailable
+	}
+	token, err := r.client.Get(ctx, tokenKey(userID)).Result()
+	r.record(err)
+	return token, err
+}
+
+// Revoke deletes the stored token for a user, invalidating their session.
+func (r *TokenRepository) Revoke(ctx context.Context, userID uint) error {
+	if !r.breaker.Allow() {
+		return ErrUnavailable
+	}
+	err := r.client.Del(ctx, tokenKey(userID)).Err()
+	r.record(err)
+	return err
+}
+
+// record feeds the outcome of a call back into the breaker. redis.Nil (key
+// not found) is a normal, expected result, not a sign Redis is unhealthy,
+// so it counts as a success.
+func (r *TokenRepository) record(err error) {
+	if err != nil && err != redis.Nil {
+		r.breaker.Failure()
+		return
+	}
+	r.breaker.Success()
+}
@@ -0,0 +1,52 @@
+// Package retry provides a small exponential-backoff helper for operations
+// that may fail transiently on process startup, such as connecting to a
+// database or cache that hasn't finished coming up yet in docker-compose or
+// Kubernetes.
+package retry
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Config controls how Do backs off between attempts.
+type Config struct {
+	// InitialDelay is how long to wait before the second attempt. It
+	// doubles after every subsequent failure.
+	InitialDelay time.Duration
+	// MaxDelay caps the backoff so it doesn't grow unbounded.
+	MaxDelay time.Duration
+	// MaxElapsed is the total time budget across every attempt, including
+	// delays. Do gives up and returns the last error once it's exceeded.
+	MaxElapsed time.Duration
+}
+
+// Do calls fn, retrying with exponential backoff until it succeeds, ctx is
+// canceled, or MaxElapsed has passed since the first attempt.
+func Do(ctx context.Context, cfg Config, fn func() error) error {
+	start := time.Now()
+	delay := cfg.InitialDelay
+	attempt := 0
+	for {
+		attempt++
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if time.Since(start) >= cfg.MaxElapsed {
+			return fmt.Errorf("retry: giving up after %d attempts: %w", attempt, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > cfg.MaxDelay {
+			delay = cfg.MaxDelay
+		}
+	}
+}
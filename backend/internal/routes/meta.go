@@ -0,0 +1,214 @@
+package routes
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RouteMeta annotates a single registered route for the API changelog.
+type RouteMeta struct {
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	Version    string `json:"version"`
+	Auth       string `json:"auth"` // "none", "user", "admin"
+	Deprecated bool   `json:"deprecated"`
+	Notes      string `json:"notes,omitempty"`
+}
+
+// routeAnnotations documents every route this package registers. It is kept
+// next to Register rather than generated, since the annotations (version,
+// auth level, deprecation) are decisions made when a route is added, not
+// something the route table itself carries.
+var routeAnnotations = map[string]RouteMeta{
+	"GET /healthz":                                          {Version: "v1", Auth: "none"},
+	"GET /readyz":                                           {Version: "v1", Auth: "none"},
+	"GET /sitemap.xml":                                      {Version: "v1", Auth: "none"},
+	"POST /api/auth/register":                               {Version: "v1", Auth: "none"},
+	"POST /api/auth/login":                                  {Version: "v1", Auth: "none"},
+	"GET /api/admin/dashboard":                              {Version: "v1", Auth: "admin"},
+	"GET /api/admin/reports/sales":                          {Version: "v1", Auth: "admin", Notes: "Accept: text/csv for a CSV export"},
+	"GET /api/admin/reports/inventory":                      {Version: "v1", Auth: "admin", Notes: "Accept: text/csv for a CSV export"},
+	"GET /api/admin/reports/top-products":                   {Version: "v1", Auth: "admin", Notes: "cached 10m"},
+	"GET /api/admin/reports/category-revenue":               {Version: "v1", Auth: "admin", Notes: "cached 10m"},
+	"GET /api/admin/reports/top-search-queries":             {Version: "v1", Auth: "admin", Notes: "last 30 days"},
+	"GET /api/admin/reports/zero-result-queries":            {Version: "v1", Auth: "admin", Notes: "last 30 days"},
+	"GET /api/admin/customers/segments":                     {Version: "v1", Auth: "admin", Notes: "paginated, ordered by lifetime spend"},
+	"GET /api/admin/inventory/low-stock":                    {Version: "v1", Auth: "admin", Notes: "precomputed on a background schedule, not per request"},
+	"GET /api/admin/audit-logs":                             {Version: "v1", Auth: "admin"},
+	"GET /api/admin/orders/export":                          {Version: "v1", Auth: "admin"},
+	"GET /api/admin/orders":                                 {Version: "v1", Auth: "admin"},
+	"PATCH /api/admin/orders/:id/meta":                      {Version: "v1", Auth: "admin"},
+	"PATCH /api/admin/orders/:id/status":                    {Version: "v1", Auth: "admin"},
+	"GET /api/admin/products/:id/completeness":              {Version: "v1", Auth: "admin"},
+	"POST /api/admin/products/:id/publish":                  {Version: "v1", Auth: "admin"},
+	"DELETE /api/admin/products/:id":                        {Version: "v1", Auth: "admin"},
+	"GET /api/admin/products/deleted":                       {Version: "v1", Auth: "admin"},
+	"POST /api/admin/products/:id/restore":                  {Version: "v1", Auth: "admin"},
+	"POST /api/admin/media/bulk-assign":                     {Version: "v1", Auth: "admin"},
+	"GET /api/admin/media/bulk-assign/:jobID":               {Version: "v1", Auth: "admin"},
+	"GET /api/admin/users":                                  {Version: "v1", Auth: "admin", Notes: "search param matches email/first/last name"},
+	"GET /api/admin/users/:id":                              {Version: "v1", Auth: "admin", Notes: "includes order history"},
+	"PATCH /api/admin/users/:id/role":                       {Version: "v1", Auth: "admin"},
+	"POST /api/admin/users/:id/block":                       {Version: "v1", Auth: "admin", Notes: "revokes the user's current session"},
+	"POST /api/admin/users/:id/unblock":                     {Version: "v1", Auth: "admin"},
+	"GET /api/admin/carts/abandoned":                        {Version: "v1", Auth: "admin"},
+	"GET /api/admin/coupons":                                {Version: "v1", Auth: "admin"},
+	"POST /api/admin/coupons":                               {Version: "v1", Auth: "admin"},
+	"PUT /api/admin/coupons/:code":                          {Version: "v1", Auth: "admin"},
+	"DELETE /api/admin/coupons/:code":                       {Version: "v1", Auth: "admin"},
+	"PATCH /api/admin/products/:id/indexable":               {Version: "v1", Auth: "admin"},
+	"PATCH /api/admin/categories/:id/indexable":             {Version: "v1", Auth: "admin"},
+	"POST /api/orders/:id/reorder":                          {Version: "v1", Auth: "user"},
+	"POST /api/products/:id/reviews":                        {Version: "v1", Auth: "user"},
+	"GET /api/products/:id/delivery-estimate":               {Version: "v1", Auth: "none"},
+	"GET /api/products/:id/page":                            {Version: "v1", Auth: "none"},
+	"GET /api/products":                                     {Version: "v1", Auth: "none", Notes: "offset paginated by default, keyset when a cursor param is passed"},
+	"GET /api/products/trending":                            {Version: "v1", Auth: "none", Notes: "by page views over the last 3 days, cached 15m"},
+	"GET /api/products/bestsellers":                         {Version: "v1", Auth: "none", Notes: "by units sold over the last 30 days, cached 15m"},
+	"POST /api/products/batch":                              {Version: "v1", Auth: "none"},
+	"POST /api/categories/batch":                            {Version: "v1", Auth: "none"},
+	"PUT /api/admin/products/:id/translations/:locale":      {Version: "v1", Auth: "admin"},
+	"PUT /api/admin/categories/:id/translations/:locale":    {Version: "v1", Auth: "admin"},
+	"GET /api/cart":                                         {Version: "v1", Auth: "none", Notes: "guest carts served by cookie"},
+	"POST /api/cart":                                        {Version: "v1", Auth: "none", Notes: "guest carts served by cookie"},
+	"PUT /api/cart/:id":                                     {Version: "v1", Auth: "user"},
+	"DELETE /api/cart/:id":                                  {Version: "v1", Auth: "user"},
+	"DELETE /api/cart":                                      {Version: "v1", Auth: "user"},
+	"GET /api/cart/totals":                                  {Version: "v1", Auth: "user"},
+	"GET /api/cart/validate-stock":                          {Version: "v1", Auth: "user"},
+	"POST /api/cart/coupon":                                 {Version: "v1", Auth: "user"},
+	"DELETE /api/cart/coupon":                               {Version: "v1", Auth: "user"},
+	"GET /api/meta":                                         {Version: "v1", Auth: "none"},
+	"POST /api/cart/:id/save-for-later":                     {Version: "v1", Auth: "user"},
+	"POST /api/cart/saved/:id/move-to-cart":                 {Version: "v1", Auth: "user"},
+	"GET /api/wishlist":                                     {Version: "v1", Auth: "user"},
+	"POST /api/wishlist":                                    {Version: "v1", Auth: "user"},
+	"DELETE /api/wishlist/:id":                              {Version: "v1", Auth: "user"},
+	"POST /api/wishlist/:id/move-to-cart":                   {Version: "v1", Auth: "user"},
+	"POST /api/wishlist/share":                              {Version: "v1", Auth: "user"},
+	"DELETE /api/wishlist/share":                            {Version: "v1", Auth: "user"},
+	"GET /api/wishlists/shared/:token":                      {Version: "v1", Auth: "none"},
+	"POST /api/push/subscriptions":                          {Version: "v1", Auth: "user"},
+	"DELETE /api/push/subscriptions":                        {Version: "v1", Auth: "user"},
+	"GET /api/notifications":                                {Version: "v1", Auth: "user", Notes: "cross-channel notification history"},
+	"POST /api/cart/share":                                  {Version: "v1", Auth: "user"},
+	"POST /api/cart/shared/:token/import":                   {Version: "v1", Auth: "user"},
+	"GET /api/carts/shared/:token":                          {Version: "v1", Auth: "none"},
+	"PUT /api/reviews/:id":                                  {Version: "v1", Auth: "user"},
+	"DELETE /api/reviews/:id":                               {Version: "v1", Auth: "user"},
+	"GET /api/products/:id/reviews":                         {Version: "v1", Auth: "none"},
+	"GET /api/admin/reviews/pending":                        {Version: "v1", Auth: "admin"},
+	"PATCH /api/admin/reviews/:id/moderate":                 {Version: "v1", Auth: "admin"},
+	"POST /api/reviews/:id/photos":                          {Version: "v1", Auth: "user"},
+	"POST /api/reviews/:id/vote":                            {Version: "v1", Auth: "user"},
+	"POST /api/admin/reviews/:id/reply":                     {Version: "v1", Auth: "admin"},
+	"POST /api/orders/:id/pay":                              {Version: "v1", Auth: "user"},
+	"POST /api/payments/webhook":                            {Version: "v1", Auth: "none", Notes: "authenticated via gateway signature header, not a session"},
+	"POST /api/sms/callback":                                {Version: "v1", Auth: "none", Notes: "delivery-status callback from the SMS provider"},
+	"POST /api/admin/payments/:id/refund":                   {Version: "v1", Auth: "admin"},
+	"GET /api/admin/payment-methods":                        {Version: "v1", Auth: "admin"},
+	"PUT /api/admin/payment-methods/:key":                   {Version: "v1", Auth: "admin"},
+	"DELETE /api/admin/payment-methods/:key":                {Version: "v1", Auth: "admin"},
+	"GET /api/checkout/payment-methods":                     {Version: "v1", Auth: "none"},
+	"GET /api/saved-cards":                                  {Version: "v1", Auth: "user"},
+	"DELETE /api/saved-cards/:id":                           {Version: "v1", Auth: "user"},
+	"POST /api/admin/payments/:id/mark-collected":           {Version: "v1", Auth: "admin", Notes: "settles a cash-on-delivery payment"},
+	"GET /api/fx/convert":                                   {Version: "v1", Auth: "none"},
+	"POST /api/orders/:id/retry-payment":                    {Version: "v1", Auth: "user", Notes: "same handler as pay, rejects once max_retries attempts exist"},
+	"GET /api/admin/ledger/reconciliation":                  {Version: "v1", Auth: "admin"},
+	"GET /api/admin/orders/:id/ledger":                      {Version: "v1", Auth: "admin"},
+	"GET /api/admin/shipping-methods":                       {Version: "v1", Auth: "admin"},
+	"POST /api/admin/shipping-methods":                      {Version: "v1", Auth: "admin"},
+	"PUT /api/admin/shipping-methods/:id":                   {Version: "v1", Auth: "admin"},
+	"DELETE /api/admin/shipping-methods/:id":                {Version: "v1", Auth: "admin"},
+	"POST /api/shipping/quote":                              {Version: "v1", Auth: "none"},
+	"GET /api/addresses":                                    {Version: "v1", Auth: "user"},
+	"POST /api/addresses":                                   {Version: "v1", Auth: "user"},
+	"PUT /api/addresses/:id":                                {Version: "v1", Auth: "user"},
+	"DELETE /api/addresses/:id":                             {Version: "v1", Auth: "user"},
+	"GET /api/orders/:id/tracking":                          {Version: "v1", Auth: "user"},
+	"POST /api/admin/pickup-points/sync":                    {Version: "v1", Auth: "admin", Notes: "async, poll job status via GET .../sync/:jobID"},
+	"GET /api/admin/pickup-points/sync/:jobID":              {Version: "v1", Auth: "admin"},
+	"PUT /api/orders/:id/pickup-point":                      {Version: "v1", Auth: "user"},
+	"GET /api/pickup-points":                                {Version: "v1", Auth: "none"},
+	"GET /api/admin/delivery-slots":                         {Version: "v1", Auth: "admin"},
+	"POST /api/admin/delivery-slots":                        {Version: "v1", Auth: "admin"},
+	"PUT /api/admin/delivery-slots/:id":                     {Version: "v1", Auth: "admin"},
+	"DELETE /api/admin/delivery-slots/:id":                  {Version: "v1", Auth: "admin"},
+	"PUT /api/orders/:id/delivery-slot":                     {Version: "v1", Auth: "user"},
+	"GET /api/orders/:id/events":                            {Version: "v1", Auth: "user", Notes: "server-sent events, connection stays open"},
+	"GET /api/delivery-slots":                               {Version: "v1", Auth: "none"},
+	"GET /api/admin/collections":                            {Version: "v1", Auth: "admin"},
+	"POST /api/admin/collections":                           {Version: "v1", Auth: "admin"},
+	"PUT /api/admin/collections/:id":                        {Version: "v1", Auth: "admin"},
+	"DELETE /api/admin/collections/:id":                     {Version: "v1", Auth: "admin"},
+	"POST /api/admin/collections/:id/products":              {Version: "v1", Auth: "admin"},
+	"DELETE /api/admin/collections/:id/products/:productID": {Version: "v1", Auth: "admin"},
+	"GET /api/collections":                                  {Version: "v1", Auth: "none"},
+	"GET /api/collections/:slug":                            {Version: "v1", Auth: "none", Notes: "returns SEO metadata plus a paginated page of products"},
+	"POST /api/admin/collections/:id/cover-image":           {Version: "v1", Auth: "admin", Notes: "stores the original upload only; banner/thumbnail variants alias it until real resizing is added"},
+	"GET /api/settings":                                     {Version: "v1", Auth: "none", Notes: "public subset only"},
+	"PUT /api/admin/settings":                               {Version: "v1", Auth: "admin"},
+	"GET /api/admin/feature-flags":                          {Version: "v1", Auth: "admin"},
+	"PUT /api/admin/feature-flags/:key":                     {Version: "v1", Auth: "admin"},
+	"DELETE /api/admin/feature-flags/:key":                  {Version: "v1", Auth: "admin"},
+	"PATCH /api/admin/settings/maintenance":                 {Version: "v1", Auth: "admin"},
+	"GET /api/admin/warehouses":                             {Version: "v1", Auth: "admin"},
+	"POST /api/admin/warehouses":                            {Version: "v1", Auth: "admin"},
+	"PUT /api/admin/warehouses/:id":                         {Version: "v1", Auth: "admin"},
+	"DELETE /api/admin/warehouses/:id":                      {Version: "v1", Auth: "admin"},
+	"PUT /api/admin/warehouses/:id/stock/:productID":        {Version: "v1", Auth: "admin"},
+	"GET /api/admin/warehouse-stock/:productID":             {Version: "v1", Auth: "admin"},
+	"POST /api/admin/warehouse-stock/transfer":              {Version: "v1", Auth: "admin", Notes: "moves stock between warehouses"},
+	"GET /api/products/:id/availability":                    {Version: "v1", Auth: "none", Notes: "aggregate quantity across all warehouses"},
+	"GET /api/search":                                       {Version: "v1", Auth: "none", Notes: "falls back to Postgres full-text search if the search engine is unavailable; response includes facet counts"},
+	"GET /api/search/suggest":                               {Version: "v1", Auth: "none", Notes: "product/category name completions plus popular past queries"},
+	"POST /api/search/click":                                {Version: "v1", Auth: "none", Notes: "records a search result click for the top/zero-result queries admin report"},
+	"GET /api/products/:id/also-bought":                     {Version: "v1", Auth: "none", Notes: "precomputed from order co-occurrence on a background schedule"},
+	"GET /api/recommendations/for-you":                      {Version: "v1", Auth: "user", Notes: "derived from browsing history, empty until the user has viewed something"},
+	"GET /api/user/profile":                                 {Version: "v1", Auth: "user"},
+	"PATCH /api/user/profile":                               {Version: "v1", Auth: "user"},
+	"DELETE /api/user/account":                              {Version: "v1", Auth: "user", Notes: "anonymizes PII, keeps orders and reviews attached"},
+	"DELETE /api/admin/users/:id":                           {Version: "v1", Auth: "admin", Notes: "admin-initiated anonymization, same effect as the self-service endpoint"},
+	"GET /api/user/export":                                  {Version: "v1", Auth: "user", Notes: "async, poll job status via GET .../export/:jobID"},
+	"GET /api/user/export/:jobID":                           {Version: "v1", Auth: "user"},
+	"GET /api/user/export/:jobID/download":                  {Version: "v1", Auth: "user", Notes: "no mailer in this codebase yet, so the archive is pulled rather than emailed"},
+	"POST /api/user/avatar":                                 {Version: "v1", Auth: "user", Notes: "stores the original upload only; no crop/resize library in this codebase yet"},
+	"DELETE /api/user/avatar":                               {Version: "v1", Auth: "user"},
+	"PATCH /api/user/preferences":                           {Version: "v1", Auth: "user", Notes: "no notification subsystem in this codebase yet to enforce these flags against"},
+	"GET /api/user/stats":                                   {Version: "v1", Auth: "user", Notes: "active_return_requests counts pending refunds - there's no separate return-request concept"},
+	"GET /api/unsubscribe":                                  {Version: "v1", Auth: "none", Notes: "signed token in the query string identifies the user and preference"},
+}
+
+// Meta returns every route currently registered on the engine, enriched with
+// its annotation, so client teams can diff the API surface between deploys
+// without hand-maintained docs.
+func Meta(r *gin.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		routes := make([]RouteMeta, 0, len(r.Routes()))
+		for _, ri := range r.Routes() {
+			// The annotation table is keyed by the unversioned /api form, since
+			// registerAPI mounts the same routes under both /api/v1 and /api -
+			// strip the version segment before looking one up.
+			meta, ok := routeAnnotations[ri.Method+" "+unversioned(ri.Path)]
+			if !ok {
+				meta = RouteMeta{Version: "unversioned", Auth: "unknown"}
+			}
+			meta.Method = ri.Method
+			meta.Path = ri.Path
+			routes = append(routes, meta)
+		}
+		c.JSON(200, gin.H{"routes": routes})
+	}
+}
+
+func unversioned(path string) string {
+	if rest, ok := strings.CutPrefix(path, "/api/v1/"); ok {
+		return "/api/" + rest
+	}
+	if path == "/api/v1" {
+		return "/api"
+	}
+	return path
+}
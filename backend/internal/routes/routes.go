@@ -0,0 +1,385 @@
+// Package routes wires handlers and middleware onto the Gin engine.
+package routes
+
+import (
+	"github.com/YoungGoofy/shopping/internal/config"
+	"github.com/YoungGoofy/shopping/internal/handlers"
+	"github.com/YoungGoofy/shopping/internal/middleware"
+	"github.com/YoungGoofy/shopping/internal/ratelimit"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/repository/redis"
+	"github.com/YoungGoofy/shopping/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// Dependencies bundles everything routes need to register handlers.
+type Dependencies struct {
+	Health                  *handlers.HealthHandler
+	AuthHandler             *handlers.AuthHandler
+	AuditLogs               *psql.AuditLogRepository
+	AdminAuditLog           *handlers.AdminAuditLogHandler
+	AdminOrders             *handlers.AdminOrderHandler
+	AdminProducts           *handlers.AdminProductHandler
+	AdminMedia              *handlers.AdminMediaHandler
+	AdminUsers              *handlers.AdminUserHandler
+	AdminCarts              *handlers.AdminCartHandler
+	AdminCoupons            *handlers.AdminCouponHandler
+	AdminReviews            *handlers.AdminReviewHandler
+	AdminPayments           *handlers.AdminPaymentHandler
+	AdminPaymentMethods     *handlers.AdminPaymentMethodHandler
+	AdminLedger             *handlers.AdminLedgerHandler
+	AdminShippingMethods    *handlers.AdminShippingMethodHandler
+	AdminPickupPoints       *handlers.AdminPickupPointHandler
+	AdminDeliverySlots      *handlers.AdminDeliverySlotHandler
+	AdminCollections        *handlers.AdminCollectionHandler
+	AdminSettings           *handlers.AdminSettingsHandler
+	AdminFeatureFlags       *handlers.AdminFeatureFlagHandler
+	AdminWarehouses         *handlers.AdminWarehouseHandler
+	AdminWarehouseStock     *handlers.AdminWarehouseStockHandler
+	Availability            *handlers.AvailabilityHandler
+	Delivery                *handlers.DeliveryHandler
+	AdminSEO                *handlers.AdminSEOHandler
+	AdminTranslations       *handlers.AdminTranslationHandler
+	Products                *handlers.ProductHandler
+	AdminDashboard          *handlers.AdminDashboardHandler
+	AdminReports            *handlers.AdminReportHandler
+	AdminCustomers          *handlers.AdminCustomerHandler
+	AdminInventoryDashboard *handlers.AdminInventoryDashboardHandler
+	Sitemap                 *handlers.SitemapHandler
+	Orders                  *handlers.OrderHandler
+	Cart                    *handlers.CartHandler
+	Reviews                 *handlers.ReviewHandler
+	Wishlist                *handlers.WishlistHandler
+	Payments                *handlers.PaymentHandler
+	SavedCards              *handlers.SavedCardHandler
+	ExchangeRates           *handlers.ExchangeRateHandler
+	Shipping                *handlers.ShippingHandler
+	Addresses               *handlers.AddressHandler
+	UserProfile             *handlers.UserProfileHandler
+	UserExport              *handlers.UserExportHandler
+	UserStats               *handlers.UserStatsHandler
+	Unsubscribe             *handlers.UnsubscribeHandler
+	Tracking                *handlers.TrackingHandler
+	PickupPoints            *handlers.PickupPointHandler
+	DeliverySlots           *handlers.DeliverySlotHandler
+	Collections             *handlers.CollectionHandler
+	ProductPage             *handlers.ProductPageHandler
+	CatalogBatch            *handlers.CatalogBatchHandler
+	Search                  *handlers.SearchHandler
+	SMS                     *handlers.SMSHandler
+	Push                    *handlers.PushHandler
+	Notifications           *handlers.NotificationHandler
+	Recommendations         *handlers.RecommendationHandler
+	Settings                *handlers.SettingsHandler
+	ShopSettings            *service.ShopSettingsService
+	Tokens                  *redis.TokenRepository
+	JWTSecret               string
+	AllowDegradedAuth       bool
+	CORS                    config.CORSConfig
+	Gzip                    config.GzipConfig
+	RateLimiter             *ratelimit.Limiter
+	RateLimit               config.RateLimitConfig
+}
+
+// Register mounts every route group onto the engine.
+//
+// Routes live under /api/v1, with /api kept mounted as an unversioned
+// compatibility alias so existing clients (the mobile app, in particular)
+// don't break the day a v1 response shape needs to change. Add /api/v2 by
+// writing a new registerAPI-style function for the routes that changed and
+// mounting it at r.Group("/api/v2") alongside this call - routes that didn't
+// change for v2 can keep being served by registerAPI under the v2 group too.
+func Register(r *gin.Engine, deps Dependencies) {
+	r.Use(middleware.RequestID())
+	r.Use(middleware.Locale())
+	r.Use(middleware.AccessLog())
+	if deps.Gzip.Enabled {
+		r.Use(middleware.Gzip(deps.Gzip.MinSizeBytes))
+	}
+	if deps.CORS.Enabled() {
+		r.Use(middleware.CORS(deps.CORS))
+	}
+	r.Use(middleware.Maintenance(deps.ShopSettings))
+
+	r.GET("/healthz", deps.Health.Healthz)
+	r.GET("/readyz", deps.Health.Readyz)
+	r.GET("/sitemap.xml", deps.Sitemap.Serve)
+
+	registerAPI(r, r.Group("/api/v1"), deps)
+	registerAPI(r, r.Group("/api"), deps)
+}
+
+// registerAPI mounts the full set of /api routes onto the given group, which
+// may be the versioned /api/v1 group or the unversioned /api compatibility
+// alias - both dispatch to the exact same handlers.
+func registerAPI(r *gin.Engine, api *gin.RouterGroup, deps Dependencies) {
+	if deps.RateLimit.Enabled {
+		api.Use(middleware.RateLimit(deps.RateLimiter, deps.RateLimit.Default, "api"))
+	}
+	{
+		auth := api.Group("/auth")
+		if deps.RateLimit.Enabled {
+			auth.Use(middleware.RateLimit(deps.RateLimiter, deps.RateLimit.Auth, "auth"))
+		}
+		{
+			auth.POST("/register", deps.AuthHandler.Register)
+			auth.POST("/login", deps.AuthHandler.Login)
+		}
+
+		admin := api.Group("/admin")
+		admin.Use(middleware.Auth(deps.JWTSecret, deps.Tokens, deps.AllowDegradedAuth), middleware.RequireAdmin())
+		admin.Use(middleware.AuditLog(deps.AuditLogs))
+		{
+			admin.GET("/dashboard", deps.AdminDashboard.Get)
+			admin.GET("/reports/sales", deps.AdminReports.Sales)
+			admin.GET("/reports/inventory", deps.AdminReports.Inventory)
+			admin.GET("/reports/top-products", deps.AdminReports.TopProducts)
+			admin.GET("/reports/category-revenue", deps.AdminReports.CategoryRevenue)
+			admin.GET("/reports/top-search-queries", deps.AdminReports.TopSearchQueries)
+			admin.GET("/reports/zero-result-queries", deps.AdminReports.ZeroResultQueries)
+			admin.GET("/customers/segments", deps.AdminCustomers.Segments)
+			admin.GET("/inventory/low-stock", deps.AdminInventoryDashboard.LowStock)
+			admin.GET("/audit-logs", deps.AdminAuditLog.List)
+			admin.GET("/orders/export", deps.AdminOrders.ExportOrders)
+			admin.GET("/orders", deps.AdminOrders.ListByTag)
+			admin.PATCH("/orders/:id/meta", deps.AdminOrders.UpdateMeta)
+			admin.PATCH("/orders/:id/status", deps.AdminOrders.UpdateStatus)
+			admin.GET("/products/:id/completeness", deps.AdminProducts.CompletenessReport)
+			admin.POST("/products/:id/publish", deps.AdminProducts.Publish)
+			admin.DELETE("/products/:id", deps.AdminProducts.Delete)
+			admin.GET("/products/deleted", deps.AdminProducts.ListDeleted)
+			admin.POST("/products/:id/restore", deps.AdminProducts.Restore)
+			admin.POST("/media/bulk-assign", deps.AdminMedia.BulkAssignMedia)
+			admin.GET("/media/bulk-assign/:jobID", deps.AdminMedia.BulkAssignMediaStatus)
+			admin.GET("/users", deps.AdminUsers.List)
+			admin.GET("/users/:id", deps.AdminUsers.Get)
+			admin.PATCH("/users/:id/role", deps.AdminUsers.UpdateRole)
+			admin.POST("/users/:id/block", deps.AdminUsers.Block)
+			admin.POST("/users/:id/unblock", deps.AdminUsers.Unblock)
+			admin.DELETE("/users/:id", deps.AdminUsers.Anonymize)
+			admin.GET("/carts/abandoned", deps.AdminCarts.Abandoned)
+			admin.GET("/coupons", deps.AdminCoupons.List)
+			admin.POST("/coupons", deps.AdminCoupons.Create)
+			admin.PUT("/coupons/:code", deps.AdminCoupons.Update)
+			admin.DELETE("/coupons/:code", deps.AdminCoupons.Delete)
+			admin.PATCH("/products/:id/indexable", deps.AdminSEO.SetProductIndexable)
+			admin.PATCH("/categories/:id/indexable", deps.AdminSEO.SetCategoryIndexable)
+			admin.GET("/reviews/pending", deps.AdminReviews.Pending)
+			admin.PATCH("/reviews/:id/moderate", deps.AdminReviews.Moderate)
+			admin.POST("/reviews/:id/reply", deps.AdminReviews.Reply)
+			admin.POST("/payments/:id/refund", deps.AdminPayments.Refund)
+			admin.POST("/payments/:id/mark-collected", deps.AdminPayments.MarkCollected)
+			admin.GET("/payment-methods", deps.AdminPaymentMethods.List)
+			admin.PUT("/payment-methods/:key", deps.AdminPaymentMethods.Set)
+			admin.DELETE("/payment-methods/:key", deps.AdminPaymentMethods.Delete)
+			admin.GET("/ledger/reconciliation", deps.AdminLedger.Reconciliation)
+			admin.GET("/orders/:id/ledger", deps.AdminLedger.OrderLedger)
+			admin.GET("/shipping-methods", deps.AdminShippingMethods.List)
+			admin.POST("/shipping-methods", deps.AdminShippingMethods.Create)
+			admin.PUT("/shipping-methods/:id", deps.AdminShippingMethods.Update)
+			admin.DELETE("/shipping-methods/:id", deps.AdminShippingMethods.Delete)
+			admin.POST("/pickup-points/sync", deps.AdminPickupPoints.Sync)
+			admin.GET("/pickup-points/sync/:jobID", deps.AdminPickupPoints.SyncStatus)
+			admin.GET("/delivery-slots", deps.AdminDeliverySlots.List)
+			admin.POST("/delivery-slots", deps.AdminDeliverySlots.Create)
+			admin.PUT("/delivery-slots/:id", deps.AdminDeliverySlots.Update)
+			admin.DELETE("/delivery-slots/:id", deps.AdminDeliverySlots.Delete)
+			admin.GET("/collections", deps.AdminCollections.List)
+			admin.POST("/collections", deps.AdminCollections.Create)
+			admin.PUT("/collections/:id", deps.AdminCollections.Update)
+			admin.DELETE("/collections/:id", deps.AdminCollections.Delete)
+			admin.POST("/collections/:id/products", deps.AdminCollections.AddProduct)
+			admin.DELETE("/collections/:id/products/:productID", deps.AdminCollections.RemoveProduct)
+			admin.POST("/collections/:id/cover-image", deps.AdminCollections.UploadCoverImage)
+			admin.PUT("/settings", deps.AdminSettings.Update)
+			admin.PATCH("/settings/maintenance", deps.AdminSettings.SetMaintenance)
+			admin.GET("/feature-flags", deps.AdminFeatureFlags.List)
+			admin.PUT("/feature-flags/:key", deps.AdminFeatureFlags.Set)
+			admin.DELETE("/feature-flags/:key", deps.AdminFeatureFlags.Delete)
+			admin.GET("/warehouses", deps.AdminWarehouses.List)
+			admin.POST("/warehouses", deps.AdminWarehouses.Create)
+			admin.PUT("/warehouses/:id", deps.AdminWarehouses.Update)
+			admin.DELETE("/warehouses/:id", deps.AdminWarehouses.Delete)
+			admin.PUT("/warehouses/:id/stock/:productID", deps.AdminWarehouseStock.SetStock)
+			admin.GET("/warehouse-stock/:productID", deps.AdminWarehouseStock.ListForProduct)
+			admin.POST("/warehouse-stock/transfer", deps.AdminWarehouseStock.Transfer)
+			admin.PUT("/products/:id/translations/:locale", deps.AdminTranslations.SetProduct)
+			admin.PUT("/categories/:id/translations/:locale", deps.AdminTranslations.SetCategory)
+		}
+
+		orders := api.Group("/orders")
+		orders.Use(middleware.Auth(deps.JWTSecret, deps.Tokens, deps.AllowDegradedAuth))
+		{
+			orders.POST("", deps.Orders.Create)
+			orders.POST("/:id/reorder", deps.Orders.Reorder)
+			orders.POST("/:id/pay", deps.Payments.Pay)
+			orders.POST("/:id/retry-payment", deps.Payments.Pay)
+			orders.GET("/:id/tracking", deps.Tracking.Tracking)
+			orders.PUT("/:id/pickup-point", deps.Orders.SetPickupPoint)
+			orders.PUT("/:id/delivery-slot", deps.Orders.BookDeliverySlot)
+			orders.GET("/:id/events", deps.Orders.Events)
+		}
+
+		pickupPoints := api.Group("/pickup-points")
+		{
+			pickupPoints.GET("", deps.PickupPoints.Search)
+		}
+
+		api.GET("/delivery-slots", deps.DeliverySlots.Available)
+
+		collections := api.Group("/collections")
+		{
+			collections.GET("", deps.Collections.List)
+			collections.GET("/:slug", deps.Collections.Get)
+		}
+
+		savedCards := api.Group("/saved-cards")
+		savedCards.Use(middleware.Auth(deps.JWTSecret, deps.Tokens, deps.AllowDegradedAuth))
+		{
+			savedCards.GET("", deps.SavedCards.List)
+			savedCards.DELETE("/:id", deps.SavedCards.Delete)
+		}
+
+		addresses := api.Group("/addresses")
+		addresses.Use(middleware.Auth(deps.JWTSecret, deps.Tokens, deps.AllowDegradedAuth))
+		{
+			addresses.GET("", deps.Addresses.List)
+			addresses.POST("", deps.Addresses.Create)
+			addresses.PUT("/:id", deps.Addresses.Update)
+			addresses.DELETE("/:id", deps.Addresses.Delete)
+		}
+
+		userProfile := api.Group("/user")
+		userProfile.Use(middleware.Auth(deps.JWTSecret, deps.Tokens, deps.AllowDegradedAuth))
+		{
+			userProfile.GET("/profile", deps.UserProfile.Get)
+			userProfile.PATCH("/profile", deps.UserProfile.Update)
+			userProfile.DELETE("/account", deps.UserProfile.DeleteAccount)
+			userProfile.GET("/export", deps.UserExport.Export)
+			userProfile.GET("/export/:jobID", deps.UserExport.ExportStatus)
+			userProfile.GET("/export/:jobID/download", deps.UserExport.ExportDownload)
+			userProfile.POST("/avatar", deps.UserProfile.UploadAvatar)
+			userProfile.DELETE("/avatar", deps.UserProfile.RemoveAvatar)
+			userProfile.PATCH("/preferences", deps.UserProfile.UpdatePreferences)
+			userProfile.GET("/stats", deps.UserStats.Get)
+		}
+
+		// Reached from a link in an email/SMS, so it carries no session auth -
+		// the signed token in the query string identifies the user instead.
+		api.GET("/unsubscribe", deps.Unsubscribe.Unsubscribe)
+
+		products := api.Group("/products")
+		products.Use(middleware.Auth(deps.JWTSecret, deps.Tokens, deps.AllowDegradedAuth))
+		{
+			products.POST("/:id/reviews", deps.Reviews.Create)
+		}
+
+		reviewsAuthed := api.Group("/reviews")
+		reviewsAuthed.Use(middleware.Auth(deps.JWTSecret, deps.Tokens, deps.AllowDegradedAuth))
+		{
+			reviewsAuthed.PUT("/:id", deps.Reviews.Update)
+			reviewsAuthed.DELETE("/:id", deps.Reviews.Delete)
+			reviewsAuthed.POST("/:id/photos", deps.Reviews.AddPhotos)
+			reviewsAuthed.POST("/:id/vote", deps.Reviews.Vote)
+		}
+
+		recommendations := api.Group("/recommendations")
+		recommendations.Use(middleware.Auth(deps.JWTSecret, deps.Tokens, deps.AllowDegradedAuth))
+		{
+			recommendations.GET("/for-you", deps.Recommendations.ForYou)
+		}
+
+		// Public product endpoints, browsable without a session.
+		publicProducts := api.Group("/products")
+		{
+			publicProducts.GET("", deps.Products.List)
+			publicProducts.GET("/trending", deps.Products.Trending)
+			publicProducts.GET("/bestsellers", deps.Products.Bestsellers)
+			publicProducts.GET("/:id/delivery-estimate", deps.Delivery.Estimate)
+			publicProducts.GET("/:id/reviews", deps.Reviews.ListByProduct)
+			publicProducts.GET("/:id/page", middleware.OptionalAuth(deps.JWTSecret, deps.Tokens, deps.AllowDegradedAuth), deps.ProductPage.Get)
+			publicProducts.GET("/:id/also-bought", deps.Recommendations.AlsoBought)
+			publicProducts.POST("/batch", deps.CatalogBatch.Products)
+		}
+
+		api.POST("/categories/batch", deps.CatalogBatch.Categories)
+
+		// List/AddItem work for both guests and signed-in users, so they run
+		// under OptionalAuth + a guest cookie rather than requiring a session.
+		cart := api.Group("/cart")
+		cart.Use(middleware.OptionalAuth(deps.JWTSecret, deps.Tokens, deps.AllowDegradedAuth), middleware.GuestCookie())
+		{
+			cart.GET("", deps.Cart.List)
+			cart.POST("", deps.Cart.AddItem)
+		}
+
+		cartAuthed := api.Group("/cart")
+		cartAuthed.Use(middleware.Auth(deps.JWTSecret, deps.Tokens, deps.AllowDegradedAuth))
+		{
+			cartAuthed.PUT("/:id", deps.Cart.UpdateItem)
+			cartAuthed.DELETE("/:id", deps.Cart.RemoveItem)
+			cartAuthed.DELETE("", deps.Cart.Clear)
+			cartAuthed.GET("/totals", deps.Cart.Totals)
+			cartAuthed.GET("/validate-stock", deps.Cart.ValidateStock)
+			cartAuthed.POST("/coupon", deps.Cart.ApplyCoupon)
+			cartAuthed.DELETE("/coupon", deps.Cart.RemoveCoupon)
+			cartAuthed.POST("/:id/save-for-later", deps.Cart.SaveForLater)
+			cartAuthed.POST("/saved/:id/move-to-cart", deps.Cart.MoveToCart)
+			cartAuthed.POST("/share", deps.Cart.Share)
+			cartAuthed.POST("/shared/:token/import", deps.Cart.ImportShared)
+		}
+
+		// Public preview of a shared cart snapshot, no session required.
+		api.GET("/carts/shared/:token", deps.Cart.GetShared)
+
+		// The gateway calls this directly, so it carries no session auth -
+		// trust is established by the signature header instead.
+		api.POST("/payments/webhook", deps.Payments.Webhook)
+		api.POST("/sms/callback", deps.SMS.Callback)
+
+		// Browsable pre-checkout, so a guest can see which methods apply
+		// before signing in to place the order.
+		api.GET("/checkout/payment-methods", deps.Payments.EligiblePaymentMethods)
+
+		api.GET("/fx/convert", deps.ExchangeRates.Convert)
+
+		api.GET("/settings", deps.Settings.Get)
+
+		api.GET("/products/:id/availability", deps.Availability.Get)
+
+		api.GET("/search", deps.Search.Search)
+		api.GET("/search/suggest", deps.Search.Suggest)
+		api.POST("/search/click", deps.Search.Click)
+
+		api.POST("/shipping/quote", deps.Shipping.Quote)
+
+		wishlist := api.Group("/wishlist")
+		wishlist.Use(middleware.Auth(deps.JWTSecret, deps.Tokens, deps.AllowDegradedAuth))
+		{
+			wishlist.GET("", deps.Wishlist.List)
+			wishlist.POST("", deps.Wishlist.Add)
+			wishlist.DELETE("/:id", deps.Wishlist.Remove)
+			wishlist.POST("/:id/move-to-cart", deps.Wishlist.MoveToCart)
+			wishlist.POST("/share", deps.Wishlist.EnableShare)
+			wishlist.DELETE("/share", deps.Wishlist.DisableShare)
+		}
+
+		// Public, unauthenticated view of a wishlist someone chose to share.
+		api.GET("/wishlists/shared/:token", deps.Wishlist.Public)
+
+		push := api.Group("/push/subscriptions")
+		push.Use(middleware.Auth(deps.JWTSecret, deps.Tokens, deps.AllowDegradedAuth))
+		{
+			push.POST("", deps.Push.Register)
+			push.DELETE("", deps.Push.Unregister)
+		}
+
+		notifications := api.Group("/notifications")
+		notifications.Use(middleware.Auth(deps.JWTSecret, deps.Tokens, deps.AllowDegradedAuth))
+		{
+			notifications.GET("", deps.Notifications.History)
+		}
+
+		api.GET("/meta", Meta(r))
+	}
+}
@@ -0,0 +1,92 @@
+// Package scheduler runs a declaratively-registered set of recurring jobs,
+// each on its own fixed interval, with Redis-backed leader election so that
+// running several instances of this service doesn't run a job several
+// times per tick. It replaces the copy-pasted ticker+lock loop that used to
+// live on each of CollectionSchedulerService, ExchangeRateService and
+// friends with one implementation.
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/lock"
+	"github.com/sirupsen/logrus"
+)
+
+// Job is one recurring task: Run executes a single tick, and Interval is
+// how often it should run. Name identifies the job in logs and as its
+// leadership lock key, so it must be unique across every job registered
+// with the same Scheduler.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs a set of registered Jobs, each on its own goroutine and
+// ticker, until the context passed to Start is canceled.
+type Scheduler struct {
+	locks *lock.Manager
+	jobs  []Job
+}
+
+// New builds a Scheduler that elects leadership through locks.
+func New(locks *lock.Manager) *Scheduler {
+	return &Scheduler{locks: locks}
+}
+
+// Register adds job to the set Start will run. It must be called before
+// Start; jobs registered afterward are not picked up.
+func (s *Scheduler) Register(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start runs every registered job on its own interval until ctx is
+// canceled. Each job runs once immediately and then on every tick
+// thereafter.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		go s.run(ctx, job)
+	}
+}
+
+// run drives one job's ticker loop, only executing it when this instance
+// wins that job's leadership lock for the tick.
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	s.runIfLeader(ctx, job)
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runIfLeader(ctx, job)
+		}
+	}
+}
+
+// runIfLeader runs job.Run only if this instance wins job's leadership
+// lock for this tick, held for slightly less than job.Interval so it
+// naturally expires before the next tick starts even if Release is never
+// reached.
+func (s *Scheduler) runIfLeader(ctx context.Context, job Job) {
+	ttl := job.Interval - time.Second
+	if ttl <= 0 {
+		ttl = job.Interval
+	}
+	held, err := s.locks.Acquire(ctx, "lock:scheduler:"+job.Name, ttl)
+	if err != nil {
+		if err != lock.ErrNotHeld {
+			logrus.WithError(err).WithField("job", job.Name).Warn("scheduler: failed to acquire leadership lock")
+		}
+		return
+	}
+	defer held.Release(ctx)
+
+	if err := job.Run(ctx); err != nil {
+		logrus.WithError(err).WithField("job", job.Name).Warn("scheduler: run failed")
+	}
+}
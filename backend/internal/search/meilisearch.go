@@ -0,0 +1,127 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/breaker"
+)
+
+// breakerFailureThreshold and breakerCooldown match TokenRepository's and
+// Cache's values for guarding a dependency that can go down for a stretch;
+// there's no traffic-derived tuning behind them.
+const (
+	breakerFailureThreshold = 5
+	breakerCooldown         = 30 * time.Second
+)
+
+// MeilisearchEngine indexes and queries products through Meilisearch's
+// REST API (https://www.meilisearch.com/docs/reference/api/documents).
+// Calls are guarded by a circuit breaker so a caller (SearchHandler) fails
+// fast to its PostgreSQL fallback instead of blocking every request on a
+// dead engine's timeout.
+type MeilisearchEngine struct {
+	host    string
+	apiKey  string
+	index   string
+	client  *http.Client
+	breaker *breaker.Breaker
+}
+
+// NewMeilisearchEngine builds a MeilisearchEngine talking to host (e.g.
+// "http://localhost:7700") and the given index, authenticating with
+// apiKey.
+func NewMeilisearchEngine(host, apiKey, index string) *MeilisearchEngine {
+	return &MeilisearchEngine{
+		host:    host,
+		apiKey:  apiKey,
+		index:   index,
+		client:  &http.Client{Timeout: 5 * time.Second},
+		breaker: breaker.New(breakerFailureThreshold, breakerCooldown),
+	}
+}
+
+func (m *MeilisearchEngine) do(ctx context.Context, method, path string, body, out interface{}) error {
+	if !m.breaker.Allow() {
+		return fmt.Errorf("search: circuit open, meilisearch unavailable")
+	}
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, m.host+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if m.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+m.apiKey)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		m.breaker.Failure()
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		m.breaker.Failure()
+		return fmt.Errorf("search: meilisearch responded with status %d", resp.StatusCode)
+	}
+	m.breaker.Success()
+
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
+
+// IndexDocument creates or overwrites doc in the engine's index.
+func (m *MeilisearchEngine) IndexDocument(ctx context.Context, doc Document) error {
+	return m.do(ctx, http.MethodPost, fmt.Sprintf("/indexes/%s/documents", m.index), []Document{doc}, nil)
+}
+
+// DeleteDocument removes a product from the engine's index by ID.
+func (m *MeilisearchEngine) DeleteDocument(ctx context.Context, id string) error {
+	return m.do(ctx, http.MethodDelete, fmt.Sprintf("/indexes/%s/documents/%s", m.index, id), nil, nil)
+}
+
+type meilisearchSearchRequest struct {
+	Q     string `json:"q"`
+	Limit int    `json:"limit"`
+}
+
+type meilisearchHit struct {
+	Document
+	RankingScore float64 `json:"_rankingScore"`
+}
+
+type meilisearchSearchResponse struct {
+	Hits []meilisearchHit `json:"hits"`
+}
+
+// Search returns up to limit matches for query, ranked by Meilisearch's
+// own relevance scoring.
+func (m *MeilisearchEngine) Search(ctx context.Context, query string, limit int) ([]Hit, error) {
+	var resp meilisearchSearchResponse
+	if err := m.do(ctx, http.MethodPost, fmt.Sprintf("/indexes/%s/search", m.index), meilisearchSearchRequest{Q: query, Limit: limit}, &resp); err != nil {
+		return nil, err
+	}
+
+	hits := make([]Hit, len(resp.Hits))
+	for i, h := range resp.Hits {
+		hits[i] = Hit{Document: h.Document, Score: h.RankingScore}
+	}
+	return hits, nil
+}
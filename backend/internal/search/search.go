@@ -0,0 +1,34 @@
+// Package search defines the search-engine-agnostic interface used to
+// index and query products, plus a concrete Meilisearch implementation.
+package search
+
+import "context"
+
+// Document is what gets indexed for a product - a denormalized subset of
+// fields relevant to matching and ranking, not the full product row.
+type Document struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	CategoryID  uint    `json:"category_id"`
+	Price       float64 `json:"price"`
+}
+
+// Hit is one search result: a Document plus the engine's relevance score.
+type Hit struct {
+	Document
+	Score float64 `json:"score"`
+}
+
+// Engine is implemented by a search engine integration.
+type Engine interface {
+	// IndexDocument creates or overwrites doc in the engine's index.
+	IndexDocument(ctx context.Context, doc Document) error
+
+	// DeleteDocument removes a product from the engine's index by ID.
+	DeleteDocument(ctx context.Context, id string) error
+
+	// Search returns up to limit matches for query, ranked by the engine's
+	// own relevance scoring.
+	Search(ctx context.Context, query string, limit int) ([]Hit, error)
+}
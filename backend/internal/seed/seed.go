@@ -0,0 +1,166 @@
+// Package seed loads demo fixture data - categories, products, an admin
+// user and a sample order - into an otherwise empty database, for local
+// development and staging environments that start with nothing in them. It
+// is invoked as the "seed" argument to cmd/main.go, the same way "migrate"
+// runs the schema migrations.
+package seed
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+//go:embed fixtures.json
+var fixturesFile embed.FS
+
+type fixtures struct {
+	Categories []string         `json:"categories"`
+	Products   []fixtureProduct `json:"products"`
+	AdminUser  fixtureAdminUser `json:"admin_user"`
+	Orders     []fixtureOrder   `json:"orders"`
+}
+
+type fixtureProduct struct {
+	Name          string  `json:"name"`
+	Description   string  `json:"description"`
+	Price         float64 `json:"price"`
+	StockQuantity int     `json:"stock_quantity"`
+	Size          string  `json:"size"`
+	Color         string  `json:"color"`
+	Gender        string  `json:"gender"`
+	WeightGrams   int     `json:"weight_grams"`
+	Category      string  `json:"category"`
+	ArticleNumber string  `json:"article_number"`
+	Published     bool    `json:"published"`
+}
+
+type fixtureAdminUser struct {
+	Email     string `json:"email"`
+	Password  string `json:"password"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Role      string `json:"role"`
+}
+
+type fixtureOrder struct {
+	Status        string             `json:"status"`
+	PaymentStatus string             `json:"payment_status"`
+	PaymentMethod string             `json:"payment_method"`
+	Items         []fixtureOrderItem `json:"items"`
+}
+
+type fixtureOrderItem struct {
+	ArticleNumber string `json:"article_number"`
+	Quantity      int    `json:"quantity"`
+}
+
+// Run loads fixtures.json and inserts it into db via the same repositories
+// the API server uses, skipping entirely if any category already exists so
+// running it twice against a populated database is a no-op rather than a
+// pile of duplicates.
+func Run(ctx context.Context, db *gorm.DB) error {
+	categories := psql.NewCategoryRepository(psql.NewReplicatedDB(db))
+	products := psql.NewProductRepository(psql.NewReplicatedDB(db))
+	users := psql.NewUserRepository(db)
+	orders := psql.NewOrderRepository(db)
+
+	existing, err := categories.List(ctx)
+	if err != nil {
+		return fmt.Errorf("seed: list categories: %w", err)
+	}
+	if len(existing) > 0 {
+		return fmt.Errorf("seed: database already has categories, refusing to seed a non-empty database")
+	}
+
+	raw, err := fixturesFile.ReadFile("fixtures.json")
+	if err != nil {
+		return fmt.Errorf("seed: read fixtures.json: %w", err)
+	}
+	var fx fixtures
+	if err := json.Unmarshal(raw, &fx); err != nil {
+		return fmt.Errorf("seed: parse fixtures.json: %w", err)
+	}
+
+	categoryIDs := make(map[string]uint, len(fx.Categories))
+	for _, name := range fx.Categories {
+		category := &models.Category{Name: name}
+		if err := categories.Create(ctx, category); err != nil {
+			return fmt.Errorf("seed: create category %q: %w", name, err)
+		}
+		categoryIDs[name] = category.ID
+	}
+
+	productsByArticle := make(map[string]*models.Product, len(fx.Products))
+	for _, fp := range fx.Products {
+		product := &models.Product{
+			Name:          fp.Name,
+			Description:   fp.Description,
+			Price:         fp.Price,
+			StockQuantity: fp.StockQuantity,
+			Size:          fp.Size,
+			Color:         fp.Color,
+			Gender:        fp.Gender,
+			WeightGrams:   fp.WeightGrams,
+			CategoryID:    categoryIDs[fp.Category],
+			ArticleNumber: fp.ArticleNumber,
+			Published:     fp.Published,
+		}
+		if err := products.Create(ctx, product); err != nil {
+			return fmt.Errorf("seed: create product %q: %w", fp.ArticleNumber, err)
+		}
+		productsByArticle[fp.ArticleNumber] = product
+	}
+
+	hash, err := utils.HashPassword(fx.AdminUser.Password)
+	if err != nil {
+		return fmt.Errorf("seed: hash admin password: %w", err)
+	}
+	admin := &models.User{
+		Email:        fx.AdminUser.Email,
+		PasswordHash: hash,
+		FirstName:    fx.AdminUser.FirstName,
+		LastName:     fx.AdminUser.LastName,
+		Role:         fx.AdminUser.Role,
+	}
+	if err := users.Create(ctx, admin); err != nil {
+		return fmt.Errorf("seed: create admin user: %w", err)
+	}
+
+	for _, fo := range fx.Orders {
+		order := &models.Order{
+			UserID:        admin.ID,
+			Status:        fo.Status,
+			PaymentStatus: fo.PaymentStatus,
+			PaymentMethod: fo.PaymentMethod,
+		}
+		for _, fi := range fo.Items {
+			product, ok := productsByArticle[fi.ArticleNumber]
+			if !ok {
+				return fmt.Errorf("seed: order references unknown product %q", fi.ArticleNumber)
+			}
+			item := models.OrderItem{
+				ID:          uuid.New(),
+				ProductID:   product.ID,
+				Size:        product.Size,
+				Quantity:    fi.Quantity,
+				PriceAtTime: product.Price,
+			}
+			item.Snapshot(*product)
+			order.Items = append(order.Items, item)
+			order.TotalAmount += product.Price * float64(fi.Quantity)
+		}
+		if err := orders.Create(ctx, order); err != nil {
+			return fmt.Errorf("seed: create order: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/sirupsen/logrus"
+)
+
+// couponCodeCharset avoids visually ambiguous characters (0/O, 1/I) since
+// these codes get typed in by hand at checkout.
+const couponCodeCharset = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// AbandonedCartReminderService finds carts that have sat untouched past
+// CartConfig.AbandonedAfterDays and emails their owner a reminder, up to
+// MaxReminders times per cycle, spaced at least ReminderIntervalHours
+// apart. The first reminder in a cycle attaches an auto-generated coupon
+// (if ReminderCouponPercent is configured), which every later reminder in
+// the same cycle reuses. Run is registered with scheduler.Scheduler rather
+// than driving its own ticker.
+type AbandonedCartReminderService struct {
+	cart      *psql.CartRepository
+	users     *psql.UserRepository
+	reminders *psql.AbandonedCartReminderRepository
+	coupons   *psql.CouponRepository
+	email     *EmailService
+	baseURL   string
+
+	abandonedAfter    time.Duration
+	reminderInterval  time.Duration
+	maxReminders      int
+	couponPercent     int
+	couponValidPeriod time.Duration
+}
+
+// NewAbandonedCartReminderService builds an AbandonedCartReminderService
+// with its dependencies. couponPercent of 0 disables generating coupons.
+func NewAbandonedCartReminderService(cart *psql.CartRepository, users *psql.UserRepository, reminders *psql.AbandonedCartReminderRepository, coupons *psql.CouponRepository, email *EmailService, baseURL string, abandonedAfter, reminderInterval time.Duration, maxReminders, couponPercent int, couponValidPeriod time.Duration) *AbandonedCartReminderService {
+	return &AbandonedCartReminderService{
+		cart:              cart,
+		users:             users,
+		reminders:         reminders,
+		coupons:           coupons,
+		email:             email,
+		baseURL:           baseURL,
+		abandonedAfter:    abandonedAfter,
+		reminderInterval:  reminderInterval,
+		maxReminders:      maxReminders,
+		couponPercent:     couponPercent,
+		couponValidPeriod: couponValidPeriod,
+	}
+}
+
+// Run reminds every user whose cart is due a reminder. A failure reminding
+// one user (e.g. their email bounces) is logged and skipped rather than
+// aborting the run.
+func (s *AbandonedCartReminderService) Run(ctx context.Context) error {
+	items, err := s.cart.ListAbandoned(ctx, time.Now().Add(-s.abandonedAfter))
+	if err != nil {
+		return err
+	}
+
+	byUser := make(map[uint][]models.CartItem)
+	for _, item := range items {
+		byUser[item.UserID] = append(byUser[item.UserID], item)
+	}
+
+	for userID, userItems := range byUser {
+		if err := s.remind(ctx, userID, userItems); err != nil {
+			logrus.WithError(err).WithField("user_id", userID).Warn("abandoned cart reminder: failed to remind user")
+		}
+	}
+
+	return nil
+}
+
+func (s *AbandonedCartReminderService) remind(ctx context.Context, userID uint, items []models.CartItem) error {
+	reminder, err := s.reminders.GetOpen(ctx, userID)
+	if err != nil {
+		reminder = &models.AbandonedCartReminder{UserID: userID}
+		if err := s.reminders.Create(ctx, reminder); err != nil {
+			return err
+		}
+	}
+
+	if reminder.RemindersSent >= s.maxReminders {
+		return nil
+	}
+	if !reminder.LastReminderDate.IsZero() && time.Since(reminder.LastReminderDate) < s.reminderInterval {
+		return nil
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	couponCode := reminder.CouponCode
+	newCoupon := ""
+	if couponCode == "" && s.couponPercent > 0 {
+		couponCode, err = s.generateCoupon(ctx)
+		if err != nil {
+			return err
+		}
+		newCoupon = couponCode
+	}
+
+	data := map[string]interface{}{
+		"Name":          user.FirstName,
+		"Items":         reminderLines(items),
+		"CartURL":       s.baseURL + "/cart",
+		"CouponCode":    couponCode,
+		"CouponPercent": s.couponPercent,
+	}
+	if err := s.email.Send(ctx, user.Email, "abandoned_cart", data); err != nil {
+		return err
+	}
+
+	return s.reminders.RecordSent(ctx, reminder.ID, newCoupon)
+}
+
+// reminderLine is one cart line as rendered in the reminder email.
+type reminderLine struct {
+	Name     string
+	Size     string
+	Quantity int
+	Price    float64
+}
+
+func reminderLines(items []models.CartItem) []reminderLine {
+	lines := make([]reminderLine, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, reminderLine{
+			Name:     item.Product.Name,
+			Size:     item.Size,
+			Quantity: item.Quantity,
+			Price:    item.PriceAtAdd,
+		})
+	}
+	return lines
+}
+
+// generateCoupon creates a single-use percent-off coupon good for
+// couponValidPeriod, for attaching to a reminder cycle's first email.
+func (s *AbandonedCartReminderService) generateCoupon(ctx context.Context) (string, error) {
+	code, err := randomCouponCode()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	coupon := &models.Coupon{
+		Code:       code,
+		Type:       "percent",
+		Amount:     float64(s.couponPercent),
+		MaxUses:    1,
+		ValidFrom:  now,
+		ValidUntil: now.Add(s.couponValidPeriod),
+		Active:     true,
+	}
+	if err := s.coupons.Create(ctx, coupon); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+func randomCouponCode() (string, error) {
+	const length = 8
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := make([]byte, length)
+	for i, v := range b {
+		code[i] = couponCodeCharset[int(v)%len(couponCodeCharset)]
+	}
+	return fmt.Sprintf("COMEBACK-%s", code), nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/carrier"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// CarrierService registers order shipments with a carrier.Provider and
+// periodically polls it for tracking updates, writing every new event to
+// the shipment's history.
+type CarrierService struct {
+	provider  carrier.Provider
+	shipments *psql.ShipmentRepository
+}
+
+// NewCarrierService builds a CarrierService around a single carrier
+// provider.
+func NewCarrierService(provider carrier.Provider, shipments *psql.ShipmentRepository) *CarrierService {
+	return &CarrierService{provider: provider, shipments: shipments}
+}
+
+// RegisterShipment creates a carrier waybill for an order and saves the
+// resulting Shipment.
+func (s *CarrierService) RegisterShipment(ctx context.Context, orderID uuid.UUID, req carrier.RegisterShipmentRequest) (*models.Shipment, error) {
+	result, err := s.provider.RegisterShipment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	shipment := &models.Shipment{
+		OrderID:        orderID,
+		Carrier:        s.provider.Name(),
+		TrackingNumber: result.TrackingNumber,
+		Status:         models.ShipmentStatusCreated,
+	}
+	if err := s.shipments.Create(ctx, shipment); err != nil {
+		return nil, err
+	}
+	return shipment, nil
+}
+
+// Poll fetches tracking status for every active shipment and appends
+// whatever events the carrier has reported since the last poll. A failure
+// tracking one shipment is logged and skipped rather than aborting the
+// whole run.
+func (s *CarrierService) Poll(ctx context.Context) error {
+	shipments, err := s.shipments.ListActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, shipment := range shipments {
+		if err := s.pollOne(ctx, shipment); err != nil {
+			logrus.WithError(err).WithField("shipment_id", shipment.ID).Warn("carrier: failed to poll tracking")
+		}
+	}
+	return nil
+}
+
+func (s *CarrierService) pollOne(ctx context.Context, shipment models.Shipment) error {
+	status, err := s.provider.Track(ctx, shipment.TrackingNumber)
+	if err != nil {
+		return err
+	}
+
+	since, err := s.shipments.LatestEventTime(ctx, shipment.ID)
+	if err != nil {
+		return err
+	}
+
+	var newEvents []*models.TrackingEvent
+	for _, event := range status.Events {
+		if !event.OccurredAt.After(since) {
+			continue
+		}
+		newEvents = append(newEvents, &models.TrackingEvent{
+			ShipmentID:  shipment.ID,
+			Status:      event.Status,
+			Description: event.Description,
+			OccurredAt:  event.OccurredAt,
+		})
+	}
+	if err := s.shipments.AppendEvents(ctx, newEvents...); err != nil {
+		return err
+	}
+
+	if status.Status != shipment.Status {
+		return s.shipments.UpdateStatus(ctx, shipment.ID, status.Status)
+	}
+	return nil
+}
+
+// Start polls tracking status on a fixed interval until ctx is canceled.
+func (s *CarrierService) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.Poll(ctx)
+			}
+		}
+	}()
+}
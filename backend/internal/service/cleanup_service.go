@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/sirupsen/logrus"
+)
+
+// CleanupService purges old, settled records so a handful of
+// append-mostly tables don't grow without bound. Run is registered with
+// scheduler.Scheduler rather than driving its own ticker.
+//
+// This only covers what this codebase actually has a repository for:
+// settled (published/failed) transactional outbox events and payment
+// webhook logs. It intentionally does not purge password-reset or
+// verification tokens or idempotency keys - no repository for any of
+// those exists in this codebase yet - or stale guest carts, which already
+// expire on their own via redis.GuestCartRepository's TTL.
+type CleanupService struct {
+	outboxEvents  *psql.OutboxEventRepository
+	webhookEvents *psql.PaymentWebhookEventRepository
+
+	outboxRetention     time.Duration
+	webhookLogRetention time.Duration
+}
+
+// NewCleanupService builds a CleanupService with its dependencies.
+func NewCleanupService(outboxEvents *psql.OutboxEventRepository, webhookEvents *psql.PaymentWebhookEventRepository, outboxRetention, webhookLogRetention time.Duration) *CleanupService {
+	return &CleanupService{outboxEvents: outboxEvents, webhookEvents: webhookEvents, outboxRetention: outboxRetention, webhookLogRetention: webhookLogRetention}
+}
+
+// Run purges every table this service is responsible for. A failure
+// purging one table is logged and does not stop the others from running.
+func (s *CleanupService) Run(ctx context.Context) error {
+	if deleted, err := s.outboxEvents.DeleteSettledOlderThan(ctx, time.Now().Add(-s.outboxRetention)); err != nil {
+		logrus.WithError(err).Warn("cleanup: failed to purge settled outbox events")
+	} else if deleted > 0 {
+		logrus.WithField("deleted", deleted).Info("cleanup: purged settled outbox events")
+	}
+
+	if deleted, err := s.webhookEvents.DeleteOlderThan(ctx, time.Now().Add(-s.webhookLogRetention)); err != nil {
+		logrus.WithError(err).Warn("cleanup: failed to purge payment webhook logs")
+	} else if deleted > 0 {
+		logrus.WithField("deleted", deleted).Info("cleanup: purged payment webhook logs")
+	}
+
+	return nil
+}
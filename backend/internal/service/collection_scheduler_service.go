@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/sirupsen/logrus"
+)
+
+// CollectionSchedulerService periodically flips Collection.IsActive to match
+// LaunchDate and EndDate, so marketing can stage a drop by setting dates
+// ahead of time instead of toggling it on and off by hand. Each transition
+// is logged, since this codebase has no email/Slack notification channel
+// for marketing to subscribe to yet - the log line is the event. Run is
+// registered with scheduler.Scheduler rather than driving its own ticker.
+type CollectionSchedulerService struct {
+	collections *psql.CollectionRepository
+}
+
+// NewCollectionSchedulerService builds a CollectionSchedulerService with its
+// dependencies.
+func NewCollectionSchedulerService(collections *psql.CollectionRepository) *CollectionSchedulerService {
+	return &CollectionSchedulerService{collections: collections}
+}
+
+// Run activates every collection whose LaunchDate has arrived and retires
+// every collection whose EndDate has passed. A failure updating one
+// collection is logged and skipped rather than aborting the whole run.
+func (s *CollectionSchedulerService) Run(ctx context.Context) error {
+	now := time.Now()
+
+	launching, err := s.collections.ListDueToLaunch(ctx, now)
+	if err != nil {
+		return err
+	}
+	for _, collection := range launching {
+		if err := s.collections.SetActive(ctx, collection.ID, true); err != nil {
+			logrus.WithError(err).WithField("collection_id", collection.ID).Warn("collection scheduler: failed to activate collection")
+			continue
+		}
+		logrus.WithField("collection_id", collection.ID).WithField("slug", collection.Slug).Info("collection scheduler: launched collection")
+	}
+
+	retiring, err := s.collections.ListDueToRetire(ctx, now)
+	if err != nil {
+		return err
+	}
+	for _, collection := range retiring {
+		if err := s.collections.SetActive(ctx, collection.ID, false); err != nil {
+			logrus.WithError(err).WithField("collection_id", collection.ID).Warn("collection scheduler: failed to retire collection")
+			continue
+		}
+		logrus.WithField("collection_id", collection.ID).WithField("slug", collection.Slug).Info("collection scheduler: retired collection")
+	}
+
+	return nil
+}
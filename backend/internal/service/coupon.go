@@ -0,0 +1,57 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+)
+
+// ErrCouponNotApplicable is returned when a coupon fails one of its own
+// constraints (validity window, usage limit, minimum order total, category
+// restriction) for the cart it is being applied to.
+var ErrCouponNotApplicable = errors.New("coupon is not applicable to this cart")
+
+// CouponService validates a coupon against a cart and computes its discount.
+type CouponService struct{}
+
+// NewCouponService builds a CouponService.
+func NewCouponService() *CouponService {
+	return &CouponService{}
+}
+
+// Apply checks coupon against subtotal and the categories present in the
+// cart, returning the discount amount if it applies or
+// ErrCouponNotApplicable otherwise.
+func (s *CouponService) Apply(coupon models.Coupon, subtotal float64, cartCategoryIDs []uint) (float64, error) {
+	now := time.Now()
+	if !coupon.Active {
+		return 0, ErrCouponNotApplicable
+	}
+	if !coupon.ValidFrom.IsZero() && now.Before(coupon.ValidFrom) {
+		return 0, ErrCouponNotApplicable
+	}
+	if !coupon.ValidUntil.IsZero() && now.After(coupon.ValidUntil) {
+		return 0, ErrCouponNotApplicable
+	}
+	if coupon.MaxUses > 0 && coupon.UsedCount >= coupon.MaxUses {
+		return 0, ErrCouponNotApplicable
+	}
+	if subtotal < coupon.MinOrderTotal {
+		return 0, ErrCouponNotApplicable
+	}
+	if coupon.CategoryID != nil {
+		matched := false
+		for _, id := range cartCategoryIDs {
+			if id == *coupon.CategoryID {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return 0, ErrCouponNotApplicable
+		}
+	}
+
+	return coupon.DiscountFor(subtotal), nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+)
+
+func TestCouponServiceApplyPercent(t *testing.T) {
+	s := NewCouponService()
+	coupon := models.Coupon{Active: true, Type: "percent", Amount: 10}
+
+	discount, err := s.Apply(coupon, 200, nil)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if discount != 20 {
+		t.Errorf("discount = %v, want 20", discount)
+	}
+}
+
+func TestCouponServiceApplyFixed(t *testing.T) {
+	s := NewCouponService()
+	coupon := models.Coupon{Active: true, Type: "fixed", Amount: 15}
+
+	discount, err := s.Apply(coupon, 50, nil)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if discount != 15 {
+		t.Errorf("discount = %v, want 15", discount)
+	}
+}
+
+func TestCouponServiceApplyRejectsInactive(t *testing.T) {
+	s := NewCouponService()
+	coupon := models.Coupon{Active: false, Type: "fixed", Amount: 15}
+
+	if _, err := s.Apply(coupon, 50, nil); err != ErrCouponNotApplicable {
+		t.Errorf("err = %v, want ErrCouponNotApplicable", err)
+	}
+}
+
+func TestCouponServiceApplyRejectsOutsideValidityWindow(t *testing.T) {
+	s := NewCouponService()
+	now := time.Now()
+
+	notYetValid := models.Coupon{Active: true, Type: "fixed", Amount: 5, ValidFrom: now.Add(time.Hour)}
+	if _, err := s.Apply(notYetValid, 50, nil); err != ErrCouponNotApplicable {
+		t.Errorf("not-yet-valid coupon: err = %v, want ErrCouponNotApplicable", err)
+	}
+
+	expired := models.Coupon{Active: true, Type: "fixed", Amount: 5, ValidUntil: now.Add(-time.Hour)}
+	if _, err := s.Apply(expired, 50, nil); err != ErrCouponNotApplicable {
+		t.Errorf("expired coupon: err = %v, want ErrCouponNotApplicable", err)
+	}
+}
+
+func TestCouponServiceApplyRejectsAtUsageLimit(t *testing.T) {
+	s := NewCouponService()
+	coupon := models.Coupon{Active: true, Type: "fixed", Amount: 5, MaxUses: 3, UsedCount: 3}
+
+	if _, err := s.Apply(coupon, 50, nil); err != ErrCouponNotApplicable {
+		t.Errorf("err = %v, want ErrCouponNotApplicable", err)
+	}
+}
+
+func TestCouponServiceApplyAllowsUnlimitedUses(t *testing.T) {
+	s := NewCouponService()
+	coupon := models.Coupon{Active: true, Type: "fixed", Amount: 5, MaxUses: 0, UsedCount: 1000}
+
+	if _, err := s.Apply(coupon, 50, nil); err != nil {
+		t.Errorf("MaxUses=0 should mean unlimited, got err = %v", err)
+	}
+}
+
+func TestCouponServiceApplyRejectsBelowMinOrderTotal(t *testing.T) {
+	s := NewCouponService()
+	coupon := models.Coupon{Active: true, Type: "fixed", Amount: 5, MinOrderTotal: 100}
+
+	if _, err := s.Apply(coupon, 50, nil); err != ErrCouponNotApplicable {
+		t.Errorf("err = %v, want ErrCouponNotApplicable", err)
+	}
+}
+
+func TestCouponServiceApplyRequiresMatchingCategory(t *testing.T) {
+	s := NewCouponService()
+	restrictedCategory := uint(7)
+	coupon := models.Coupon{Active: true, Type: "fixed", Amount: 5, CategoryID: &restrictedCategory}
+
+	if _, err := s.Apply(coupon, 50, []uint{1, 2, 3}); err != ErrCouponNotApplicable {
+		t.Errorf("no matching category: err = %v, want ErrCouponNotApplicable", err)
+	}
+	if _, err := s.Apply(coupon, 50, []uint{1, restrictedCategory}); err != nil {
+		t.Errorf("matching category should apply, got err = %v", err)
+	}
+}
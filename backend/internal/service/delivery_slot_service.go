@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/google/uuid"
+)
+
+// DeliverySlotService resolves which recurring DeliverySlots have capacity
+// left on a given calendar date, and books an order against one.
+type DeliverySlotService struct {
+	slots *psql.DeliverySlotRepository
+}
+
+// NewDeliverySlotService builds a DeliverySlotService with its dependencies.
+func NewDeliverySlotService(slots *psql.DeliverySlotRepository) *DeliverySlotService {
+	return &DeliverySlotService{slots: slots}
+}
+
+// AvailableSlot pairs a DeliverySlot with how many bookings it has left on
+// the requested date.
+type AvailableSlot struct {
+	Slot      models.DeliverySlot `json:"slot"`
+	Remaining int                 `json:"remaining"`
+}
+
+// Available returns every slot that recurs on date's weekday and still has
+// room, along with how many bookings each has left.
+func (s *DeliverySlotService) Available(ctx context.Context, date time.Time) ([]AvailableSlot, error) {
+	slots, err := s.slots.ListEnabledForWeekday(ctx, int(date.Weekday()))
+	if err != nil {
+		return nil, err
+	}
+
+	available := make([]AvailableSlot, 0, len(slots))
+	for _, slot := range slots {
+		booked, err := s.slots.CountForDate(ctx, slot.ID, date)
+		if err != nil {
+			return nil, err
+		}
+		remaining := slot.Capacity - int(booked)
+		if remaining <= 0 {
+			continue
+		}
+		available = append(available, AvailableSlot{Slot: slot, Remaining: remaining})
+	}
+	return available, nil
+}
+
+// Book claims a slot for an order on a date, rejecting the booking once the
+// slot's capacity for that date is already spoken for.
+func (s *DeliverySlotService) Book(ctx context.Context, orderID, slotID uuid.UUID, date time.Time) error {
+	slot, err := s.slots.GetByID(ctx, slotID.String())
+	if err != nil {
+		return err
+	}
+	if !slot.Enabled || slot.Weekday != int(date.Weekday()) {
+		return fmt.Errorf("service: slot is not available on %s", date.Format("2006-01-02"))
+	}
+
+	booked, err := s.slots.CountForDate(ctx, slotID, date)
+	if err != nil {
+		return err
+	}
+	if int(booked) >= slot.Capacity {
+		return fmt.Errorf("service: slot is fully booked on %s", date.Format("2006-01-02"))
+	}
+
+	return s.slots.Book(ctx, &models.DeliverySlotBooking{SlotID: slotID, OrderID: orderID, Date: date})
+}
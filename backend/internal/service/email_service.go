@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/mailer"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/sirupsen/logrus"
+)
+
+// EmailService renders a named mailer template, sends it through a
+// mailer.Provider with a few immediate retries for transient failures, and
+// records the outcome to EmailLogRepository regardless of whether it
+// eventually succeeded.
+type EmailService struct {
+	provider mailer.Provider
+	renderer *mailer.Renderer
+	logs     *psql.EmailLogRepository
+	settings *psql.ShopSettingsRepository
+
+	from        string
+	maxAttempts int
+	retryDelay  time.Duration
+}
+
+// NewEmailService builds an EmailService with its dependencies. from is the
+// envelope/header From address every message is sent as.
+func NewEmailService(provider mailer.Provider, renderer *mailer.Renderer, logs *psql.EmailLogRepository, settings *psql.ShopSettingsRepository, from string, maxAttempts int, retryDelay time.Duration) *EmailService {
+	return &EmailService{provider: provider, renderer: renderer, logs: logs, settings: settings, from: from, maxAttempts: maxAttempts, retryDelay: retryDelay}
+}
+
+// Send renders template against data - merged with the shop's name and
+// support address so every template can reference {{.ShopName}} and
+// {{.SupportEmail}} without every call site passing them - and sends the
+// result to. A transient send failure is retried up to maxAttempts times
+// before Send gives up and returns the last error; either way, the
+// attempt is logged.
+func (s *EmailService) Send(ctx context.Context, to, template string, data map[string]interface{}) error {
+	shop, err := s.settings.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	merged := make(map[string]interface{}, len(data)+2)
+	for k, v := range data {
+		merged[k] = v
+	}
+	merged["ShopName"] = shop.Name
+	merged["SupportEmail"] = shop.SupportEmail
+
+	rendered, err := s.renderer.Render(template, merged)
+	if err != nil {
+		return err
+	}
+
+	msg := mailer.Message{From: s.from, To: to, Subject: rendered.Subject, HTML: rendered.HTML, Text: rendered.Text}
+
+	attempts := 0
+	var sendErr error
+	for attempts < s.maxAttempts {
+		attempts++
+		sendErr = s.provider.Send(ctx, msg)
+		if sendErr == nil {
+			break
+		}
+		logrus.WithError(sendErr).WithField("to", to).WithField("template", template).WithField("attempt", attempts).Warn("mailer: send attempt failed")
+		if attempts < s.maxAttempts {
+			time.Sleep(s.retryDelay)
+		}
+	}
+
+	log := &models.EmailLog{To: to, TemplateName: template, Subject: rendered.Subject, Attempts: attempts}
+	if sendErr != nil {
+		log.Status = models.EmailStatusFailed
+		log.LastError = sendErr.Error()
+	} else {
+		log.Status = models.EmailStatusSent
+		now := time.Now()
+		log.SentDate = &now
+	}
+	if logErr := s.logs.Create(ctx, log); logErr != nil {
+		logrus.WithError(logErr).WithField("to", to).WithField("template", template).Error("mailer: failed to record email log")
+	}
+
+	return sendErr
+}
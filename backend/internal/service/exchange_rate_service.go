@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/YoungGoofy/shopping/internal/fx"
+	"github.com/YoungGoofy/shopping/internal/repository/redis"
+)
+
+// ExchangeRateService periodically refreshes FX rates from a configurable
+// fx.Source into Redis, and converts amounts between currencies using
+// the latest cached rates. Multi-currency pricing and payment amount
+// calculation go through Convert rather than talking to the source
+// directly, so a source outage never blocks either - Convert just keeps
+// using whatever was cached last.
+type ExchangeRateService struct {
+	source fx.Source
+	cache  *redis.ExchangeRateCache
+	base   string
+}
+
+// NewExchangeRateService builds an ExchangeRateService quoting every rate
+// against base (e.g. "USD").
+func NewExchangeRateService(source fx.Source, cache *redis.ExchangeRateCache, base string) *ExchangeRateService {
+	return &ExchangeRateService{source: source, cache: cache, base: base}
+}
+
+// Refresh fetches the latest rates from the source and caches them. On
+// failure the previously cached rates are left untouched.
+func (s *ExchangeRateService) Refresh(ctx context.Context) error {
+	rates, err := s.source.FetchRates(ctx, s.base)
+	if err != nil {
+		return err
+	}
+	return s.cache.Set(ctx, s.base, rates)
+}
+
+// Convert converts amount from one ISO 4217 currency code to another
+// using the latest cached rates.
+func (s *ExchangeRateService) Convert(ctx context.Context, amount float64, from, to string) (float64, error) {
+	if from == to {
+		return amount, nil
+	}
+
+	rates, err := s.cache.Get(ctx, s.base)
+	if err != nil {
+		return 0, err
+	}
+	if rates == nil {
+		return 0, fmt.Errorf("service: no exchange rates cached yet")
+	}
+
+	fromRate, err := s.rateFor(rates, from)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := s.rateFor(rates, to)
+	if err != nil {
+		return 0, err
+	}
+
+	return amount / fromRate * toRate, nil
+}
+
+// rateFor returns how many units of currency equal one unit of the base
+// currency, using rates fetched from the source.
+func (s *ExchangeRateService) rateFor(rates map[string]float64, currency string) (float64, error) {
+	if currency == s.base {
+		return 1, nil
+	}
+	rate, ok := rates[currency]
+	if !ok {
+		return 0, fmt.Errorf("service: no rate cached for %s", currency)
+	}
+	return rate, nil
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/featureflag"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+)
+
+// FeatureFlagService looks up flags and evaluates them for a given user.
+type FeatureFlagService struct {
+	flags *psql.FeatureFlagRepository
+}
+
+// NewFeatureFlagService builds a FeatureFlagService with its dependencies.
+func NewFeatureFlagService(flags *psql.FeatureFlagRepository) *FeatureFlagService {
+	return &FeatureFlagService{flags: flags}
+}
+
+// IsEnabled reports whether key is on for userID. An unknown key is treated
+// as disabled rather than an error, so a handler can gate on a flag that
+// hasn't been created yet without special-casing it.
+func (s *FeatureFlagService) IsEnabled(ctx context.Context, key string, userID uint) bool {
+	flag, err := s.flags.GetByKey(ctx, key)
+	if err != nil {
+		return false
+	}
+	return featureflag.Evaluate(flag.Enabled, flag.RolloutPercent, flag.Key, userID)
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/repository/redis"
+	"github.com/google/uuid"
+)
+
+// MergeGuestCart copies every line from the guest cart identified by cookie
+// into userID's database cart, then clears the guest cart. Call it right
+// after login so items added before signing in are not lost.
+func MergeGuestCart(ctx context.Context, cart *psql.CartRepository, guest *redis.GuestCartRepository, products *psql.ProductRepository, userID uint, cookie string) error {
+	lines, err := guest.List(ctx, cookie)
+	if err != nil || len(lines) == 0 {
+		return err
+	}
+
+	for _, line := range lines {
+		productID, err := uuid.Parse(line.ProductID)
+		if err != nil {
+			continue
+		}
+		price := 0.0
+		if product, err := products.GetByID(ctx, line.ProductID); err == nil {
+			price = product.DiscountedPrice()
+		}
+		if err := cart.AddOrIncrement(ctx, userID, productID, line.Size, line.Quantity, price); err != nil {
+			return err
+		}
+	}
+
+	return guest.Clear(ctx, cookie)
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+)
+
+// ledgerEntryRecorder is the write access LedgerService needs. It's
+// satisfied by *psql.LedgerEntryRepository; declaring it here instead of
+// importing repository/psql keeps this package free to depend on, and
+// lets tests exercise the debit/credit pairing without a database.
+type ledgerEntryRecorder interface {
+	Record(ctx context.Context, entries ...*models.LedgerEntry) error
+}
+
+// LedgerService records the double-entry bookkeeping rows behind order
+// money movements. Each event books a debit and a matching credit sharing
+// a transaction ID, so finance can audit money flow independent of the
+// mutable Order/Payment rows those same events also update.
+type LedgerService struct {
+	entries ledgerEntryRecorder
+}
+
+// NewLedgerService builds a LedgerService around a LedgerEntryRepository.
+func NewLedgerService(entries ledgerEntryRecorder) *LedgerService {
+	return &LedgerService{entries: entries}
+}
+
+// RecordCapture books a gateway capture: cash comes in, revenue is recognized.
+func (s *LedgerService) RecordCapture(ctx context.Context, orderID, paymentID uuid.UUID, amount float64, currency string) error {
+	return s.record(ctx, orderID, paymentID.String(), "payment captured", amount, currency, models.LedgerAccountCash, models.LedgerAccountRevenue)
+}
+
+// RecordCODSettlement books a cash-on-delivery collection. Same accounts as
+// a gateway capture - the cash just lands in the till instead of the
+// gateway's balance.
+func (s *LedgerService) RecordCODSettlement(ctx context.Context, orderID, paymentID uuid.UUID, amount float64, currency string) error {
+	return s.record(ctx, orderID, paymentID.String(), "cash on delivery collected", amount, currency, models.LedgerAccountCash, models.LedgerAccountRevenue)
+}
+
+// RecordRefund books a refund: revenue is given back, cash goes out.
+func (s *LedgerService) RecordRefund(ctx context.Context, orderID, refundID uuid.UUID, amount float64, currency string) error {
+	return s.record(ctx, orderID, refundID.String(), "refund issued", amount, currency, models.LedgerAccountRefunds, models.LedgerAccountCash)
+}
+
+// RecordCouponDiscount books a coupon discount as revenue given up at the
+// point of sale, with no cash movement of its own.
+func (s *LedgerService) RecordCouponDiscount(ctx context.Context, orderID, couponID uuid.UUID, amount float64, currency string) error {
+	return s.record(ctx, orderID, couponID.String(), "coupon discount applied", amount, currency, models.LedgerAccountDiscounts, models.LedgerAccountRevenue)
+}
+
+func (s *LedgerService) record(ctx context.Context, orderID uuid.UUID, reference, description string, amount float64, currency, debitAccount, creditAccount string) error {
+	transactionID := uuid.New()
+	return s.entries.Record(ctx,
+		&models.LedgerEntry{TransactionID: transactionID, OrderID: orderID, Account: debitAccount, EntryType: models.LedgerEntryDebit, Amount: amount, Currency: currency, Reference: reference, Description: description},
+		&models.LedgerEntry{TransactionID: transactionID, OrderID: orderID, Account: creditAccount, EntryType: models.LedgerEntryCredit, Amount: amount, Currency: currency, Reference: reference, Description: description},
+	)
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+)
+
+// fakeLedgerEntryRecorder captures the entries it's asked to record,
+// standing in for psql.LedgerEntryRepository so tests can inspect the
+// pairs LedgerService builds without a database.
+type fakeLedgerEntryRecorder struct {
+	recorded []*models.LedgerEntry
+}
+
+func (f *fakeLedgerEntryRecorder) Record(ctx context.Context, entries ...*models.LedgerEntry) error {
+	f.recorded = append(f.recorded, entries...)
+	return nil
+}
+
+func assertBalanced(t *testing.T, entries []*models.LedgerEntry, wantDebit, wantCredit string, wantAmount float64, wantCurrency string) {
+	t.Helper()
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2 (one debit, one credit)", len(entries))
+	}
+	debit, credit := entries[0], entries[1]
+	if debit.EntryType != models.LedgerEntryDebit || debit.Account != wantDebit {
+		t.Errorf("first entry = %+v, want debit to %s", debit, wantDebit)
+	}
+	if credit.EntryType != models.LedgerEntryCredit || credit.Account != wantCredit {
+		t.Errorf("second entry = %+v, want credit to %s", credit, wantCredit)
+	}
+	if debit.Amount != wantAmount || credit.Amount != wantAmount {
+		t.Errorf("debit amount %v and credit amount %v must both equal %v", debit.Amount, credit.Amount, wantAmount)
+	}
+	if debit.Currency != wantCurrency || credit.Currency != wantCurrency {
+		t.Errorf("debit currency %q and credit currency %q must both equal %q", debit.Currency, credit.Currency, wantCurrency)
+	}
+	if debit.TransactionID != credit.TransactionID {
+		t.Errorf("debit and credit must share a transaction ID, got %s and %s", debit.TransactionID, credit.TransactionID)
+	}
+	if debit.OrderID != credit.OrderID {
+		t.Errorf("debit and credit must share an order ID, got %s and %s", debit.OrderID, credit.OrderID)
+	}
+}
+
+func TestLedgerServiceRecordCapture(t *testing.T) {
+	recorder := &fakeLedgerEntryRecorder{}
+	ledger := NewLedgerService(recorder)
+	orderID, paymentID := uuid.New(), uuid.New()
+
+	if err := ledger.RecordCapture(context.Background(), orderID, paymentID, 49.99, "usd"); err != nil {
+		t.Fatalf("RecordCapture: %v", err)
+	}
+	assertBalanced(t, recorder.recorded, models.LedgerAccountCash, models.LedgerAccountRevenue, 49.99, "usd")
+}
+
+func TestLedgerServiceRecordRefund(t *testing.T) {
+	recorder := &fakeLedgerEntryRecorder{}
+	ledger := NewLedgerService(recorder)
+	orderID, refundID := uuid.New(), uuid.New()
+
+	if err := ledger.RecordRefund(context.Background(), orderID, refundID, 12.50, "usd"); err != nil {
+		t.Fatalf("RecordRefund: %v", err)
+	}
+	assertBalanced(t, recorder.recorded, models.LedgerAccountRefunds, models.LedgerAccountCash, 12.50, "usd")
+}
+
+func TestLedgerServiceRecordCouponDiscount(t *testing.T) {
+	recorder := &fakeLedgerEntryRecorder{}
+	ledger := NewLedgerService(recorder)
+	orderID, couponID := uuid.New(), uuid.New()
+
+	if err := ledger.RecordCouponDiscount(context.Background(), orderID, couponID, 5.00, "usd"); err != nil {
+		t.Fatalf("RecordCouponDiscount: %v", err)
+	}
+	assertBalanced(t, recorder.recorded, models.LedgerAccountDiscounts, models.LedgerAccountRevenue, 5.00, "usd")
+}
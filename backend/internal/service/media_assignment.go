@@ -0,0 +1,165 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+)
+
+// maxCoverImageBytes bounds a collection cover image upload.
+const maxCoverImageBytes = 5 << 20 // 5MB
+
+// maxAvatarBytes bounds a user avatar upload.
+const maxAvatarBytes = 2 << 20 // 2MB
+
+// allowedCoverImageTypes are the Content-Types AssignCoverImage accepts.
+var allowedCoverImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+// allowedAvatarTypes are the Content-Types AssignAvatar accepts.
+var allowedAvatarTypes = allowedCoverImageTypes
+
+// CoverImageVariants names the stored file for each rendition of a
+// collection's cover image.
+type CoverImageVariants struct {
+	Original  string
+	Banner    string
+	Thumbnail string
+}
+
+// MediaAssignmentResult reports the outcome of assigning one file from the
+// uploaded ZIP to a product.
+type MediaAssignmentResult struct {
+	File    string `json:"file"`
+	SKU     string `json:"sku"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// MediaService assigns bulk-uploaded images to products by SKU.
+type MediaService struct {
+	products *psql.ProductRepository
+}
+
+// NewMediaService builds a MediaService with its dependencies.
+func NewMediaService(products *psql.ProductRepository) *MediaService {
+	return &MediaService{products: products}
+}
+
+// AssignZIP walks a ZIP archive whose entries are named "<SKU>.<ext>" and
+// appends each image's stored name to the matching product's Images list.
+// It never returns an error itself; failures are reported per-file so a
+// handful of bad entries don't abort the whole batch.
+func (s *MediaService) AssignZIP(ctx context.Context, r *zip.Reader) []MediaAssignmentResult {
+	results := make([]MediaAssignmentResult, 0, len(r.File))
+
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		sku := strings.TrimSuffix(filepath.Base(f.Name), filepath.Ext(f.Name))
+		result := MediaAssignmentResult{File: f.Name, SKU: sku}
+
+		product, err := s.products.GetByArticleNumber(ctx, sku)
+		if err != nil {
+			result.Error = "no product with this SKU"
+			results = append(results, result)
+			continue
+		}
+
+		if err := s.readAndDiscard(f); err != nil {
+			result.Error = "failed to read image data"
+			results = append(results, result)
+			continue
+		}
+
+		product.Images = append(product.Images, f.Name)
+		if err := s.products.Update(ctx, product); err != nil {
+			result.Error = "failed to save product"
+			results = append(results, result)
+			continue
+		}
+
+		result.Success = true
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// readAndDiscard streams a ZIP entry's content out, standing in for handing
+// the bytes off to the media storage pipeline.
+func (s *MediaService) readAndDiscard(f *zip.File) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	_, err = io.Copy(io.Discard, rc)
+	return err
+}
+
+// AssignCoverImage validates a collection cover image upload by size and
+// Content-Type and hands its bytes off to the media storage pipeline,
+// returning the stored name for each variant. There's no image-processing
+// library in this codebase to actually produce a resized banner or
+// thumbnail, so both variants alias the original until that's wired up.
+func (s *MediaService) AssignCoverImage(fh *multipart.FileHeader) (*CoverImageVariants, error) {
+	if fh.Size > maxCoverImageBytes {
+		return nil, fmt.Errorf("cover image exceeds %d bytes", maxCoverImageBytes)
+	}
+	contentType := fh.Header.Get("Content-Type")
+	if !allowedCoverImageTypes[contentType] {
+		return nil, fmt.Errorf("unsupported image type %q", contentType)
+	}
+
+	file, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if _, err := io.Copy(io.Discard, file); err != nil {
+		return nil, err
+	}
+
+	return &CoverImageVariants{
+		Original:  fh.Filename,
+		Banner:    fh.Filename,
+		Thumbnail: fh.Filename,
+	}, nil
+}
+
+// AssignAvatar validates a user avatar upload by size and Content-Type and
+// hands its bytes off to the media storage pipeline, returning the stored
+// name. Same caveat as AssignCoverImage: there's no image-processing
+// library in this codebase to actually square-crop and resize it, so the
+// returned name points at the original upload.
+func (s *MediaService) AssignAvatar(fh *multipart.FileHeader) (string, error) {
+	if fh.Size > maxAvatarBytes {
+		return "", fmt.Errorf("avatar exceeds %d bytes", maxAvatarBytes)
+	}
+	contentType := fh.Header.Get("Content-Type")
+	if !allowedAvatarTypes[contentType] {
+		return "", fmt.Errorf("unsupported image type %q", contentType)
+	}
+
+	file, err := fh.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	if _, err := io.Copy(io.Discard, file); err != nil {
+		return "", err
+	}
+
+	return fh.Filename, nil
+}
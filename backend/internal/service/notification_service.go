@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/sirupsen/logrus"
+)
+
+// Notification channels a NotificationEvent can be routed to.
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+	ChannelPush  = "push"
+)
+
+// Notification event kinds recognized by NotificationService.Dispatch.
+// Each has a fixed set of default channels, defined in eventChannels
+// below, further filtered by the recipient's own preferences.
+const (
+	EventOrderShipped = "order.shipped"
+	EventPriceDrop    = "price_drop"
+	EventReviewReply  = "review.reply"
+)
+
+// NotificationEvent is one thing that happened to UserID, to be routed to
+// whichever of email/SMS/push the event kind defaults to and the user
+// hasn't opted out of. Only the fields relevant to the channels that
+// actually fire are used, so callers only need to fill in what their
+// event needs.
+type NotificationEvent struct {
+	Kind   string
+	UserID uint
+
+	// EmailTemplate/EmailData are used if the email channel fires,
+	// against mailer's embedded template set (see NewRenderer).
+	EmailTemplate string
+	EmailData     map[string]interface{}
+
+	// SMSBody is used if the SMS channel fires.
+	SMSBody string
+
+	// PushTitle/PushBody/PushData are used if the push channel fires.
+	PushTitle string
+	PushBody  string
+	PushData  map[string]string
+}
+
+// channelRule pairs a channel with the user preference that gates it.
+type channelRule struct {
+	channel string
+	allowed func(*models.User) bool
+}
+
+// eventChannels is each event kind's default channels, in the order
+// they're attempted. price_drop is gated on EmailMarketing rather than
+// EmailOrderUpdates since it's promotional, not transactional; it also has
+// no SMS default since an SMS is a poor fit for "you might like this deal".
+var eventChannels = map[string][]channelRule{
+	EventOrderShipped: {
+		{ChannelEmail, func(u *models.User) bool { return u.EmailOrderUpdates }},
+		{ChannelSMS, func(u *models.User) bool { return u.SMSNotifications }},
+		{ChannelPush, func(u *models.User) bool { return u.PushNotifications }},
+	},
+	EventPriceDrop: {
+		{ChannelEmail, func(u *models.User) bool { return u.EmailMarketing }},
+		{ChannelPush, func(u *models.User) bool { return u.PushNotifications }},
+	},
+	EventReviewReply: {
+		{ChannelEmail, func(u *models.User) bool { return u.EmailOrderUpdates }},
+		{ChannelPush, func(u *models.User) bool { return u.PushNotifications }},
+	},
+}
+
+// NotificationService routes a NotificationEvent to email, SMS and/or push
+// depending on the event kind's default channels and the recipient's own
+// preferences, and records the outcome of every channel it actually
+// attempted to NotificationLogRepository.
+type NotificationService struct {
+	users *psql.UserRepository
+	logs  *psql.NotificationLogRepository
+	email *EmailService
+	sms   *SMSService
+	push  *PushNotificationService
+}
+
+// NewNotificationService builds a NotificationService with its
+// dependencies.
+func NewNotificationService(users *psql.UserRepository, logs *psql.NotificationLogRepository, email *EmailService, sms *SMSService, push *PushNotificationService) *NotificationService {
+	return &NotificationService{users: users, logs: logs, email: email, sms: sms, push: push}
+}
+
+// Dispatch routes event to its default channels, skipping any the
+// recipient has opted out of, and logs the outcome of each channel it
+// attempted. It returns the first channel error encountered, if any,
+// after attempting every eligible channel - a failed email shouldn't stop
+// the SMS/push attempts that follow it.
+func (s *NotificationService) Dispatch(ctx context.Context, event NotificationEvent) error {
+	rules, ok := eventChannels[event.Kind]
+	if !ok {
+		return fmt.Errorf("service: unknown notification event kind %q", event.Kind)
+	}
+
+	user, err := s.users.GetByID(ctx, event.UserID)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, rule := range rules {
+		if !rule.allowed(user) {
+			continue
+		}
+
+		sendErr := s.send(ctx, rule.channel, user, event)
+
+		log := &models.NotificationLog{UserID: event.UserID, EventKind: event.Kind, Channel: rule.channel}
+		if sendErr != nil {
+			log.Status = models.NotificationStatusFailed
+			if firstErr == nil {
+				firstErr = sendErr
+			}
+			logrus.WithError(sendErr).WithField("user_id", event.UserID).WithField("event", event.Kind).WithField("channel", rule.channel).Warn("notification: send failed")
+		} else {
+			log.Status = models.NotificationStatusSent
+		}
+		if logErr := s.logs.Create(ctx, log); logErr != nil {
+			logrus.WithError(logErr).WithField("user_id", event.UserID).WithField("event", event.Kind).Error("notification: failed to record notification log")
+		}
+	}
+	return firstErr
+}
+
+func (s *NotificationService) send(ctx context.Context, channel string, user *models.User, event NotificationEvent) error {
+	switch channel {
+	case ChannelEmail:
+		return s.email.Send(ctx, user.Email, event.EmailTemplate, event.EmailData)
+	case ChannelSMS:
+		return s.sms.Send(ctx, user.Phone, user.Country, event.SMSBody)
+	case ChannelPush:
+		return s.push.Notify(ctx, user.ID, event.PushTitle, event.PushBody, event.Kind, event.PushData)
+	default:
+		return fmt.Errorf("service: unknown notification channel %q", channel)
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/sirupsen/logrus"
+)
+
+// OrderCancellationService cancels orders whose payment never went through
+// within maxUnpaidAge of being placed, freeing the stock they were holding
+// back for checkout to compete for. Run is registered with
+// scheduler.Scheduler rather than driving its own ticker.
+type OrderCancellationService struct {
+	orders       *psql.OrderRepository
+	maxUnpaidAge time.Duration
+}
+
+// NewOrderCancellationService builds an OrderCancellationService with its
+// dependencies.
+func NewOrderCancellationService(orders *psql.OrderRepository, maxUnpaidAge time.Duration) *OrderCancellationService {
+	return &OrderCancellationService{orders: orders, maxUnpaidAge: maxUnpaidAge}
+}
+
+// Run cancels every order placed more than maxUnpaidAge ago whose payment
+// never went through. A failure canceling one order (e.g. it was updated
+// by someone else since it was listed) is logged and skipped rather than
+// aborting the run.
+func (s *OrderCancellationService) Run(ctx context.Context) error {
+	cutoff := time.Now().Add(-s.maxUnpaidAge)
+
+	orders, err := s.orders.ListUnpaidOlderThan(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		if err := s.orders.UpdateStatus(ctx, order.ID, order.Version, models.OrderStatusCancelled); err != nil {
+			logrus.WithError(err).WithField("order_id", order.ID).Warn("order cancellation: failed to cancel unpaid order")
+			continue
+		}
+		logrus.WithField("order_id", order.ID).WithField("payment_status", order.PaymentStatus).Info("order cancellation: cancelled unpaid order")
+	}
+
+	return nil
+}
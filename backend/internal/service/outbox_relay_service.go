@@ -0,0 +1,105 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/sirupsen/logrus"
+)
+
+// outboxRelayBatchSize caps how many pending events OutboxRelayService picks
+// up per Run, so one slow poll cycle can't starve the next.
+const outboxRelayBatchSize = 50
+
+// OutboxRelayService polls the transactional outbox and delivers each
+// pending event to every configured webhook URL, retrying failed
+// deliveries on the next poll (at-least-once - a subscriber must tolerate
+// receiving the same event more than once).
+type OutboxRelayService struct {
+	events      *psql.OutboxEventRepository
+	webhookURLs []string
+	client      *http.Client
+}
+
+// NewOutboxRelayService builds an OutboxRelayService with its dependencies.
+func NewOutboxRelayService(events *psql.OutboxEventRepository, webhookURLs []string) *OutboxRelayService {
+	return &OutboxRelayService{
+		events:      events,
+		webhookURLs: webhookURLs,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Run delivers one batch of pending events. A delivery failure is recorded
+// against that event and does not stop the batch from continuing.
+func (s *OutboxRelayService) Run(ctx context.Context) error {
+	if len(s.webhookURLs) == 0 {
+		return nil
+	}
+
+	events, err := s.events.ListPending(ctx, outboxRelayBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if err := s.deliver(ctx, event.Payload); err != nil {
+			attempts := event.Attempts + 1
+			logrus.WithError(err).WithField("event_id", event.ID).WithField("attempt", attempts).Warn("outbox relay: delivery failed")
+			if markErr := s.events.MarkAttemptFailed(ctx, event.ID, attempts, err.Error()); markErr != nil {
+				logrus.WithError(markErr).WithField("event_id", event.ID).Error("outbox relay: failed to record delivery failure")
+			}
+			continue
+		}
+		if err := s.events.MarkPublished(ctx, event.ID); err != nil {
+			logrus.WithError(err).WithField("event_id", event.ID).Error("outbox relay: failed to mark event published")
+		}
+	}
+	return nil
+}
+
+// deliver POSTs payload to every configured webhook URL, failing if any one
+// of them doesn't accept it - a partial delivery still counts as failed so
+// the whole event is retried rather than silently only reaching some
+// subscribers.
+func (s *OutboxRelayService) deliver(ctx context.Context, payload string) error {
+	for _, url := range s.webhookURLs {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBufferString(payload))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("webhook %s responded with status %d", url, resp.StatusCode)
+		}
+	}
+	return nil
+}
+
+// Start runs Run on a fixed interval until ctx is canceled.
+func (s *OutboxRelayService) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		s.Run(ctx)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.Run(ctx)
+			}
+		}
+	}()
+}
@@ -0,0 +1,50 @@
+package service
+
+import "github.com/YoungGoofy/shopping/internal/models"
+
+// PaymentMethodEligibilityService filters a shop's configured payment
+// methods down to the ones actually usable for a given order, since a
+// method like cash-on-delivery is often capped by amount or restricted to
+// certain regions.
+type PaymentMethodEligibilityService struct{}
+
+// NewPaymentMethodEligibilityService builds a PaymentMethodEligibilityService.
+func NewPaymentMethodEligibilityService() *PaymentMethodEligibilityService {
+	return &PaymentMethodEligibilityService{}
+}
+
+// IsEligible reports whether method can be used for an order of the given
+// amount and region. An empty AllowedRegions list means "no restriction".
+func (s *PaymentMethodEligibilityService) IsEligible(method models.PaymentMethod, amount float64, region string) bool {
+	if !method.Enabled {
+		return false
+	}
+	if method.MaxAmount > 0 && amount > method.MaxAmount {
+		return false
+	}
+	if len(method.AllowedRegions) > 0 {
+		allowed := false
+		for _, r := range method.AllowedRegions {
+			if r == region {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterEligible returns the subset of methods usable for the given
+// amount and region.
+func (s *PaymentMethodEligibilityService) FilterEligible(methods []models.PaymentMethod, amount float64, region string) []models.PaymentMethod {
+	eligible := make([]models.PaymentMethod, 0, len(methods))
+	for _, m := range methods {
+		if s.IsEligible(m, amount, region) {
+			eligible = append(eligible, m)
+		}
+	}
+	return eligible
+}
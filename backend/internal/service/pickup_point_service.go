@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/carrier"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+)
+
+// PickupPointService mirrors a carrier.Provider's pickup point directory
+// into PickupPointRepository, so search can be served from the database
+// instead of calling the carrier on every request.
+type PickupPointService struct {
+	provider carrier.Provider
+	points   *psql.PickupPointRepository
+}
+
+// NewPickupPointService builds a PickupPointService around a single carrier
+// provider.
+func NewPickupPointService(provider carrier.Provider, points *psql.PickupPointRepository) *PickupPointService {
+	return &PickupPointService{provider: provider, points: points}
+}
+
+// Sync fetches every pickup point the carrier operates in city and upserts
+// it into PickupPointRepository.
+func (s *PickupPointService) Sync(ctx context.Context, city string) error {
+	fetched, err := s.provider.ListPickupPoints(ctx, city)
+	if err != nil {
+		return err
+	}
+
+	for _, point := range fetched {
+		if err := s.points.Upsert(ctx, &models.PickupPoint{
+			Carrier:    s.provider.Name(),
+			ExternalID: point.ExternalID,
+			Name:       point.Name,
+			Address:    point.Address,
+			City:       point.City,
+			Region:     point.Region,
+			PostalCode: point.PostalCode,
+			Latitude:   point.Latitude,
+			Longitude:  point.Longitude,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
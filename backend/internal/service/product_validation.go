@@ -0,0 +1,65 @@
+// Package service holds business logic that coordinates repositories and
+// does not belong in a single HTTP handler.
+package service
+
+import "github.com/YoungGoofy/shopping/internal/models"
+
+const minDescriptionLength = 120
+
+// requiredTranslations lists the languages the storefront requires content in.
+var requiredTranslations = []string{"ru", "en"}
+
+// ProductCompleteness is a breakdown of a product's content score.
+type ProductCompleteness struct {
+	Score                int  `json:"score"`
+	HasImages            bool `json:"has_images"`
+	DescriptionOK        bool `json:"description_ok"`
+	AttributesFilled     bool `json:"attributes_filled"`
+	SizeChartLinked      bool `json:"size_chart_linked"`
+	TranslationsComplete bool `json:"translations_complete"`
+}
+
+// ProductValidationService scores product content completeness and decides
+// whether a product may be published.
+type ProductValidationService struct {
+	publishThreshold int
+}
+
+// NewProductValidationService builds a ProductValidationService. Products
+// scoring below threshold (0-100) are blocked from publishing.
+func NewProductValidationService(threshold int) *ProductValidationService {
+	return &ProductValidationService{publishThreshold: threshold}
+}
+
+// Score computes the completeness breakdown for a product. Each of the five
+// checks is worth 20 points.
+func (s *ProductValidationService) Score(product models.Product) ProductCompleteness {
+	c := ProductCompleteness{
+		HasImages:            len(product.Images) > 0,
+		DescriptionOK:        len(product.Description) >= minDescriptionLength,
+		AttributesFilled:     len(product.Attributes) > 0,
+		SizeChartLinked:      product.SizeChartURL != "",
+		TranslationsComplete: s.translationsComplete(product.Translations),
+	}
+
+	for _, ok := range []bool{c.HasImages, c.DescriptionOK, c.AttributesFilled, c.SizeChartLinked, c.TranslationsComplete} {
+		if ok {
+			c.Score += 20
+		}
+	}
+	return c
+}
+
+func (s *ProductValidationService) translationsComplete(translations models.StringMap) bool {
+	for _, lang := range requiredTranslations {
+		if translations[lang] == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// CanPublish reports whether a product's score meets the publish threshold.
+func (s *ProductValidationService) CanPublish(product models.Product) bool {
+	return s.Score(product).Score >= s.publishThreshold
+}
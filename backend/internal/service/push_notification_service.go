@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/webpush"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// PushNotificationService pushes order-status and back-in-stock updates to
+// a user's registered browsers, respecting their PushNotifications
+// preference. It has no retry loop like EmailService/SMSService - a push
+// service already queues undelivered messages for its own TTL, so a
+// second attempt from here would just double-send once it comes back.
+type PushNotificationService struct {
+	sender        *webpush.Sender
+	subscriptions *psql.PushSubscriptionRepository
+	users         *psql.UserRepository
+	wishlist      *psql.WishlistRepository
+}
+
+// NewPushNotificationService builds a PushNotificationService with its
+// dependencies.
+func NewPushNotificationService(sender *webpush.Sender, subscriptions *psql.PushSubscriptionRepository, users *psql.UserRepository, wishlist *psql.WishlistRepository) *PushNotificationService {
+	return &PushNotificationService{sender: sender, subscriptions: subscriptions, users: users, wishlist: wishlist}
+}
+
+// pushPayload is the JSON body delivered to the browser's service worker.
+// Type lets the service worker pick a click-through URL without the
+// backend needing to know its routing.
+type pushPayload struct {
+	Title string            `json:"title"`
+	Body  string            `json:"body"`
+	Type  string            `json:"type"`
+	Data  map[string]string `json:"data,omitempty"`
+}
+
+// NotifyOrderStatus pushes an order's new status to userID, if they have
+// push notifications enabled and at least one registered subscription.
+func (s *PushNotificationService) NotifyOrderStatus(ctx context.Context, userID uint, orderID uuid.UUID, status string) error {
+	return s.notifyUser(ctx, userID, pushPayload{
+		Title: "Order update",
+		Body:  "Your order status changed to " + status,
+		Type:  "order_status",
+		Data:  map[string]string{"order_id": orderID.String(), "status": status},
+	})
+}
+
+// Notify pushes an arbitrary title/body/data to userID, for callers (like
+// NotificationService) that don't map onto NotifyOrderStatus or
+// NotifyBackInStock. kind becomes the payload's Type field.
+func (s *PushNotificationService) Notify(ctx context.Context, userID uint, title, body, kind string, data map[string]string) error {
+	return s.notifyUser(ctx, userID, pushPayload{Title: title, Body: body, Type: kind, Data: data})
+}
+
+// NotifyBackInStock pushes a restock alert to every user with productID on
+// their wishlist, if they have push notifications enabled.
+func (s *PushNotificationService) NotifyBackInStock(ctx context.Context, productID uuid.UUID, productName string) error {
+	items, err := s.wishlist.ListContainingProduct(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	payload := pushPayload{
+		Title: "Back in stock",
+		Body:  productName + " is back in stock",
+		Type:  "back_in_stock",
+		Data:  map[string]string{"product_id": productID.String()},
+	}
+
+	for _, item := range items {
+		if err := s.notifyUser(ctx, item.UserID, payload); err != nil {
+			logrus.WithError(err).WithField("user_id", item.UserID).WithField("product_id", productID).Warn("push: failed to notify wishlist entry")
+		}
+	}
+	return nil
+}
+
+// notifyUser sends payload to every subscription registered for userID,
+// after checking their PushNotifications preference. A subscription the
+// push service reports gone is dropped rather than retried.
+func (s *PushNotificationService) notifyUser(ctx context.Context, userID uint, payload pushPayload) error {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.PushNotifications {
+		return nil
+	}
+
+	subs, err := s.subscriptions.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for _, sub := range subs {
+		sendErr := s.sender.Send(ctx, webpush.Subscription{Endpoint: sub.Endpoint, P256DH: sub.P256DH, Auth: sub.Auth}, body)
+		switch {
+		case sendErr == nil:
+			// delivered (or at least accepted by the push service)
+		case errors.Is(sendErr, webpush.ErrGone):
+			if delErr := s.subscriptions.Delete(ctx, sub.ID); delErr != nil {
+				logrus.WithError(delErr).WithField("subscription_id", sub.ID).Warn("push: failed to drop gone subscription")
+			}
+		default:
+			lastErr = sendErr
+			logrus.WithError(sendErr).WithField("subscription_id", sub.ID).Warn("push: send failed")
+		}
+	}
+	return lastErr
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/cache"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/repository/redis"
+)
+
+// alsoBoughtCacheKey namespaces cached also-bought lists by product.
+func alsoBoughtCacheKey(productID string) string {
+	return "also_bought:" + productID
+}
+
+// RecommendationService precomputes "customers also bought" lists from
+// order co-occurrence on a background schedule, and combines them with a
+// user's browsing history at read time to answer "recommended for you" -
+// so personalization doesn't need its own per-user background job, just a
+// lookup against data the background job already produced.
+type RecommendationService struct {
+	orders          *psql.OrderRepository
+	history         *redis.BrowsingHistoryRepository
+	cache           *cache.Cache
+	lookbackDays    int
+	alsoBoughtLimit int
+	forYouLimit     int
+}
+
+// NewRecommendationService builds a RecommendationService with its dependencies.
+func NewRecommendationService(orders *psql.OrderRepository, history *redis.BrowsingHistoryRepository, cache *cache.Cache, lookbackDays, alsoBoughtLimit, forYouLimit int) *RecommendationService {
+	return &RecommendationService{
+		orders:          orders,
+		history:         history,
+		cache:           cache,
+		lookbackDays:    lookbackDays,
+		alsoBoughtLimit: alsoBoughtLimit,
+		forYouLimit:     forYouLimit,
+	}
+}
+
+// Refresh recomputes every product's also-bought list from paid orders
+// placed in the last lookbackDays days and caches each under its own key.
+// Since's result is already ordered by orders_together per product
+// (CoPurchasedProducts groups by the primary product first), so building
+// each list is just capping the run of rows for that product.
+func (s *RecommendationService) Refresh(ctx context.Context) error {
+	since := time.Now().AddDate(0, 0, -s.lookbackDays)
+	pairs, err := s.orders.CoPurchasedProducts(ctx, since)
+	if err != nil {
+		return err
+	}
+
+	var currentProduct string
+	var alsoBought []string
+	flush := func() error {
+		if currentProduct == "" {
+			return nil
+		}
+		return s.cache.Set(ctx, alsoBoughtCacheKey(currentProduct), alsoBought)
+	}
+
+	for _, pair := range pairs {
+		productID := pair.ProductID.String()
+		if productID != currentProduct {
+			if err := flush(); err != nil {
+				return err
+			}
+			currentProduct = productID
+			alsoBought = nil
+		}
+		if len(alsoBought) < s.alsoBoughtLimit {
+			alsoBought = append(alsoBought, pair.CoProductID.String())
+		}
+	}
+	return flush()
+}
+
+// Start runs Refresh on a fixed interval until ctx is canceled.
+func (s *RecommendationService) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		s.Refresh(ctx)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.Refresh(ctx)
+			}
+		}
+	}()
+}
+
+// AlsoBought returns the cached "customers also bought" list for
+// productID, or an empty slice if it hasn't sold alongside anything yet.
+func (s *RecommendationService) AlsoBought(ctx context.Context, productID string) ([]string, error) {
+	var alsoBought []string
+	if _, err := s.cache.Get(ctx, alsoBoughtCacheKey(productID), &alsoBought); err != nil {
+		return nil, err
+	}
+	return alsoBought, nil
+}
+
+// ForYou builds a personalized list for userID from the also-bought lists
+// of the products they've viewed most recently, most-recently-viewed
+// product first, deduplicated and capped at forYouLimit. A user with no
+// browsing history yet gets an empty list rather than a fallback to
+// something generic - there isn't a good generic answer for "recommended
+// for you" when there's no "you" data yet.
+func (s *RecommendationService) ForYou(ctx context.Context, userID uint) ([]string, error) {
+	viewed, err := s.history.List(ctx, userID, 20)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(viewed))
+	for _, productID := range viewed {
+		seen[productID] = true
+	}
+
+	recommended := make([]string, 0, s.forYouLimit)
+	for _, productID := range viewed {
+		alsoBought, err := s.AlsoBought(ctx, productID)
+		if err != nil {
+			return nil, fmt.Errorf("recommendations: also-bought lookup for %s: %w", productID, err)
+		}
+		for _, candidate := range alsoBought {
+			if seen[candidate] {
+				continue
+			}
+			seen[candidate] = true
+			recommended = append(recommended, candidate)
+			if len(recommended) == s.forYouLimit {
+				return recommended, nil
+			}
+		}
+	}
+	return recommended, nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/cache"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+)
+
+// reorderCacheKey is the single cache entry holding the latest low-stock
+// dashboard - there's one shop-wide view, not one per query, so a fixed
+// key is enough.
+const reorderCacheKey = "low_stock"
+
+// ReorderSuggestionService periodically recomputes low-stock products and
+// their reorder suggestions and caches the result, so the admin dashboard
+// reads a precomputed view instead of re-scanning order_items on every
+// page load.
+type ReorderSuggestionService struct {
+	products     *psql.ProductRepository
+	cache        *cache.Cache
+	threshold    int
+	lookbackDays int
+	leadTimeDays int
+}
+
+// NewReorderSuggestionService builds a ReorderSuggestionService. threshold
+// is the stock level at or below which a product is considered low;
+// lookbackDays is the window sales velocity is measured over; leadTimeDays
+// is how much future demand a suggested reorder should cover.
+func NewReorderSuggestionService(products *psql.ProductRepository, cache *cache.Cache, threshold, lookbackDays, leadTimeDays int) *ReorderSuggestionService {
+	return &ReorderSuggestionService{
+		products:     products,
+		cache:        cache,
+		threshold:    threshold,
+		lookbackDays: lookbackDays,
+		leadTimeDays: leadTimeDays,
+	}
+}
+
+// Refresh recomputes the low-stock dashboard and caches it. On failure the
+// previously cached dashboard is left in place.
+func (s *ReorderSuggestionService) Refresh(ctx context.Context) error {
+	rows, err := s.products.LowStockReorderSuggestions(ctx, s.threshold, s.lookbackDays, s.leadTimeDays)
+	if err != nil {
+		return err
+	}
+	return s.cache.Set(ctx, reorderCacheKey, rows)
+}
+
+// Start refreshes the dashboard on a fixed interval until ctx is canceled.
+// Errors are not surfaced - a failed refresh just leaves Get serving the
+// last successfully computed dashboard until the next tick succeeds.
+func (s *ReorderSuggestionService) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		s.Refresh(ctx)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.Refresh(ctx)
+			}
+		}
+	}()
+}
+
+// Get returns the last cached low-stock dashboard, or a cache miss if
+// Refresh has never completed successfully.
+func (s *ReorderSuggestionService) Get(ctx context.Context) ([]psql.ReorderSuggestionRow, bool, error) {
+	var rows []psql.ReorderSuggestionRow
+	hit, err := s.cache.Get(ctx, reorderCacheKey, &rows)
+	return rows, hit, err
+}
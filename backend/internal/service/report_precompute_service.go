@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/cache"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+)
+
+// DashboardCacheKey is the single cache entry ReportPrecomputeService
+// refreshes and AdminDashboardHandler reads from.
+const DashboardCacheKey = "dashboard"
+
+// DashboardSnapshot is the precomputed shape of AdminDashboardHandler's
+// response, cached by ReportPrecomputeService so a dashboard load doesn't
+// have to run five aggregate queries against production traffic.
+type DashboardSnapshot struct {
+	Today          *psql.PeriodStats `json:"today"`
+	ThisWeek       *psql.PeriodStats `json:"this_week"`
+	NewUsers7d     int64             `json:"new_users_7d"`
+	PendingReviews int64             `json:"pending_reviews"`
+	PendingReturns int64             `json:"pending_returns"`
+}
+
+// ReportPrecomputeService periodically recomputes DashboardSnapshot and
+// caches it, so AdminDashboardHandler.Get can serve it without querying
+// live. Run is registered with scheduler.Scheduler rather than driving its
+// own ticker.
+type ReportPrecomputeService struct {
+	orders  *psql.OrderRepository
+	users   *psql.UserRepository
+	reviews *psql.ReviewRepository
+	refunds *psql.RefundRepository
+	cache   *cache.Cache
+}
+
+// NewReportPrecomputeService builds a ReportPrecomputeService with its
+// dependencies.
+func NewReportPrecomputeService(orders *psql.OrderRepository, users *psql.UserRepository, reviews *psql.ReviewRepository, refunds *psql.RefundRepository, cache *cache.Cache) *ReportPrecomputeService {
+	return &ReportPrecomputeService{orders: orders, users: users, reviews: reviews, refunds: refunds, cache: cache}
+}
+
+// Run recomputes DashboardSnapshot and stores it in the cache.
+func (s *ReportPrecomputeService) Run(ctx context.Context) error {
+	now := time.Now()
+
+	today, err := s.orders.StatsSince(ctx, now.Add(-24*time.Hour))
+	if err != nil {
+		return err
+	}
+	week, err := s.orders.StatsSince(ctx, now.Add(-7*24*time.Hour))
+	if err != nil {
+		return err
+	}
+	newUsers, err := s.users.CountCreatedSince(ctx, now.Add(-7*24*time.Hour))
+	if err != nil {
+		return err
+	}
+	pendingReviews, err := s.reviews.CountPending(ctx)
+	if err != nil {
+		return err
+	}
+	pendingReturns, err := s.refunds.CountPending(ctx)
+	if err != nil {
+		return err
+	}
+
+	snapshot := DashboardSnapshot{
+		Today:          today,
+		ThisWeek:       week,
+		NewUsers7d:     newUsers,
+		PendingReviews: pendingReviews,
+		PendingReturns: pendingReturns,
+	}
+	return s.cache.Set(ctx, DashboardCacheKey, snapshot)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/search"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// searchIndexBatchSize caps how many pending jobs SearchIndexService picks
+// up per Run, so one slow poll cycle can't starve the next.
+const searchIndexBatchSize = 50
+
+// SearchIndexService polls the search index job queue and applies each
+// pending job to a search.Engine, keeping it in sync with product
+// publish/delete/restore without either operation blocking on the
+// engine's latency.
+type SearchIndexService struct {
+	jobs     *psql.SearchIndexJobRepository
+	products *psql.ProductRepository
+	engine   search.Engine
+}
+
+// NewSearchIndexService builds a SearchIndexService with its dependencies.
+func NewSearchIndexService(jobs *psql.SearchIndexJobRepository, products *psql.ProductRepository, engine search.Engine) *SearchIndexService {
+	return &SearchIndexService{jobs: jobs, products: products, engine: engine}
+}
+
+// Run applies one batch of pending jobs. A failure syncing one job is
+// recorded against that job and does not stop the batch from continuing.
+func (s *SearchIndexService) Run(ctx context.Context) error {
+	jobs, err := s.jobs.ListPending(ctx, searchIndexBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if err := s.apply(ctx, job); err != nil {
+			attempts := job.Attempts + 1
+			logrus.WithError(err).WithField("job_id", job.ID).WithField("attempt", attempts).Warn("search index: sync failed")
+			if markErr := s.jobs.MarkAttemptFailed(ctx, job.ID, attempts, err.Error()); markErr != nil {
+				logrus.WithError(markErr).WithField("job_id", job.ID).Error("search index: failed to record sync failure")
+			}
+			continue
+		}
+		if err := s.jobs.MarkPublished(ctx, job.ID); err != nil {
+			logrus.WithError(err).WithField("job_id", job.ID).Error("search index: failed to mark job published")
+		}
+	}
+	return nil
+}
+
+// apply syncs a single job to the engine. An upsert job fetches the
+// product's current state at sync time rather than carrying a snapshot,
+// so it always indexes what's actually in the database even if the job
+// sat pending behind an earlier one for the same product.
+func (s *SearchIndexService) apply(ctx context.Context, job models.SearchIndexJob) error {
+	if job.Action == models.SearchJobActionDelete {
+		return s.engine.DeleteDocument(ctx, job.ProductID.String())
+	}
+
+	product, err := s.products.GetByID(ctx, job.ProductID.String())
+	if err != nil {
+		return err
+	}
+	return s.engine.IndexDocument(ctx, search.Document{
+		ID:          product.ID.String(),
+		Name:        product.Name,
+		Description: product.Description,
+		CategoryID:  product.CategoryID,
+		Price:       product.Price,
+	})
+}
+
+// EnqueueUpsert schedules productID to be re-indexed on the next Run,
+// e.g. in response to an events.ProductStockChanged notification.
+func (s *SearchIndexService) EnqueueUpsert(ctx context.Context, productID uuid.UUID) error {
+	return s.jobs.Create(ctx, &models.SearchIndexJob{ProductID: productID, Action: models.SearchJobActionUpsert})
+}
+
+// Start runs Run on a fixed interval until ctx is canceled.
+func (s *SearchIndexService) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		s.Run(ctx)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.Run(ctx)
+			}
+		}
+	}()
+}
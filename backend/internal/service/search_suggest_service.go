@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/cache"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/repository/redis"
+)
+
+const (
+	suggestPerSourceLimit = 8
+	suggestCacheTTL       = 5 * time.Minute
+)
+
+// Suggestions is one autocomplete response: name completions, matching
+// categories, and popular past queries starting with the same prefix.
+type Suggestions struct {
+	Products       []string `json:"products"`
+	Categories     []string `json:"categories"`
+	PopularQueries []string `json:"popular_queries"`
+}
+
+// SearchSuggestService answers search-box autocomplete. Product and
+// category name completions come from a prefix match in PostgreSQL rather
+// than a dedicated prefix index or the external engine's suggester - the
+// catalog is small enough that ILIKE 'prefix%' with an index on name stays
+// well under the endpoint's latency budget, and it's one less thing that
+// depends on the search engine being up.
+type SearchSuggestService struct {
+	products       *psql.ProductRepository
+	categories     *psql.CategoryRepository
+	popularQueries *redis.PopularQueryRepository
+	cache          *cache.Cache
+}
+
+// NewSearchSuggestService builds a SearchSuggestService with its dependencies.
+func NewSearchSuggestService(products *psql.ProductRepository, categories *psql.CategoryRepository, popularQueries *redis.PopularQueryRepository, cache *cache.Cache) *SearchSuggestService {
+	return &SearchSuggestService{products: products, categories: categories, popularQueries: popularQueries, cache: cache}
+}
+
+// RecordQuery counts query towards the popular-queries suggestion source.
+// Called once per completed search, not per keystroke.
+func (s *SearchSuggestService) RecordQuery(ctx context.Context, query string) error {
+	return s.popularQueries.Record(ctx, query)
+}
+
+// Suggest returns autocomplete suggestions for prefix. Results are cached
+// briefly per prefix, since the same handful of characters gets typed by
+// many users but the underlying catalog and popularity data change slowly.
+func (s *SearchSuggestService) Suggest(ctx context.Context, prefix string) (Suggestions, error) {
+	cacheKey := "prefix:" + strings.ToLower(prefix)
+	var cached Suggestions
+	if hit, err := s.cache.Get(ctx, cacheKey, &cached); err == nil && hit {
+		return cached, nil
+	}
+
+	products, err := s.products.SuggestNames(ctx, prefix, suggestPerSourceLimit)
+	if err != nil {
+		return Suggestions{}, err
+	}
+	categories, err := s.categories.SuggestNames(ctx, prefix, suggestPerSourceLimit)
+	if err != nil {
+		return Suggestions{}, err
+	}
+	popular, err := s.matchingPopularQueries(ctx, prefix)
+	if err != nil {
+		return Suggestions{}, err
+	}
+
+	result := Suggestions{Products: products, Categories: categories, PopularQueries: popular}
+	_ = s.cache.Set(ctx, cacheKey, result)
+	return result, nil
+}
+
+// matchingPopularQueries ranks past search queries by popularity and
+// filters them down to ones starting with prefix. The popularity set is
+// small enough (one sorted set, capped implicitly by distinct query
+// volume) that fetching a wider top-N and filtering in process is simpler
+// than maintaining a per-prefix index in Redis.
+func (s *SearchSuggestService) matchingPopularQueries(ctx context.Context, prefix string) ([]string, error) {
+	top, err := s.popularQueries.Top(ctx, 200)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix = strings.ToLower(prefix)
+	matches := make([]string, 0, suggestPerSourceLimit)
+	for _, query := range top {
+		if strings.HasPrefix(strings.ToLower(query), prefix) {
+			matches = append(matches, query)
+			if len(matches) == suggestPerSourceLimit {
+				break
+			}
+		}
+	}
+	return matches, nil
+}
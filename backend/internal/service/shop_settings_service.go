@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/repository/redis"
+)
+
+// ShopSettingsService reads and writes the shop's runtime-editable
+// settings through a Redis cache in front of the psql repository, since
+// settings are read on effectively every request but written rarely.
+type ShopSettingsService struct {
+	settings *psql.ShopSettingsRepository
+	cache    *redis.ShopSettingsCache
+}
+
+// NewShopSettingsService builds a ShopSettingsService with its dependencies.
+func NewShopSettingsService(settings *psql.ShopSettingsRepository, cache *redis.ShopSettingsCache) *ShopSettingsService {
+	return &ShopSettingsService{settings: settings, cache: cache}
+}
+
+// Get returns the shop settings, serving from cache when possible and
+// filling it from the repository on a miss.
+func (s *ShopSettingsService) Get(ctx context.Context) (*models.ShopSettings, error) {
+	cached, err := s.cache.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		return cached, nil
+	}
+
+	settings, err := s.settings.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cache.Set(ctx, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Update saves the shop settings and invalidates the cache, rather than
+// writing the new value straight into it, so a failed or concurrent write
+// elsewhere can't leave a stale cached copy ahead of the database.
+func (s *ShopSettingsService) Update(ctx context.Context, settings *models.ShopSettings) error {
+	if err := s.settings.Update(ctx, settings); err != nil {
+		return err
+	}
+	return s.cache.Invalidate(ctx)
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/YoungGoofy/shopping/internal/sms"
+	"github.com/sirupsen/logrus"
+)
+
+// SMSService sends an SMS through an sms.Provider, resolving which sending
+// number to use from the recipient's country, retries a transient failure
+// a bounded number of times, and records every attempt to SMSLogRepository
+// regardless of whether it eventually succeeded.
+type SMSService struct {
+	provider sms.Provider
+	logs     *psql.SMSLogRepository
+
+	defaultFrom string
+	sendersBy   map[string]string
+	maxAttempts int
+	retryDelay  time.Duration
+}
+
+// NewSMSService builds an SMSService with its dependencies. senders maps a
+// country code onto the sending number registered for it; a country with
+// no entry falls back to defaultFrom.
+func NewSMSService(provider sms.Provider, logs *psql.SMSLogRepository, defaultFrom string, senders []SMSSender, maxAttempts int, retryDelay time.Duration) *SMSService {
+	sendersBy := make(map[string]string, len(senders))
+	for _, s := range senders {
+		sendersBy[s.Country] = s.From
+	}
+	return &SMSService{provider: provider, logs: logs, defaultFrom: defaultFrom, sendersBy: sendersBy, maxAttempts: maxAttempts, retryDelay: retryDelay}
+}
+
+// SMSSender pins the sending number used for a country - the service-layer
+// mirror of config.SMSSenderConfig, kept separate so this package doesn't
+// depend on internal/config.
+type SMSSender struct {
+	Country string
+	From    string
+}
+
+// Send submits body to a recipient in country, retrying a transient
+// failure up to maxAttempts times before giving up and returning the last
+// error; either way, the attempt is logged. The provider's own message ID
+// is recorded on success, so a later delivery-status callback can update
+// this same log entry.
+func (s *SMSService) Send(ctx context.Context, to, country, body string) error {
+	from, ok := s.sendersBy[country]
+	if !ok {
+		from = s.defaultFrom
+	}
+	msg := sms.Message{From: from, To: to, Body: body}
+
+	attempts := 0
+	var providerMessageID string
+	var sendErr error
+	for attempts < s.maxAttempts {
+		attempts++
+		providerMessageID, sendErr = s.provider.Send(ctx, msg)
+		if sendErr == nil {
+			break
+		}
+		logrus.WithError(sendErr).WithField("to", to).WithField("country", country).WithField("attempt", attempts).Warn("sms: send attempt failed")
+		if attempts < s.maxAttempts {
+			time.Sleep(s.retryDelay)
+		}
+	}
+
+	log := &models.SMSLog{To: to, Country: country, Body: body, Attempts: attempts}
+	if sendErr != nil {
+		log.Status = models.SMSStatusFailed
+		log.LastError = sendErr.Error()
+	} else {
+		log.Status = models.SMSStatusSubmitted
+		log.ProviderMessageID = providerMessageID
+	}
+	if logErr := s.logs.Create(ctx, log); logErr != nil {
+		logrus.WithError(logErr).WithField("to", to).Error("sms: failed to record sms log")
+	}
+
+	return sendErr
+}
@@ -0,0 +1,60 @@
+package service
+
+import "github.com/YoungGoofy/shopping/internal/models"
+
+// StockIssue describes why a requested quantity could not be fully
+// satisfied, so the frontend can adjust the line instead of failing the
+// whole purchase.
+type StockIssue struct {
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Requested   int    `json:"requested"`
+	Available   int    `json:"available"`
+	Reason      string `json:"reason"` // "out_of_stock" or "insufficient_stock"
+}
+
+// StockValidationService checks requested quantities against a product's
+// StockQuantity, used both when adding to the cart and again at checkout
+// since stock can change between the two.
+type StockValidationService struct{}
+
+// NewStockValidationService builds a StockValidationService.
+func NewStockValidationService() *StockValidationService {
+	return &StockValidationService{}
+}
+
+// CheckQuantity validates a single requested quantity against a product's
+// stock, returning nil if it can be fully satisfied.
+func (s *StockValidationService) CheckQuantity(product models.Product, requested int) *StockIssue {
+	if product.Discontinued || product.StockQuantity <= 0 {
+		return &StockIssue{
+			ProductID:   product.ID.String(),
+			ProductName: product.Name,
+			Requested:   requested,
+			Available:   0,
+			Reason:      "out_of_stock",
+		}
+	}
+	if requested > product.StockQuantity {
+		return &StockIssue{
+			ProductID:   product.ID.String(),
+			ProductName: product.Name,
+			Requested:   requested,
+			Available:   product.StockQuantity,
+			Reason:      "insufficient_stock",
+		}
+	}
+	return nil
+}
+
+// CheckCart validates every line in a cart, returning one issue per line
+// that cannot be fully satisfied.
+func (s *StockValidationService) CheckCart(items []models.CartItem) []StockIssue {
+	var issues []StockIssue
+	for _, item := range items {
+		if issue := s.CheckQuantity(item.Product, item.Quantity); issue != nil {
+			issues = append(issues, *issue)
+		}
+	}
+	return issues
+}
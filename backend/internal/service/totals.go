@@ -0,0 +1,82 @@
+package service
+
+import (
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/google/uuid"
+)
+
+// Totals is the breakdown of a cart's price at checkout time.
+type Totals struct {
+	Subtotal       float64 `json:"subtotal"`        // sum of list prices before any discount
+	ItemDiscounts  float64 `json:"item_discounts"`  // sum of per-product DiscountPercent savings
+	CouponDiscount float64 `json:"coupon_discount"` // additional discount from an applied coupon
+	Tax            float64 `json:"tax"`
+	Shipping       float64 `json:"shipping"`
+	Total          float64 `json:"total"`
+	// ShippingMethods lists every ShippingMethod available for the cart's
+	// region with its computed price, so the client can offer a choice
+	// instead of only the flat Shipping figure above. Populated by the
+	// caller, not Calculate, since it depends on region and cart weight.
+	ShippingMethods []ShippingMethodQuote `json:"shipping_methods,omitempty"`
+}
+
+// ShippingMethodQuote is one ShippingMethod priced for a specific cart.
+type ShippingMethodQuote struct {
+	ID      uuid.UUID `json:"id"`
+	Name    string    `json:"name"`
+	Carrier string    `json:"carrier"`
+	Price   float64   `json:"price"`
+}
+
+// TotalsService derives an authoritative price breakdown for a cart, so both
+// the cart preview and the order placed from it agree on the total.
+type TotalsService struct {
+	taxRate          float64
+	taxInclusive     bool
+	shippingFlatRate float64
+	freeShippingOver float64
+}
+
+// NewTotalsService builds a TotalsService from the tax/shipping policy.
+// taxMode "inclusive" means the rate is already baked into product prices.
+func NewTotalsService(taxRate float64, taxMode string, shippingFlatRate, freeShippingOver float64) *TotalsService {
+	return &TotalsService{
+		taxRate:          taxRate,
+		taxInclusive:     taxMode == "inclusive",
+		shippingFlatRate: shippingFlatRate,
+		freeShippingOver: freeShippingOver,
+	}
+}
+
+// Calculate computes the full breakdown for a set of cart lines and an
+// optional coupon discount already validated against the cart (see
+// CouponService.Apply).
+func (s *TotalsService) Calculate(items []models.CartItem, couponDiscount float64) Totals {
+	var t Totals
+	for _, item := range items {
+		lineListPrice := item.Product.Price * float64(item.Quantity)
+		lineDiscountedPrice := item.Product.DiscountedPrice() * float64(item.Quantity)
+		t.Subtotal += lineListPrice
+		t.ItemDiscounts += lineListPrice - lineDiscountedPrice
+	}
+
+	t.CouponDiscount = couponDiscount
+	netSubtotal := t.Subtotal - t.ItemDiscounts - t.CouponDiscount
+
+	if s.taxInclusive {
+		t.Tax = netSubtotal - netSubtotal/(1+s.taxRate/100)
+	} else {
+		t.Tax = netSubtotal * s.taxRate / 100
+	}
+
+	t.Shipping = s.shippingFlatRate
+	if s.freeShippingOver > 0 && netSubtotal >= s.freeShippingOver {
+		t.Shipping = 0
+	}
+
+	t.Total = netSubtotal + t.Shipping
+	if !s.taxInclusive {
+		t.Total += t.Tax
+	}
+	return t
+}
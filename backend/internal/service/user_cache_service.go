@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/cache"
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+	"github.com/redis/go-redis/v9"
+)
+
+// userCacheTTL is how long a cached profile lives in Redis, bounding
+// staleness in case an invalidation on a write is ever missed.
+const userCacheTTL = 30 * time.Minute
+
+// userCacheL1TTL is how long a profile stays in a replica's own memory
+// before it falls back to Redis - short, since the invalidation broadcast
+// is what actually keeps it correct, not this TTL.
+const userCacheL1TTL = 5 * time.Minute
+
+// UserCacheService wraps UserRepository.GetByID with a Redis-backed cache,
+// for the profile lookup that happens on essentially every authenticated
+// request. It's backed by cache.Cache with its L1 layer enabled, so most
+// reads are served from process memory while still staying consistent
+// across replicas and surviving any one instance restarting.
+type UserCacheService struct {
+	users *psql.UserRepository
+	cache *cache.Cache
+}
+
+// NewUserCacheService builds a UserCacheService and enables its L1 layer.
+// ctx governs the lifetime of the L1 invalidation subscriber, so pass one
+// tied to the process, not a single request.
+func NewUserCacheService(ctx context.Context, users *psql.UserRepository, client *redis.Client) *UserCacheService {
+	return &UserCacheService{
+		users: users,
+		cache: cache.New(client, "user", userCacheTTL).WithL1(ctx, userCacheL1TTL),
+	}
+}
+
+// GetByID returns a user, serving from cache when possible and filling it
+// from the repository on a miss.
+func (s *UserCacheService) GetByID(ctx context.Context, id uint) (*models.User, error) {
+	key := userCacheKey(id)
+
+	var user models.User
+	hit, err := s.cache.Get(ctx, key, &user)
+	if err != nil {
+		return nil, err
+	}
+	if hit {
+		return &user, nil
+	}
+
+	fetched, err := s.users.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cache.Set(ctx, key, fetched); err != nil {
+		return nil, err
+	}
+	return fetched, nil
+}
+
+// Invalidate purges id from the cache. Call it after any write to that
+// user's row.
+func (s *UserCacheService) Invalidate(ctx context.Context, id uint) error {
+	return s.cache.Delete(ctx, userCacheKey(id))
+}
+
+// UpdateRole changes a user's role and invalidates their cached profile, so
+// a later GetByID can't serve the role they had before the change.
+func (s *UserCacheService) UpdateRole(ctx context.Context, id uint, role string) error {
+	if err := s.users.UpdateRole(ctx, id, role); err != nil {
+		return err
+	}
+	return s.Invalidate(ctx, id)
+}
+
+// SetBlocked flips whether a user can log in and invalidates their cached
+// profile, so a request already holding a valid session sees the change on
+// its next profile lookup rather than after the cache TTL expires.
+func (s *UserCacheService) SetBlocked(ctx context.Context, id uint, blocked bool) error {
+	if err := s.users.SetBlocked(ctx, id, blocked); err != nil {
+		return err
+	}
+	return s.Invalidate(ctx, id)
+}
+
+// UpdateProfile saves a user's editable profile fields and invalidates their
+// cached profile.
+func (s *UserCacheService) UpdateProfile(ctx context.Context, user *models.User) error {
+	if err := s.users.UpdateProfile(ctx, user); err != nil {
+		return err
+	}
+	return s.Invalidate(ctx, user.ID)
+}
+
+// UpdateAvatar sets or clears a user's avatar URL and invalidates their
+// cached profile.
+func (s *UserCacheService) UpdateAvatar(ctx context.Context, id uint, avatarURL string) error {
+	if err := s.users.UpdateAvatar(ctx, id, avatarURL); err != nil {
+		return err
+	}
+	return s.Invalidate(ctx, id)
+}
+
+// UpdatePreferences saves a user's communication preferences and invalidates
+// their cached profile.
+func (s *UserCacheService) UpdatePreferences(ctx context.Context, user *models.User) error {
+	if err := s.users.UpdatePreferences(ctx, user); err != nil {
+		return err
+	}
+	return s.Invalidate(ctx, user.ID)
+}
+
+// SetPreferenceColumn flips a single communication preference column and
+// invalidates the cached profile, same as UpdatePreferences. It exists
+// because the unsubscribe endpoint only ever knows the one column named in
+// the link it was given.
+func (s *UserCacheService) SetPreferenceColumn(ctx context.Context, id uint, column string, value bool) error {
+	if err := s.users.SetPreferenceColumn(ctx, id, column, value); err != nil {
+		return err
+	}
+	return s.Invalidate(ctx, id)
+}
+
+// Anonymize scrubs a user's PII, including their password hash, and
+// invalidates their cached profile so a stale copy - password hash included
+// - can never be served after the account is anonymized.
+func (s *UserCacheService) Anonymize(ctx context.Context, id uint) error {
+	if err := s.users.Anonymize(ctx, id); err != nil {
+		return err
+	}
+	return s.Invalidate(ctx, id)
+}
+
+func userCacheKey(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
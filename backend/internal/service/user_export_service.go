@@ -0,0 +1,85 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/YoungGoofy/shopping/internal/models"
+	"github.com/YoungGoofy/shopping/internal/repository/psql"
+)
+
+// UserExportArchive is a ZIP file containing a user's data export.
+type UserExportArchive struct {
+	Filename string `json:"filename"`
+	Data     []byte `json:"-"`
+}
+
+// userExportPayload is the single JSON document written into the archive.
+type userExportPayload struct {
+	Profile   models.User      `json:"profile"`
+	Addresses []models.Address `json:"addresses"`
+	Orders    []models.Order   `json:"orders"`
+	Reviews   []models.Review  `json:"reviews"`
+}
+
+// UserExportService assembles a personal-data export archive for a user, to
+// satisfy data-portability requests.
+type UserExportService struct {
+	users     *psql.UserRepository
+	addresses *psql.AddressRepository
+	orders    *psql.OrderRepository
+	reviews   *psql.ReviewRepository
+}
+
+// NewUserExportService builds a UserExportService with its dependencies.
+func NewUserExportService(users *psql.UserRepository, addresses *psql.AddressRepository, orders *psql.OrderRepository, reviews *psql.ReviewRepository) *UserExportService {
+	return &UserExportService{users: users, addresses: addresses, orders: orders, reviews: reviews}
+}
+
+// Build gathers a user's profile, addresses, orders, and reviews into a
+// single export.json and returns it zipped up.
+func (s *UserExportService) Build(ctx context.Context, userID uint) (*UserExportArchive, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	addresses, err := s.addresses.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := s.orders.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := s.reviews.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.MarshalIndent(userExportPayload{
+		Profile:   *user,
+		Addresses: addresses,
+		Orders:    orders,
+		Reviews:   reviews,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	writer, err := zw.Create("export.json")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writer.Write(payload); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return &UserExportArchive{Filename: "export.zip", Data: buf.Bytes()}, nil
+}
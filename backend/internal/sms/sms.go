@@ -0,0 +1,24 @@
+// Package sms defines the provider-agnostic interface for sending an SMS,
+// plus a concrete Twilio implementation. Retrying and logging deliveries
+// is service.SMSService's job, layered on top - same split as package
+// mailer.
+package sms
+
+import "context"
+
+// Message is a single SMS ready to hand to a Provider.
+type Message struct {
+	From string
+	To   string
+	Body string
+}
+
+// Provider is implemented by an SMS delivery backend.
+type Provider interface {
+	// Send submits msg for delivery and returns the provider's own message
+	// identifier, so a later delivery-status callback can be matched back
+	// to it. A returned error is assumed transient unless the caller knows
+	// otherwise - callers that want to retry should do so above this
+	// interface.
+	Send(ctx context.Context, msg Message) (providerMessageID string, err error)
+}
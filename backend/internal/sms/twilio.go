@@ -0,0 +1,86 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/breaker"
+)
+
+// breakerFailureThreshold and breakerCooldown match the other
+// external-dependency breakers in this codebase (search.MeilisearchEngine,
+// mailer.APIProvider); there's no traffic-derived tuning behind them.
+const (
+	breakerFailureThreshold = 5
+	breakerCooldown         = 30 * time.Second
+)
+
+// TwilioProvider sends SMS through Twilio's Messages API
+// (https://www.twilio.com/docs/sms/api/message-resource).
+type TwilioProvider struct {
+	accountSID string
+	authToken  string
+	client     *http.Client
+	breaker    *breaker.Breaker
+}
+
+// NewTwilioProvider builds a TwilioProvider authenticating with the given
+// account SID and auth token.
+func NewTwilioProvider(accountSID, authToken string) *TwilioProvider {
+	return &TwilioProvider{
+		accountSID: accountSID,
+		authToken:  authToken,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		breaker:    breaker.New(breakerFailureThreshold, breakerCooldown),
+	}
+}
+
+type twilioMessageResponse struct {
+	SID string `json:"sid"`
+}
+
+// Send submits msg through Twilio's Messages API, guarded by a circuit
+// breaker so a struggling API fails fast instead of blocking every send on
+// its timeout.
+func (t *TwilioProvider) Send(ctx context.Context, msg Message) (string, error) {
+	if !t.breaker.Allow() {
+		return "", fmt.Errorf("sms: circuit open, twilio unavailable")
+	}
+
+	form := url.Values{
+		"From": {msg.From},
+		"To":   {msg.To},
+		"Body": {msg.Body},
+	}
+
+	endpoint := fmt.Sprintf("https://api.twilio.com/2010-04-01/Accounts/%s/Messages.json", t.accountSID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(t.accountSID, t.authToken)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		t.breaker.Failure()
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		t.breaker.Failure()
+		return "", fmt.Errorf("sms: twilio responded with status %d", resp.StatusCode)
+	}
+	t.breaker.Success()
+
+	var out twilioMessageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", err
+	}
+	return out.SID, nil
+}
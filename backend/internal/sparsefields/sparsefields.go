@@ -0,0 +1,64 @@
+// Package sparsefields trims a JSON response down to the top-level fields a
+// client actually asked for via a "fields" query parameter, so a mobile
+// list view doesn't pay to transfer images/description/attributes it isn't
+// going to render. Trimming happens at serialization rather than by
+// narrowing the SQL SELECT - the affected endpoints preload associations
+// (Category, reviews) that don't map cleanly onto a partial column list,
+// and this way one helper works for all of them regardless of query shape.
+package sparsefields
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Respond writes v as JSON, keeping only the fields named in the request's
+// "fields" query parameter (comma-separated) - matched against v's own
+// top-level JSON keys, applied element-wise if v marshals to an array. With
+// no "fields" param, v is written unmodified.
+func Respond(c *gin.Context, status int, v interface{}) {
+	raw := c.Query("fields")
+	if raw == "" {
+		c.JSON(status, v)
+		return
+	}
+
+	body, err := json.Marshal(v)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to encode response"})
+		return
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		c.Data(status, "application/json; charset=utf-8", body)
+		return
+	}
+
+	fields := strings.Split(raw, ",")
+	c.JSON(status, trim(decoded, fields))
+}
+
+func trim(v interface{}, fields []string) interface{} {
+	switch val := v.(type) {
+	case []interface{}:
+		trimmed := make([]interface{}, len(val))
+		for i, item := range val {
+			trimmed[i] = trim(item, fields)
+		}
+		return trimmed
+	case map[string]interface{}:
+		trimmed := make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			if value, ok := val[field]; ok {
+				trimmed[field] = value
+			}
+		}
+		return trimmed
+	default:
+		return v
+	}
+}
@@ -0,0 +1,16 @@
+package utils
+
+import "strings"
+
+// SanitizeCSVField neutralizes formula/DDE injection in a value bound for a
+// CSV cell: if it starts with '=', '+', '-', or '@' - the characters
+// Excel/Sheets treat as the start of a formula - it's prefixed with a
+// single quote, which spreadsheet apps render literally instead of
+// evaluating. Only apply this to free-text fields that came from an end
+// user; it isn't needed for values the application itself generated.
+func SanitizeCSVField(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
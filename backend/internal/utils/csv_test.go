@@ -0,0 +1,19 @@
+package utils
+
+import "testing"
+
+func TestSanitizeCSVField(t *testing.T) {
+	cases := map[string]string{
+		"=HYPERLINK(\"http://evil\")": "'=HYPERLINK(\"http://evil\")",
+		"+1234567890":                 "'+1234567890",
+		"-1234567890":                 "'-1234567890",
+		"@SUM(A1:A2)":                 "'@SUM(A1:A2)",
+		"regular note":                "regular note",
+		"":                            "",
+	}
+	for input, want := range cases {
+		if got := SanitizeCSVField(input); got != want {
+			t.Errorf("SanitizeCSVField(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims is the payload embedded in access tokens.
+type Claims struct {
+	UserID uint   `json:"user_id"`
+	Role   string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// GenerateToken signs a new JWT for userID valid for expiry.
+func GenerateToken(secret string, userID uint, role string, expiry time.Duration) (string, error) {
+	claims := Claims{
+		UserID: userID,
+		Role:   role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiry)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secret))
+}
+
+// ParseToken validates tokenString and returns its claims.
+func ParseToken(secret, tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("utils: invalid token")
+	}
+	return claims, nil
+}
+
+// UnsubscribeClaims identifies which notification kind an unsubscribe link
+// turns off, and for whom. It carries no expiry, since a link sent in an
+// old email should still work.
+type UnsubscribeClaims struct {
+	UserID uint   `json:"user_id"`
+	Kind   string `json:"kind"`
+	jwt.RegisteredClaims
+}
+
+// GenerateUnsubscribeToken signs an unsubscribe link token for userID/kind.
+func GenerateUnsubscribeToken(secret string, userID uint, kind string) (string, error) {
+	claims := UnsubscribeClaims{
+		UserID: userID,
+		Kind:   kind,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secret))
+}
+
+// ParseUnsubscribeToken validates an unsubscribe link token and returns its claims.
+func ParseUnsubscribeToken(secret, tokenString string) (*UnsubscribeClaims, error) {
+	claims := &UnsubscribeClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("utils: invalid token")
+	}
+	return claims, nil
+}
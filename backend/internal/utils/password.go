@@ -0,0 +1,18 @@
+// Package utils holds small stateless helpers shared across handlers.
+package utils
+
+import "golang.org/x/crypto/bcrypt"
+
+// HashPassword returns the bcrypt hash of a plaintext password.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword reports whether password matches the given bcrypt hash.
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
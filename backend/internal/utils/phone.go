@@ -0,0 +1,24 @@
+package utils
+
+import "regexp"
+
+// e164Pattern matches a phone number in E.164 form: a leading '+', a
+// non-zero first digit, and up to 15 digits total.
+var e164Pattern = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
+
+// stripPhoneFormatting removes spaces, dashes, dots, and parentheses so a
+// number like "+7 (900) 123-45-67" normalizes to "+79001234567" before
+// E.164 validation.
+var phoneFormattingChars = regexp.MustCompile(`[\s\-().]`)
+
+// NormalizePhone strips common formatting characters from a phone number,
+// leaving digits and a leading '+' (if present) untouched.
+func NormalizePhone(raw string) string {
+	return phoneFormattingChars.ReplaceAllString(raw, "")
+}
+
+// IsValidE164 reports whether phone (after NormalizePhone) is a valid
+// E.164 number.
+func IsValidE164(phone string) bool {
+	return e164Pattern.MatchString(phone)
+}
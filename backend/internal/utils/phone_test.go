@@ -0,0 +1,45 @@
+package utils
+
+import "testing"
+
+func TestNormalizePhone(t *testing.T) {
+	cases := map[string]string{
+		"+7 (900) 123-45-67": "+79001234567",
+		"+7.900.123.45.67":   "+79001234567",
+		"+15551234567":       "+15551234567",
+		"":                   "",
+	}
+	for input, want := range cases {
+		if got := NormalizePhone(input); got != want {
+			t.Errorf("NormalizePhone(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestIsValidE164(t *testing.T) {
+	valid := []string{"+79001234567", "+15551234567", "+447911123456"}
+	for _, phone := range valid {
+		if !IsValidE164(phone) {
+			t.Errorf("IsValidE164(%q) = false, want true", phone)
+		}
+	}
+
+	invalid := []string{
+		"89001234567",       // missing leading '+'
+		"+0900123456",       // leading zero after '+' is not allowed
+		"+7900123456789012", // too many digits
+		"+7 900 123 45 67",  // not normalized: contains spaces
+		"",
+	}
+	for _, phone := range invalid {
+		if IsValidE164(phone) {
+			t.Errorf("IsValidE164(%q) = true, want false", phone)
+		}
+	}
+}
+
+func TestNormalizeThenValidate(t *testing.T) {
+	if !IsValidE164(NormalizePhone("+7 (900) 123-45-67")) {
+		t.Error("a formatted number should validate once normalized")
+	}
+}
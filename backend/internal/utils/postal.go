@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// postalPatterns holds a postal code regex for countries we know the format
+// for. Countries not listed here fall back to a simple non-empty check.
+var postalPatterns = map[string]*regexp.Regexp{
+	"RU": regexp.MustCompile(`^\d{6}$`),
+	"US": regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+	"GB": regexp.MustCompile(`^[A-Za-z]{1,2}\d[A-Za-z\d]?\s?\d[A-Za-z]{2}$`),
+	"DE": regexp.MustCompile(`^\d{5}$`),
+	"FR": regexp.MustCompile(`^\d{5}$`),
+}
+
+// IsValidPostalCode reports whether postalCode matches the known format for
+// country, an ISO 3166-1 alpha-2 code such as "RU". A country we have no
+// pattern for is accepted as long as postalCode is non-empty.
+func IsValidPostalCode(country, postalCode string) bool {
+	if postalCode == "" {
+		return false
+	}
+	pattern, ok := postalPatterns[strings.ToUpper(country)]
+	if !ok {
+		return true
+	}
+	return pattern.MatchString(postalCode)
+}
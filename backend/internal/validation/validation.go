@@ -0,0 +1,87 @@
+// Package validation translates go-playground/validator failures (the
+// engine behind gin's c.ShouldBindJSON) into the apperr response envelope,
+// so a bad request payload comes back as a per-field validation_error
+// instead of a raw validator error string.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/YoungGoofy/shopping/internal/apperr"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// UseJSONFieldNames makes validator report a struct's json tag (e.g.
+// "phone") instead of its Go field name (e.g. "Phone") in FieldError.Field,
+// so the "field" a client sees in a 400 response matches the key it sent.
+// Call this once at startup, before any request is served.
+func UseJSONFieldNames() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+}
+
+// FieldError is one field's validation failure.
+type FieldError struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+}
+
+// BindJSON decodes and validates the request body into req. On failure it
+// writes the standard apperr envelope (400, CodeValidation, one FieldError
+// per failed tag) and returns false; callers should return immediately when
+// it does. On success it returns true and req is populated.
+func BindJSON(c *gin.Context, req interface{}) bool {
+	err := c.ShouldBindJSON(req)
+	if err == nil {
+		return true
+	}
+	apperr.RespondCode(c, http.StatusBadRequest, apperr.CodeValidation, "validation failed", translate(err))
+	return false
+}
+
+func translate(err error) []FieldError {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return []FieldError{{Field: "_", Rule: err.Error()}}
+	}
+
+	fields := make([]FieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		fields = append(fields, FieldError{Field: fe.Field(), Rule: describe(fe)})
+	}
+	return fields
+}
+
+func describe(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "min":
+		return fmt.Sprintf("must be at least %s characters", fe.Param())
+	case "max":
+		return fmt.Sprintf("must be at most %s characters", fe.Param())
+	case "e164":
+		return "must be a valid E.164 phone number"
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", fe.Param())
+	default:
+		return "failed " + fe.Tag() + " validation"
+	}
+}
@@ -0,0 +1,128 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// recordSize is the aes128gcm record size we advertise in the header. A
+// push payload is small enough to always fit in one record, so this is
+// just "big enough for anything we send", not a real chunking parameter.
+const recordSize = 4096
+
+// encrypt implements RFC 8291 (Message Encryption for Web Push), producing
+// an aes128gcm content-coded body ready to POST to sub.Endpoint.
+func encrypt(sub Subscription, plaintext []byte) ([]byte, error) {
+	clientPub, err := decodeP256PublicKey(sub.P256DH)
+	if err != nil {
+		return nil, fmt.Errorf("webpush: invalid subscription key: %w", err)
+	}
+	authSecret, err := base64.RawURLEncoding.DecodeString(sub.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("webpush: invalid subscription auth secret: %w", err)
+	}
+
+	curve := ecdh.P256()
+	ephemeral, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	sharedSecret, err := ephemeral.ECDH(clientPub)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	ephPubBytes := ephemeral.PublicKey().Bytes()
+	clientPubBytes := clientPub.Bytes()
+
+	ikm, err := webPushIKM(sharedSecret, authSecret, clientPubBytes, ephPubBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	cek, err := hkdfExpand(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdfExpand(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// RFC 8291 pads the plaintext with a single 0x02 delimiter (no further
+	// padding record follows), then GCM-seals it.
+	padded := append(append([]byte{}, plaintext...), 0x02)
+	sealed := gcm.Seal(nil, nonce, padded, nil)
+
+	header := recordHeader(salt, ephPubBytes)
+	return append(header, sealed...), nil
+}
+
+// recordHeader builds the aes128gcm record header defined in RFC 8188:
+// a 16-byte salt, the 4-byte big-endian record size, a 1-byte key id
+// length, and the key id itself (here, the sender's ephemeral public key,
+// as RFC 8291 requires).
+func recordHeader(salt, keyID []byte) []byte {
+	header := make([]byte, 16+4+1+len(keyID))
+	copy(header, salt)
+	binary.BigEndian.PutUint32(header[16:20], recordSize)
+	header[20] = byte(len(keyID))
+	copy(header[21:], keyID)
+	return header
+}
+
+// webPushIKM derives the input keying material for the content-encryption
+// key and nonce, per RFC 8291 section 3.3: an "auth" info-keyed HKDF over
+// the ECDH shared secret, then a second, key-specific info string built
+// from both parties' public keys.
+func webPushIKM(sharedSecret, authSecret, clientPub, serverPub []byte) ([]byte, error) {
+	prk, err := hkdfExpand(sharedSecret, authSecret, []byte("WebPush: info\x00"+string(clientPub)+string(serverPub)), 32)
+	if err != nil {
+		return nil, err
+	}
+	return prk, nil
+}
+
+// hkdfExpand runs full HKDF-Extract-then-Expand (RFC 5869) over secret
+// with the given salt and info, returning length bytes.
+func hkdfExpand(secret, salt, info []byte, length int) ([]byte, error) {
+	reader := hkdf.New(sha256.New, secret, salt, info)
+	out := make([]byte, length)
+	if _, err := io.ReadFull(reader, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// decodeP256PublicKey parses a base64url-encoded, uncompressed P-256
+// point, as browsers supply in a subscription's p256dh key.
+func decodeP256PublicKey(encoded string) (*ecdh.PublicKey, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return ecdh.P256().NewPublicKey(raw)
+}
@@ -0,0 +1,117 @@
+package webpush
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+)
+
+// decrypt reverses encrypt, playing the part of the browser: given the
+// subscription's private key material and the aes128gcm body encrypt
+// produced, it recovers the original plaintext. It exists only to give
+// this test something to assert against, since RFC 8291 has no public
+// test vectors that use fresh, randomly generated keys.
+func decrypt(t *testing.T, clientPriv *ecdh.PrivateKey, authSecret []byte, body []byte) []byte {
+	t.Helper()
+
+	if len(body) < 21 {
+		t.Fatalf("body too short to contain a record header: %d bytes", len(body))
+	}
+	salt := body[:16]
+	keyIDLen := int(body[20])
+	if len(body) < 21+keyIDLen {
+		t.Fatalf("body too short to contain a %d-byte key id", keyIDLen)
+	}
+	serverPubBytes := body[21 : 21+keyIDLen]
+	sealed := body[21+keyIDLen:]
+
+	serverPub, err := ecdh.P256().NewPublicKey(serverPubBytes)
+	if err != nil {
+		t.Fatalf("parse server public key: %v", err)
+	}
+	sharedSecret, err := clientPriv.ECDH(serverPub)
+	if err != nil {
+		t.Fatalf("ECDH: %v", err)
+	}
+
+	ikm, err := webPushIKM(sharedSecret, authSecret, clientPriv.PublicKey().Bytes(), serverPubBytes)
+	if err != nil {
+		t.Fatalf("derive IKM: %v", err)
+	}
+	cek, err := hkdfExpand(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		t.Fatalf("derive CEK: %v", err)
+	}
+	nonce, err := hkdfExpand(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		t.Fatalf("derive nonce: %v", err)
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		t.Fatalf("new cipher: %v", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatalf("new GCM: %v", err)
+	}
+	padded, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		t.Fatalf("GCM open: %v", err)
+	}
+
+	if len(padded) == 0 || padded[len(padded)-1] != 0x02 {
+		t.Fatalf("plaintext missing 0x02 padding delimiter: %x", padded)
+	}
+	return padded[:len(padded)-1]
+}
+
+func TestEncryptRoundTrip(t *testing.T) {
+	curve := ecdh.P256()
+	clientPriv, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate client key: %v", err)
+	}
+	authSecret := make([]byte, 16)
+	if _, err := rand.Read(authSecret); err != nil {
+		t.Fatalf("generate auth secret: %v", err)
+	}
+
+	sub := Subscription{
+		Endpoint: "https://push.example.com/subscription/abc",
+		P256DH:   base64.RawURLEncoding.EncodeToString(clientPriv.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(authSecret),
+	}
+	plaintext := []byte(`{"title":"Order shipped","body":"Your order is on its way"}`)
+
+	body, err := encrypt(sub, plaintext)
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+
+	recordSizeBytes := body[16:20]
+	if got := binary.BigEndian.Uint32(recordSizeBytes); got != recordSize {
+		t.Errorf("record size header = %d, want %d", got, recordSize)
+	}
+
+	got := decrypt(t, clientPriv, authSecret, body)
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("decrypted plaintext = %q, want %q", got, plaintext)
+	}
+}
+
+func TestEncryptRejectsInvalidSubscriptionKey(t *testing.T) {
+	sub := Subscription{
+		Endpoint: "https://push.example.com/subscription/abc",
+		P256DH:   "not-a-valid-key",
+		Auth:     base64.RawURLEncoding.EncodeToString(make([]byte, 16)),
+	}
+	if _, err := encrypt(sub, []byte("hi")); err == nil {
+		t.Fatal("expected an error for an invalid p256dh key, got nil")
+	}
+}
@@ -0,0 +1,82 @@
+package webpush
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// vapidTokenTTL is how long a VAPID JWT is valid for. RFC 8292 recommends
+// no more than 24 hours; we use a much shorter window since a fresh token
+// is cheap to mint per-send and a stolen one is less useful if it expires
+// quickly.
+const vapidTokenTTL = 12 * time.Hour
+
+// GenerateVAPIDKeys creates a new P-256 key pair suitable for VAPIDKeys.
+// It's a setup-time helper, not something called from a request path -
+// operators run it once and paste the result into config.
+func GenerateVAPIDKeys(subject string) (VAPIDKeys, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return VAPIDKeys{}, err
+	}
+	pub := elliptic.Marshal(elliptic.P256(), key.X, key.Y)
+	priv := key.D.FillBytes(make([]byte, 32))
+	return VAPIDKeys{
+		Subject:    subject,
+		PublicKey:  base64.RawURLEncoding.EncodeToString(pub),
+		PrivateKey: base64.RawURLEncoding.EncodeToString(priv),
+	}, nil
+}
+
+// vapidAuthorizationHeader builds the "vapid t=<jwt>, k=<publicKey>" header
+// RFC 8292 requires on every push request, scoped to endpoint's origin.
+func (s *Sender) vapidAuthorizationHeader(endpoint string) (string, error) {
+	aud, err := audienceFor(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	privBytes, err := base64.RawURLEncoding.DecodeString(s.vapid.PrivateKey)
+	if err != nil {
+		return "", fmt.Errorf("webpush: invalid VAPID private key: %w", err)
+	}
+	curve := elliptic.P256()
+	priv := new(ecdsa.PrivateKey)
+	priv.PublicKey.Curve = curve
+	priv.D = new(big.Int).SetBytes(privBytes)
+	priv.PublicKey.X, priv.PublicKey.Y = curve.ScalarBaseMult(privBytes)
+
+	claims := jwt.RegisteredClaims{
+		Audience:  jwt.ClaimStrings{aud},
+		Subject:   s.vapid.Subject,
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(vapidTokenTTL)),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(priv)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("vapid t=%s, k=%s", token, s.vapid.PublicKey), nil
+}
+
+// audienceFor returns the scheme+host of endpoint, which VAPID's aud claim
+// must be scoped to rather than the full push URL.
+func audienceFor(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", errors.New("webpush: subscription endpoint is not an absolute URL")
+	}
+	return u.Scheme + "://" + u.Host, nil
+}
@@ -0,0 +1,116 @@
+// Package webpush sends Web Push notifications (RFC 8030) to a browser's
+// push service, encrypting the payload per RFC 8291 (aes128gcm) and
+// authenticating with VAPID (RFC 8292). It has no opinion on who gets
+// notified or why - that's service.PushNotificationService's job; this
+// package only knows how to deliver one payload to one subscription.
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/YoungGoofy/shopping/internal/breaker"
+)
+
+const (
+	breakerFailureThreshold = 5
+	breakerCooldown         = 30 * time.Second
+)
+
+// Subscription is the browser-supplied endpoint and keys from a
+// PushManager.subscribe() call, as stored in models.PushSubscription.
+type Subscription struct {
+	Endpoint string
+	P256DH   string // base64url-encoded uncompressed EC public key
+	Auth     string // base64url-encoded auth secret
+}
+
+// ErrGone means the push service reports the subscription no longer
+// exists (HTTP 404/410) - the caller should delete it rather than retry.
+var ErrGone = errors.New("webpush: subscription is gone")
+
+// VAPIDKeys is a subject/public/private key triple used to sign the
+// Authorization header sent with every push, so the push service can
+// identify (and rate-limit or block) the sender without a shared secret.
+type VAPIDKeys struct {
+	// Subject identifies the sender to the push service, per RFC 8292 -
+	// conventionally a mailto: or https: URL.
+	Subject string
+	// PublicKey and PrivateKey are base64url-encoded, uncompressed
+	// P-256 key material, generated once and stored in config.
+	PublicKey  string
+	PrivateKey string
+}
+
+// Sender delivers encrypted push messages to browsers' push services.
+type Sender struct {
+	vapid   VAPIDKeys
+	ttl     time.Duration
+	client  *http.Client
+	breaker *breaker.Breaker
+}
+
+// NewSender builds a Sender that signs every push with vapid and asks the
+// push service to hold undelivered messages for ttl before giving up.
+func NewSender(vapid VAPIDKeys, ttl time.Duration) *Sender {
+	return &Sender{
+		vapid:   vapid,
+		ttl:     ttl,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		breaker: breaker.New(breakerFailureThreshold, breakerCooldown),
+	}
+}
+
+// Send encrypts payload for sub and delivers it to the browser's push
+// service. It returns ErrGone if the push service reports the
+// subscription no longer exists.
+func (s *Sender) Send(ctx context.Context, sub Subscription, payload []byte) error {
+	if !s.breaker.Allow() {
+		return errors.New("webpush: push service circuit open")
+	}
+
+	body, err := encrypt(sub, payload)
+	if err != nil {
+		s.breaker.Failure()
+		return err
+	}
+
+	authHeader, err := s.vapidAuthorizationHeader(sub.Endpoint)
+	if err != nil {
+		s.breaker.Failure()
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		s.breaker.Failure()
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(int(s.ttl.Seconds())))
+	req.Header.Set("Authorization", authHeader)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.breaker.Failure()
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		s.breaker.Success()
+		return ErrGone
+	case resp.StatusCode >= 300:
+		s.breaker.Failure()
+		return errors.New("webpush: push service returned " + resp.Status)
+	}
+
+	s.breaker.Success()
+	return nil
+}